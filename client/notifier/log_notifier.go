@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/client"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+// NewLogNotifier returns a notifier that only logs alerts, for environments without an email or
+// push provider.
+func NewLogNotifier() client.NotifierClient {
+	return &LogNotifier{}
+}
+
+type LogNotifier struct {
+}
+
+func (n LogNotifier) Notify(ctx context.Context, alert model.ProductAlertModel) error {
+	switch alert.Type {
+	case "price_drop":
+		common.NewLogger().Info("Price drop alert to ", alert.Email, ": ", alert.ProductName, " now ", alert.NewPrice, " (was ", alert.OldPrice, ")")
+	case "back_in_stock":
+		common.NewLogger().Info("Back in stock alert to ", alert.Email, ": ", alert.ProductName, " has ", alert.Stock, " in stock")
+	}
+	return nil
+}
+
+func (n LogNotifier) NotifyCartReminder(ctx context.Context, reminder model.CartReminderModel) error {
+	common.NewLogger().Info("Cart reminder ", reminder.Stage, " to ", reminder.Email, ": ", len(reminder.Items), " items worth ", reminder.CartValue, " in ", reminder.CartName)
+	if reminder.CouponCode != "" {
+		common.NewLogger().Info("Cart reminder coupon for ", reminder.Email, ": ", reminder.CouponCode, " for ", reminder.CouponPercent, "% off until ", reminder.CouponEndsAt)
+	}
+	return nil
+}
+
+func (n LogNotifier) NotifyOrder(ctx context.Context, notification model.OrderNotificationModel) error {
+	switch notification.Type {
+	case "expired":
+		common.NewLogger().Info("Order expired notice to ", notification.Email, ": order ", notification.Number, " of ", notification.Total, " was not paid in time and is cancelled")
+	}
+	return nil
+}
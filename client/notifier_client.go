@@ -0,0 +1,14 @@
+package client
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+// NotifierClient delivers alerts, reminders and order updates to customers. Implementations report
+// delivery failures as errors, so alerts and reminders are retried.
+type NotifierClient interface {
+	Notify(ctx context.Context, alert model.ProductAlertModel) error
+	NotifyCartReminder(ctx context.Context, reminder model.CartReminderModel) error
+	NotifyOrder(ctx context.Context, notification model.OrderNotificationModel) error
+}
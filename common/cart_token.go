@@ -0,0 +1,64 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CartTokenHeader = "X-Cart-Token"
+	CartTokenCookie = "cart_token"
+	// CartTokenLifetime is how long a guest cart can be reached with its token.
+	CartTokenLifetime = 30 * 24 * time.Hour
+)
+
+// ErrCartTokenExpired is returned for a well signed cart token past its expiry.
+var ErrCartTokenExpired = errors.New("cart token expired")
+
+// GenerateCartToken issues the token of a new guest cart: a random guest id, its expiry and their
+// signature.
+func GenerateCartToken(config configuration.Config) (string, string) {
+	guestId := uuid.New().String()
+	return cartToken(guestId, time.Now().Add(CartTokenLifetime), config), guestId
+}
+
+// ParseCartToken returns the guest id of a cart token after checking its signature and expiry.
+func ParseCartToken(token string, config configuration.Config) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("invalid cart token")
+	}
+	guestId, expires, signature := parts[0], parts[1], parts[2]
+	if !hmac.Equal([]byte(signature), []byte(cartTokenSignature(guestId+"."+expires, config))) {
+		return "", errors.New("invalid cart token")
+	}
+	if _, err := uuid.Parse(guestId); err != nil {
+		return "", errors.New("invalid cart token")
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", errors.New("invalid cart token")
+	}
+	if time.Now().Unix() >= expiresAt {
+		return "", ErrCartTokenExpired
+	}
+	return guestId, nil
+}
+
+func cartToken(guestId string, expiresAt time.Time, config configuration.Config) string {
+	payload := guestId + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + cartTokenSignature(payload, config)
+}
+
+func cartTokenSignature(payload string, config configuration.Config) string {
+	mac := hmac.New(sha256.New, []byte(config.Get("JWT_SECRET_KEY")))
+	mac.Write([]byte("cart:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type cartTokenTestConfig map[string]string
+
+func (config cartTokenTestConfig) Get(key string) string {
+	return config[key]
+}
+
+func TestParseCartToken(t *testing.T) {
+	config := cartTokenTestConfig{"JWT_SECRET_KEY": "secret"}
+	token, guestId := GenerateCartToken(config)
+
+	parsed, err := ParseCartToken(token, config)
+	if err != nil || parsed != guestId {
+		t.Fatalf("ParseCartToken(%q) = %q, %v; want %q", token, parsed, err, guestId)
+	}
+
+	payload := token[:strings.LastIndex(token, ".")]
+	signature := token[strings.LastIndex(token, ".")+1:]
+	expires := payload[strings.Index(payload, ".")+1:]
+	tampered := []string{
+		"",
+		guestId,
+		payload,
+		payload + ".",
+		guestId + "." + signature,
+		"00000000-0000-0000-0000-000000000000." + expires + "." + signature,
+		guestId + ".9999999999." + signature,
+		"not-a-uuid." + expires + "." + cartTokenSignature("not-a-uuid."+expires, config),
+		guestId + ".soon." + cartTokenSignature(guestId+".soon", config),
+	}
+	for _, token := range tampered {
+		if _, err := ParseCartToken(token, config); err == nil {
+			t.Errorf("ParseCartToken(%q) accepted a tampered token", token)
+		}
+	}
+
+	if _, err := ParseCartToken(token, cartTokenTestConfig{"JWT_SECRET_KEY": "other"}); err == nil {
+		t.Error("ParseCartToken accepted a token signed with another secret")
+	}
+}
+
+func TestParseCartToken_Expired(t *testing.T) {
+	config := cartTokenTestConfig{"JWT_SECRET_KEY": "secret"}
+	guestId := "3f1d8a8e-6a4b-4c53-9f64-2d5b8f1a7c10"
+
+	if _, err := ParseCartToken(cartToken(guestId, time.Now().Add(-time.Minute), config), config); err != ErrCartTokenExpired {
+		t.Errorf("ParseCartToken of an expired token = %v; want %v", err, ErrCartTokenExpired)
+	}
+	if parsed, err := ParseCartToken(cartToken(guestId, time.Now().Add(time.Minute), config), config); err != nil || parsed != guestId {
+		t.Errorf("ParseCartToken of a token about to expire = %q, %v; want %q", parsed, err, guestId)
+	}
+}
@@ -3,26 +3,56 @@ package common
 import (
 	"encoding/json"
 	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/money"
 	"github.com/go-playground/validator/v10"
+	"reflect"
 )
 
 func Validate(modelValidate interface{}) {
+	messages := ValidationMessages(modelValidate)
+	if len(messages) > 0 {
+		panic(NewValidationErrors(messages))
+	}
+}
+
+// ValidationMessages returns the field and message of every failed rule without panicking,
+// for callers such as bulk imports that report errors per record.
+func ValidationMessages(modelValidate interface{}) []map[string]interface{} {
 	validate := validator.New()
+	// Amounts are validated in cents, e.g. min=100 is one shilling
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(money.Money).Cents()
+	}, money.Money{})
 	err := validate.Struct(modelValidate)
-	if err != nil {
-		var messages []map[string]interface{}
-		for _, err := range err.(validator.ValidationErrors) {
-			messages = append(messages, map[string]interface{}{
-				"field":   err.Field(),
-				"message": "this field is " + err.Tag(),
-			})
-		}
-
-		jsonMessage, errJson := json.Marshal(messages)
-		exception.PanicLogging(errJson)
+	if err == nil {
+		return nil
+	}
 
-		panic(exception.ValidationError{
-			Message: string(jsonMessage),
+	var messages []map[string]interface{}
+	for _, err := range err.(validator.ValidationErrors) {
+		messages = append(messages, map[string]interface{}{
+			"field":   err.Field(),
+			"message": "this field is " + err.Tag(),
 		})
 	}
+	return messages
+}
+
+func NewValidationError(field string, message string) exception.ValidationError {
+	return NewValidationErrors([]map[string]interface{}{
+		{
+			"field":   field,
+			"message": message,
+		},
+	})
+}
+
+// NewValidationErrors reports several field/message pairs in one ValidationError.
+func NewValidationErrors(messages []map[string]interface{}) exception.ValidationError {
+	jsonMessage, err := json.Marshal(messages)
+	exception.PanicLogging(err)
+
+	return exception.ValidationError{
+		Message: string(jsonMessage),
+	}
 }
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"testing"
+)
+
+func TestValidationMessages_Money(t *testing.T) {
+	type request struct {
+		Price    money.Money  `validate:"required,gt=0"`
+		Fee      money.Money  `validate:"min=0"`
+		MinSpend *money.Money `validate:"omitempty,min=100"`
+	}
+
+	tooLow := money.New(0, 99)
+	cases := map[string]struct {
+		request request
+		fields  []string
+	}{
+		"valid":            {request: request{Price: money.New(10, 0)}},
+		"missing price":    {request: request{}, fields: []string{"Price"}},
+		"negative amounts": {request: request{Price: money.New(-1, 0), Fee: money.New(0, -1)}, fields: []string{"Price", "Fee"}},
+		"bounds are cents": {request: request{Price: money.New(10, 0), MinSpend: &tooLow}, fields: []string{"MinSpend"}},
+	}
+	for name, c := range cases {
+		var fields []string
+		for _, message := range ValidationMessages(c.request) {
+			fields = append(fields, message["field"].(string))
+		}
+		if len(fields) != len(c.fields) {
+			t.Errorf("%s: ValidationMessages fields = %v; want %v", name, fields, c.fields)
+			continue
+		}
+		for i := range fields {
+			if fields[i] != c.fields[i] {
+				t.Errorf("%s: ValidationMessages fields = %v; want %v", name, fields, c.fields)
+			}
+		}
+	}
+}
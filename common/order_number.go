@@ -0,0 +1,73 @@
+package common
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// orderNumberRandomDigits is how many random digits follow the date, so numbers do not tell how
+// many orders were placed. A million per day keeps collisions rare; a clash is retried.
+const orderNumberRandomDigits = 6
+
+// NewOrderNumber numbers an order placed on date, e.g. TH-261019-4827315: the prefix, the date
+// as YYMMDD, random digits and a Luhn check digit over the date and random digits, which catches a
+// mistyped digit or two swapped neighbouring ones.
+func NewOrderNumber(prefix string, date time.Time) string {
+	random, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		panic(err)
+	}
+	digits := date.Format("060102") + leftPad(random.String(), orderNumberRandomDigits)
+	return prefix + "-" + digits[:6] + "-" + digits[6:] + string(luhnCheckDigit(digits))
+}
+
+// ParseOrderNumber reads an order number as a customer may type or say it, in any case and with
+// or without hyphens and spaces, and returns it as it is stored. Numbers with a wrong check
+// digit are rejected.
+func ParseOrderNumber(text string) (string, error) {
+	compact := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(text))
+	digitsAt := strings.IndexFunc(compact, func(r rune) bool { return r < 'A' || r > 'Z' })
+	if digitsAt <= 0 {
+		return "", errors.New("invalid order number")
+	}
+	prefix, digits := compact[:digitsAt], compact[digitsAt:]
+	if len(digits) != 6+orderNumberRandomDigits+1 || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return "", errors.New("invalid order number")
+	}
+	if luhnCheckDigit(digits[:len(digits)-1]) != digits[len(digits)-1] {
+		return "", errors.New("invalid order number")
+	}
+	return prefix + "-" + digits[:6] + "-" + digits[6:], nil
+}
+
+// OrderAccountReference shortens an order number to the 12 characters M-Pesa allows in an account
+// reference, e.g. 261019482731 for TH-261019-4827315: its date and random digits, which tell the
+// orders of a shop apart, without the fixed prefix, the hyphens and the check digit.
+func OrderAccountReference(number string) string {
+	digits := strings.NewReplacer("-", "", " ", "").Replace(number)
+	digits = strings.TrimLeftFunc(digits, func(r rune) bool { return r >= 'A' && r <= 'Z' })
+	return digits[:6+orderNumberRandomDigits]
+}
+
+// luhnCheckDigit is the digit that makes digits followed by it pass the Luhn check.
+func luhnCheckDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i -= 2 {
+		doubled := int(digits[i]-'0') * 2
+		if doubled > 9 {
+			doubled -= 9
+		}
+		sum += doubled
+		if i > 0 {
+			sum += int(digits[i-1] - '0')
+		}
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func leftPad(digits string, length int) string {
+	return strings.Repeat("0", length-len(digits)) + digits
+}
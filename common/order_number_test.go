@@ -0,0 +1,48 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLuhnCheckDigit(t *testing.T) {
+	if digit := luhnCheckDigit("7992739871"); digit != '3' {
+		t.Fatalf("luhnCheckDigit(7992739871) = %c; want 3", digit)
+	}
+}
+
+func TestParseOrderNumber(t *testing.T) {
+	number := NewOrderNumber("TH", time.Date(2026, 10, 19, 15, 4, 0, 0, time.Local))
+	if !strings.HasPrefix(number, "TH-261019-") || len(number) != len("TH-261019-4827315") {
+		t.Fatalf("NewOrderNumber = %q; want TH-261019-NNNNNNC", number)
+	}
+
+	spoken := []string{number, strings.ToLower(number), strings.ReplaceAll(number, "-", ""), strings.ReplaceAll(number, "-", " ")}
+	for _, text := range spoken {
+		if parsed, err := ParseOrderNumber(text); err != nil || parsed != number {
+			t.Errorf("ParseOrderNumber(%q) = %q, %v; want %q", text, parsed, err, number)
+		}
+	}
+
+	digits := []byte(number)
+	digits[len(digits)-2], digits[len(digits)-3] = digits[len(digits)-3], digits[len(digits)-2]
+	invalid := []string{"", "42", "TH-261019", "-2610194827315", "TH-261019-48273150", "TH-26A019-4827315"}
+	if digits[len(digits)-2] != digits[len(digits)-3] {
+		invalid = append(invalid, string(digits))
+	}
+	for _, text := range invalid {
+		if _, err := ParseOrderNumber(text); err == nil {
+			t.Errorf("ParseOrderNumber(%q) accepted an invalid number", text)
+		}
+	}
+}
+
+func TestOrderAccountReference(t *testing.T) {
+	if reference := OrderAccountReference("TH-261019-4827315"); reference != "261019482731" {
+		t.Fatalf("OrderAccountReference(TH-261019-4827315) = %q; want 261019482731", reference)
+	}
+	if reference := OrderAccountReference(NewOrderNumber("SHOP", time.Now())); len(reference) != 12 {
+		t.Fatalf("OrderAccountReference = %q; want 12 characters", reference)
+	}
+}
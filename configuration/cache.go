@@ -0,0 +1,260 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/go-redis/redis/v9"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProductCatalogueCacheNamespace groups every cached response of the public product listing.
+const ProductCatalogueCacheNamespace = "product_catalogue"
+
+const (
+	cacheLockTtl      = 5 * time.Second
+	cacheLockWait     = 50 * time.Millisecond
+	cacheLockAttempts = 20
+)
+
+// CachePolicy controls how long entries under one key prefix are kept.
+type CachePolicy struct {
+	Ttl time.Duration
+	// NegativeTtl is how long a not-found lookup is remembered; zero disables negative caching.
+	NegativeTtl time.Duration
+}
+
+// NewCachePolicy reads CACHE_<PREFIX>_TTL_SECONDS and CACHE_<PREFIX>_NEGATIVE_TTL_SECONDS.
+func NewCachePolicy(config Config, prefix string) CachePolicy {
+	name := "CACHE_" + strings.ToUpper(prefix)
+	ttl, err := strconv.Atoi(config.Get(name + "_TTL_SECONDS"))
+	exception.PanicLogging(err)
+	negativeTtl, err := strconv.Atoi(config.Get(name + "_NEGATIVE_TTL_SECONDS"))
+	exception.PanicLogging(err)
+
+	return CachePolicy{
+		Ttl:         time.Duration(ttl) * time.Second,
+		NegativeTtl: time.Duration(negativeTtl) * time.Second,
+	}
+}
+
+// cacheEntry is what SetCache stores. Entries written before it existed decode as a
+// miss and are replaced on the next load.
+type cacheEntry struct {
+	Value    json.RawMessage `json:"value,omitempty"`
+	NotFound string          `json:"not_found,omitempty"`
+}
+
+type cacheResult[T any] struct {
+	value    T
+	notFound string
+	err      error // a failed load, which is never cached
+}
+
+// SetCache returns the value cached under prefix_key, loading it with executeData on a miss.
+// Concurrent misses share a single load, within the process and across instances through a
+// short Redis lock. Not-found results are cached for policy.NegativeTtl, other errors of
+// executeData are not cached, and when Redis is unreachable the value is read straight from
+// executeData instead of failing the request.
+func SetCache[T any](cacheManager *redis.Client, ctx context.Context, policy CachePolicy, prefix string, key string, executeData func(context.Context, string) (T, error)) *T {
+	cacheKey := prefix + "_" + key
+
+	result, hit, err := readCache[T](cacheManager, ctx, cacheKey)
+	if err != nil {
+		NewLogger().Error("Cache read of ", cacheKey, " failed, falling back to source: ", err.Error())
+		result = loadCacheSource(ctx, key, executeData)
+	} else if !hit {
+		loaded, err := cacheLoads.do(cacheKey, func() (interface{}, error) {
+			return loadCache(cacheManager, ctx, policy, cacheKey, key, executeData), nil
+		})
+		exception.PanicLogging(err)
+		result = loaded.(cacheResult[T])
+	}
+
+	exception.PanicLogging(result.err)
+	if result.notFound != "" {
+		panic(exception.NotFoundError{
+			Message: result.notFound,
+		})
+	}
+	return &result.value
+}
+
+// DeleteCache evicts prefix_key, including a cached not-found result.
+func DeleteCache(cacheManager *redis.Client, ctx context.Context, prefix string, key string) error {
+	return cacheManager.Del(ctx, prefix+"_"+key).Err()
+}
+
+func readCache[T any](cacheManager *redis.Client, ctx context.Context, cacheKey string) (cacheResult[T], bool, error) {
+	var result cacheResult[T]
+	data, err := cacheManager.Get(ctx, cacheKey).Bytes()
+	if err == redis.Nil {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || (entry.NotFound == "" && len(entry.Value) == 0) {
+		return result, false, nil
+	}
+	if entry.NotFound != "" {
+		result.notFound = entry.NotFound
+		return result, true, nil
+	}
+	if err := json.Unmarshal(entry.Value, &result.value); err != nil {
+		return result, false, nil
+	}
+	return result, true, nil
+}
+
+func loadCache[T any](cacheManager *redis.Client, ctx context.Context, policy CachePolicy, cacheKey string, key string, executeData func(context.Context, string) (T, error)) cacheResult[T] {
+	lockKey := cacheKey + "_lock"
+	lockToken, locked, err := AcquireLock(cacheManager, ctx, lockKey, cacheLockTtl)
+	if err != nil {
+		NewLogger().Error("Cache lock of ", cacheKey, " failed, falling back to source: ", err.Error())
+		return loadCacheSource(ctx, key, executeData)
+	}
+
+	if !locked {
+		// Another instance is loading the same key; wait for it to land in Redis.
+		for attempt := 0; attempt < cacheLockAttempts; attempt++ {
+			time.Sleep(cacheLockWait)
+			if result, hit, err := readCache[T](cacheManager, ctx, cacheKey); err == nil && hit {
+				return result
+			}
+		}
+		return loadCacheSource(ctx, key, executeData)
+	}
+	defer ReleaseLock(cacheManager, ctx, lockKey, lockToken)
+
+	if result, hit, err := readCache[T](cacheManager, ctx, cacheKey); err == nil && hit {
+		return result
+	}
+
+	result := loadCacheSource(ctx, key, executeData)
+	if result.err != nil {
+		return result
+	}
+	entry := cacheEntry{NotFound: result.notFound}
+	ttl := policy.NegativeTtl
+	if result.notFound == "" {
+		entry.Value, err = json.Marshal(result.value)
+		exception.PanicLogging(err)
+		ttl = policy.Ttl
+	}
+	if ttl > 0 {
+		data, err := json.Marshal(entry)
+		exception.PanicLogging(err)
+		if err := cacheManager.Set(ctx, cacheKey, data, ttl).Err(); err != nil {
+			NewLogger().Error("Cache write of ", cacheKey, " failed: ", err.Error())
+		}
+	}
+	return result
+}
+
+func loadCacheSource[T any](ctx context.Context, key string, executeData func(context.Context, string) (T, error)) cacheResult[T] {
+	value, err := executeData(ctx, key)
+	if err != nil && isNotFound(err) {
+		return cacheResult[T]{notFound: err.Error()}
+	}
+	if err != nil {
+		return cacheResult[T]{err: err}
+	}
+	return cacheResult[T]{value: value}
+}
+
+// isNotFound tells a missing record, from GORM or one of the repositories' "... Not Found"
+// errors, from a failure such as the database being unreachable.
+func isNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound) || strings.HasSuffix(err.Error(), "Not Found")
+}
+
+var cacheLoads = &cacheFlightGroup{calls: map[string]*cacheFlight{}}
+
+type cacheFlight struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// cacheFlightGroup makes concurrent loads of one key within this process share a single call.
+type cacheFlightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*cacheFlight
+}
+
+func (group *cacheFlightGroup) do(key string, load func() (interface{}, error)) (interface{}, error) {
+	group.mutex.Lock()
+	if call, ok := group.calls[key]; ok {
+		group.mutex.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &cacheFlight{done: make(chan struct{}), err: errors.New("cache load for " + key + " did not complete")}
+	group.calls[key] = call
+	group.mutex.Unlock()
+
+	defer func() {
+		group.mutex.Lock()
+		delete(group.calls, key)
+		group.mutex.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = load()
+	return call.value, call.err
+}
+
+// GetCacheNamespace returns the current version of a cache namespace and when it was
+// last invalidated. Keys that embed the version are orphaned once it is bumped.
+func GetCacheNamespace(cacheManager *redis.Client, ctx context.Context, namespace string) (int64, time.Time, error) {
+	version, err := cacheManager.Get(ctx, namespace+"_version").Int64()
+	if err != nil && err != redis.Nil {
+		return 0, time.Time{}, err
+	}
+
+	modifiedAt, err := cacheManager.Get(ctx, namespace+"_modified_at").Int64()
+	if err == redis.Nil {
+		modifiedAt = time.Now().Unix()
+		err = cacheManager.SetNX(ctx, namespace+"_modified_at", modifiedAt, 0).Err()
+	}
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return version, time.Unix(modifiedAt, 0), nil
+}
+
+// InvalidateCacheNamespace bumps the namespace version so all of its cached entries
+// stop being served, and records the modification time for Last-Modified headers.
+func InvalidateCacheNamespace(cacheManager *redis.Client, ctx context.Context, namespace string) error {
+	if err := cacheManager.Incr(ctx, namespace+"_version").Err(); err != nil {
+		return err
+	}
+	return cacheManager.Set(ctx, namespace+"_modified_at", time.Now().Unix(), 0).Err()
+}
+
+// releaseLockScript deletes a lock only while it still holds the token it was taken with, so a holder
+// that outlived the TTL cannot release a lock another instance has taken since.
+var releaseLockScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`)
+
+// AcquireLock takes a Redis lock that expires after ttl, and returns the token to release it with.
+func AcquireLock(cacheManager *redis.Client, ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	token := uuid.New().String()
+	locked, err := cacheManager.SetNX(ctx, key, token, ttl).Result()
+	return token, locked, err
+}
+
+// ReleaseLock releases a lock taken with AcquireLock, unless it has expired and been taken again.
+func ReleaseLock(cacheManager *redis.Client, ctx context.Context, key string, token string) {
+	if err := releaseLockScript.Run(ctx, cacheManager, []string{key}, token).Err(); err != nil {
+		NewLogger().Error("Failed to release lock ", key, ": ", err.Error())
+	}
+}
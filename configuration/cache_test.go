@@ -0,0 +1,66 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheFlightGroup_SharesConcurrentLoads(t *testing.T) {
+	group := &cacheFlightGroup{calls: map[string]*cacheFlight{}}
+	var loads int32
+	var waitGroup sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			value, err := group.do("product_1", func() (interface{}, error) {
+				atomic.AddInt32(&loads, 1)
+				time.Sleep(50 * time.Millisecond)
+				return "loaded", nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "loaded", value)
+		}()
+	}
+	waitGroup.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+}
+
+func TestCacheFlightGroup_ReleasesKeyAfterLoad(t *testing.T) {
+	group := &cacheFlightGroup{calls: map[string]*cacheFlight{}}
+
+	_, _ = group.do("product_1", func() (interface{}, error) { return 1, nil })
+	value, _ := group.do("product_1", func() (interface{}, error) { return 2, nil })
+
+	assert.Equal(t, 2, value)
+	assert.Empty(t, group.calls)
+}
+
+func TestLoadCacheSource_CachesOnlyNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	notFound := loadCacheSource(ctx, "1", func(context.Context, string) (string, error) {
+		return "", errors.New("product Not Found")
+	})
+	assert.Equal(t, "product Not Found", notFound.notFound)
+	assert.NoError(t, notFound.err)
+
+	recordNotFound := loadCacheSource(ctx, "1", func(context.Context, string) (string, error) {
+		return "", gorm.ErrRecordNotFound
+	})
+	assert.Equal(t, gorm.ErrRecordNotFound.Error(), recordNotFound.notFound)
+
+	outage := loadCacheSource(ctx, "1", func(context.Context, string) (string, error) {
+		return "", errors.New("dial tcp 127.0.0.1:3306: connect: connection refused")
+	})
+	assert.Empty(t, outage.notFound)
+	assert.Error(t, outage.err)
+}
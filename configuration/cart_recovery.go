@@ -0,0 +1,62 @@
+package configuration
+
+import (
+	"errors"
+	"github.com/tech-hive/ecommerce/exception"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CartRecoveryPolicy controls the reminders sent for abandoned carts.
+type CartRecoveryPolicy struct {
+	// ReminderDelays are how long a cart sits untouched before each reminder, shortest first.
+	ReminderDelays []time.Duration
+	// CouponPercent is the discount of the one-time coupon sent with the last reminder; zero sends none.
+	CouponPercent  float64
+	CouponValidity time.Duration
+	// RecoveryWindow is how long after a reminder an order from the cart counts as recovered.
+	RecoveryWindow time.Duration
+}
+
+// NewCartRecoveryPolicy reads CART_RECOVERY_REMINDER_DELAYS_MINUTES, a comma-separated list,
+// CART_RECOVERY_COUPON_PERCENT, CART_RECOVERY_COUPON_VALID_HOURS and CART_RECOVERY_WINDOW_HOURS.
+func NewCartRecoveryPolicy(config Config) CartRecoveryPolicy {
+	var delays []time.Duration
+	for _, value := range strings.Split(config.Get("CART_RECOVERY_REMINDER_DELAYS_MINUTES"), ",") {
+		minutes, err := strconv.Atoi(strings.TrimSpace(value))
+		exception.PanicLogging(err)
+		if minutes <= 0 {
+			exception.PanicLogging(errors.New("cart recovery reminder delays must be positive"))
+		}
+		delays = append(delays, time.Duration(minutes)*time.Minute)
+	}
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+
+	couponPercent, err := strconv.ParseFloat(config.Get("CART_RECOVERY_COUPON_PERCENT"), 64)
+	exception.PanicLogging(err)
+	couponValidHours, err := strconv.Atoi(config.Get("CART_RECOVERY_COUPON_VALID_HOURS"))
+	exception.PanicLogging(err)
+	windowHours, err := strconv.Atoi(config.Get("CART_RECOVERY_WINDOW_HOURS"))
+	exception.PanicLogging(err)
+
+	return CartRecoveryPolicy{
+		ReminderDelays: delays,
+		CouponPercent:  couponPercent,
+		CouponValidity: time.Duration(couponValidHours) * time.Hour,
+		RecoveryWindow: time.Duration(windowHours) * time.Hour,
+	}
+}
+
+// ReminderStage is the reminder due for a cart untouched for idle: 1 for the first delay, 2 for the
+// second, and so on, or 0 before the first delay.
+func (policy CartRecoveryPolicy) ReminderStage(idle time.Duration) int {
+	stage := 0
+	for i, delay := range policy.ReminderDelays {
+		if idle >= delay {
+			stage = i + 1
+		}
+	}
+	return stage
+}
@@ -0,0 +1,16 @@
+package configuration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCartRecoveryPolicy_ReminderStage(t *testing.T) {
+	policy := CartRecoveryPolicy{ReminderDelays: []time.Duration{time.Hour, 24 * time.Hour, 72 * time.Hour}}
+
+	assert.Equal(t, 0, policy.ReminderStage(59*time.Minute))
+	assert.Equal(t, 1, policy.ReminderStage(time.Hour))
+	assert.Equal(t, 2, policy.ReminderStage(30*time.Hour))
+	assert.Equal(t, 3, policy.ReminderStage(30*24*time.Hour))
+}
@@ -0,0 +1,18 @@
+package configuration
+
+// InvoiceIssuer is the business named on invoices and receipts. TaxPin is its KRA PIN, which tax
+// invoices show.
+type InvoiceIssuer struct {
+	Name    string
+	Address string
+	TaxPin  string
+}
+
+// NewInvoiceIssuer reads INVOICE_ISSUER_NAME, INVOICE_ISSUER_ADDRESS and INVOICE_ISSUER_KRA_PIN.
+func NewInvoiceIssuer(config Config) InvoiceIssuer {
+	return InvoiceIssuer{
+		Name:    config.Get("INVOICE_ISSUER_NAME"),
+		Address: config.Get("INVOICE_ISSUER_ADDRESS"),
+		TaxPin:  config.Get("INVOICE_ISSUER_KRA_PIN"),
+	}
+}
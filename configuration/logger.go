@@ -0,0 +1,36 @@
+package configuration
+
+import (
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"time"
+)
+
+// NewLogger is the structured logger of the application. It lives here so configuration can log
+// too; common.NewLogger returns it for the other packages.
+func NewLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	logger.SetFormatter(&logrus.JSONFormatter{
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime: "@timestamp",
+			logrus.FieldKeyMsg:  "message",
+		},
+	})
+
+	if _, err := os.Stat("logs"); os.IsNotExist(err) {
+		err := os.Mkdir("logs", 0770)
+		exception.PanicLogging(err)
+	}
+
+	date := time.Now()
+	logFile, err := os.OpenFile("logs/log_"+date.Format("01-02-2006_15")+".log", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	exception.PanicLogging(err)
+	if err == nil {
+		multiWriter := io.MultiWriter(os.Stdout, logFile)
+		logger.SetOutput(multiWriter)
+	}
+	return logger
+}
@@ -0,0 +1,24 @@
+package configuration
+
+import (
+	"errors"
+	"github.com/tech-hive/ecommerce/exception"
+	"strconv"
+	"time"
+)
+
+// OrderExpiryPolicy tells how long a customer has to pay for an order before it is cancelled and its
+// stock goes back on sale.
+type OrderExpiryPolicy struct {
+	PaymentTimeout time.Duration
+}
+
+// NewOrderExpiryPolicy reads ORDER_PAYMENT_TIMEOUT_MINUTES.
+func NewOrderExpiryPolicy(config Config) OrderExpiryPolicy {
+	minutes, err := strconv.Atoi(config.Get("ORDER_PAYMENT_TIMEOUT_MINUTES"))
+	exception.PanicLogging(err)
+	if minutes <= 0 {
+		exception.PanicLogging(errors.New("order payment timeout must be positive"))
+	}
+	return OrderExpiryPolicy{PaymentTimeout: time.Duration(minutes) * time.Minute}
+}
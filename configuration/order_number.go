@@ -0,0 +1,21 @@
+package configuration
+
+import (
+	"errors"
+	"github.com/tech-hive/ecommerce/exception"
+)
+
+// NewOrderNumberPrefix reads ORDER_NUMBER_PREFIX, the letters order numbers start with. Orders keep
+// the prefix they were numbered with when it changes.
+func NewOrderNumberPrefix(config Config) string {
+	prefix := config.Get("ORDER_NUMBER_PREFIX")
+	if len(prefix) == 0 || len(prefix) > 4 {
+		exception.PanicLogging(errors.New("order number prefix must be 1 to 4 letters"))
+	}
+	for _, c := range prefix {
+		if c < 'A' || c > 'Z' {
+			exception.PanicLogging(errors.New("order number prefix must be uppercase letters"))
+		}
+	}
+	return prefix
+}
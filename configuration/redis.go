@@ -1,8 +1,6 @@
 package configuration
 
 import (
-	"context"
-	"encoding/json"
 	"github.com/tech-hive/ecommerce/exception"
 	"github.com/go-redis/redis/v9"
 	"strconv"
@@ -23,27 +21,3 @@ func NewRedis(config Config) *redis.Client {
 	})
 	return redisStore
 }
-
-func SetCache[T any](cacheManager *redis.Client, ctx context.Context, prefix string, key string, executeData func(context.Context, string) (T, error)) *T {
-	var data []byte
-	var object T
-	if err := cacheManager.Get(ctx, prefix+"_"+key).Scan(&data); err == nil {
-		err := json.Unmarshal(data, &object)
-		exception.PanicLogging(err)
-
-		return &object
-	}
-	value, err := executeData(ctx, key)
-	if err != nil {
-		panic(exception.NotFoundError{
-			Message: err.Error(),
-		})
-	}
-	cacheValue, err := json.Marshal(value)
-	exception.PanicLogging(err)
-
-	if err := cacheManager.Set(ctx, prefix+"_"+key, cacheValue, -1).Err(); err != nil {
-		exception.PanicLogging(err)
-	}
-	return &value
-}
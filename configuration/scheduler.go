@@ -0,0 +1,54 @@
+package configuration
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/exception"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scheduler runs a background job at a fixed interval.
+type Scheduler struct {
+	Name     string
+	Interval time.Duration
+}
+
+// NewScheduler reads SCHEDULER_<NAME>_INTERVAL_SECONDS.
+func NewScheduler(config Config, name string) Scheduler {
+	interval, err := strconv.Atoi(config.Get("SCHEDULER_" + strings.ToUpper(name) + "_INTERVAL_SECONDS"))
+	exception.PanicLogging(err)
+
+	return Scheduler{
+		Name:     name,
+		Interval: time.Duration(interval) * time.Second,
+	}
+}
+
+// Start runs job once immediately and then every interval until ctx is done. A failing or
+// panicking run is logged and the job is tried again on the next tick.
+func (scheduler Scheduler) Start(ctx context.Context, job func(context.Context) error) {
+	go func() {
+		ticker := time.NewTicker(scheduler.Interval)
+		defer ticker.Stop()
+		for {
+			scheduler.run(ctx, job)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (scheduler Scheduler) run(ctx context.Context, job func(context.Context) error) {
+	defer func() {
+		if r := recover(); r != nil {
+			NewLogger().Error("Scheduler ", scheduler.Name, " panicked: ", r)
+		}
+	}()
+	if err := job(ctx); err != nil {
+		NewLogger().Error("Scheduler ", scheduler.Name, " failed: ", err.Error())
+	}
+}
@@ -0,0 +1,19 @@
+package configuration
+
+import (
+	"github.com/tech-hive/ecommerce/exception"
+	"strconv"
+)
+
+// TaxPolicy tells whether catalogue prices include VAT, as retail prices in Kenya usually do, or VAT
+// is added on top at checkout.
+type TaxPolicy struct {
+	PricesIncludeTax bool
+}
+
+// NewTaxPolicy reads TAX_PRICES_INCLUDE_TAX.
+func NewTaxPolicy(config Config) TaxPolicy {
+	pricesIncludeTax, err := strconv.ParseBool(config.Get("TAX_PRICES_INCLUDE_TAX"))
+	exception.PanicLogging(err)
+	return TaxPolicy{PricesIncludeTax: pricesIncludeTax}
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+func NewAttributeController(attributeService *service.AttributeService, config configuration.Config) *AttributeController {
+	return &AttributeController{AttributeService: *attributeService, Config: config}
+}
+
+type AttributeController struct {
+	service.AttributeService
+	configuration.Config
+}
+
+func (controller AttributeController) Route(app *fiber.App) {
+	app.Post("/v1/api/attributes", middleware.AuthenticateJWT("admin", controller.Config), controller.CreateDefinition)
+	app.Get("/v1/api/attributes", controller.FindDefinitions) // Public endpoint for building catalogue filters
+	app.Put("/v1/api/categories/:category/attributes/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.AttachToCategory)
+	app.Delete("/v1/api/categories/:category/attributes/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.DetachFromCategory)
+}
+
+// CreateDefinition func create attribute.
+// @Description create a typed product attribute: text, number (with an optional unit), enum (with options) or boolean.
+// @Summary create attribute
+// @Tags Attribute
+// @Accept json
+// @Produce json
+// @Param request body model.AttributeDefinitionCreateModel true "Request Body"
+// @Success 201 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/attributes [post]
+func (controller AttributeController) CreateDefinition(c *fiber.Ctx) error {
+	var request model.AttributeDefinitionCreateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.AttributeService.CreateDefinition(c.Context(), request)
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// FindDefinitions func gets attributes.
+// @Description Get every attribute, or the attributes of one category for building filters.
+// @Summary get attributes
+// @Tags Attribute
+// @Accept json
+// @Produce json
+// @Param category query string false "Product category"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/attributes [get]
+func (controller AttributeController) FindDefinitions(c *fiber.Ctx) error {
+	response := controller.AttributeService.FindDefinitions(c.Context(), c.Query("category"))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// AttachToCategory func attach attribute to category.
+// @Description attach an attribute to a product category, or change whether products of the category must set it.
+// @Summary attach attribute to category
+// @Tags Attribute
+// @Accept json
+// @Produce json
+// @Param category path string true "Product category"
+// @Param id path int true "Attribute Id"
+// @Param request body model.CategoryAttributeModel true "Request Body"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/categories/{category}/attributes/{id} [put]
+func (controller AttributeController) AttachToCategory(c *fiber.Ctx) error {
+	var request model.CategoryAttributeModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid attribute ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.AttributeService.AttachToCategory(c.Context(), c.Params("category"), uint(id), request)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// DetachFromCategory func detach attribute from category.
+// @Description detach an attribute from a product category. Values already set on products are kept.
+// @Summary detach attribute from category
+// @Tags Attribute
+// @Accept json
+// @Produce json
+// @Param category path string true "Product category"
+// @Param id path int true "Attribute Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/categories/{category}/attributes/{id} [delete]
+func (controller AttributeController) DetachFromCategory(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid attribute ID",
+			Data:    err.Error(),
+		})
+	}
+
+	controller.AttributeService.DetachFromCategory(c.Context(), c.Params("category"), uint(id))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+	})
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+	"github.com/tech-hive/ecommerce/common"
 	"github.com/tech-hive/ecommerce/configuration"
 	"github.com/tech-hive/ecommerce/exception"
 	"github.com/tech-hive/ecommerce/middleware"
@@ -9,24 +11,41 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v4"
 	"strconv"
+	"time"
 )
 
-func NewCartController(cartService *service.CartService, config configuration.Config) *CartController {
-	return &CartController{CartService: *cartService, Config: config}
+// cartTokenMaxAge keeps the guest cart cookie as long as its token is valid.
+const cartTokenMaxAge = int(common.CartTokenLifetime / time.Second)
+
+func NewCartController(cartService *service.CartService, currencyService *service.CurrencyService, config configuration.Config) *CartController {
+	return &CartController{CartService: *cartService, CurrencyService: *currencyService, Config: config}
 }
 
 type CartController struct {
 	service.CartService
+	service.CurrencyService
 	configuration.Config
 }
 
 func (controller CartController) Route(app *fiber.App) {
-	// All cart routes require authentication
-	app.Get("/v1/api/cart", middleware.AuthenticateJWT("customer", controller.Config), controller.GetCart)
-	app.Post("/v1/api/cart/items", middleware.AuthenticateJWT("customer", controller.Config), controller.AddToCart)
-	app.Put("/v1/api/cart/items/:id", middleware.AuthenticateJWT("customer", controller.Config), controller.UpdateCartItem)
-	app.Delete("/v1/api/cart/items/:id", middleware.AuthenticateJWT("customer", controller.Config), controller.RemoveFromCart)
-	app.Delete("/v1/api/cart", middleware.AuthenticateJWT("customer", controller.Config), controller.ClearCart)
+	// Customers use their cart with a JWT, guests with a cart token
+	app.Get("/v1/api/cart", middleware.AuthenticateCart(controller.Config), controller.GetCart)
+	app.Post("/v1/api/cart/items", middleware.AuthenticateCart(controller.Config), controller.AddToCart)
+	app.Put("/v1/api/cart/items/:id", middleware.AuthenticateCart(controller.Config), controller.UpdateCartItem)
+	app.Delete("/v1/api/cart/items/:id", middleware.AuthenticateCart(controller.Config), controller.RemoveFromCart)
+	app.Delete("/v1/api/cart", middleware.AuthenticateCart(controller.Config), controller.ClearCart)
+	app.Get("/v1/api/cart/shipping-options", middleware.AuthenticateCart(controller.Config), controller.GetShippingOptions)
+	app.Post("/v1/api/cart/acknowledge", middleware.AuthenticateCart(controller.Config), controller.AcknowledgeChanges)
+	app.Post("/v1/api/cart/coupons", middleware.AuthenticateCart(controller.Config), controller.ApplyCoupon)
+	app.Delete("/v1/api/cart/coupons/:code", middleware.AuthenticateCart(controller.Config), controller.RemoveCoupon)
+	app.Post("/v1/api/cart/items/:id/move", middleware.AuthenticateCart(controller.Config), controller.MoveCartItem)
+
+	// Named carts and the save-for-later list are for customers only
+	app.Get("/v1/api/cart/saved", middleware.AuthenticateJWT("customer", controller.Config), controller.GetSavedItems)
+	app.Get("/v1/api/carts", middleware.AuthenticateJWT("customer", controller.Config), controller.FindCarts)
+	app.Post("/v1/api/carts", middleware.AuthenticateJWT("customer", controller.Config), controller.CreateCart)
+	app.Put("/v1/api/carts/:id", middleware.AuthenticateJWT("customer", controller.Config), controller.RenameCart)
+	app.Delete("/v1/api/carts/:id", middleware.AuthenticateJWT("customer", controller.Config), controller.DeleteCart)
 }
 
 // GetCart godoc
@@ -35,17 +54,16 @@ func (controller CartController) Route(app *fiber.App) {
 // @Tags Cart
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
+// @Param currency query string false "Display currency, KES by default"
 // @Success 200 {object} model.GeneralResponse
 // @Router /v1/api/cart [get]
 // @Security JWT
 func (controller CartController) GetCart(c *fiber.Ctx) error {
-	// Get user ID from JWT token
-	user := c.Locals("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userIdFloat := claims["user_id"].(float64)
-	userId := uint(userIdFloat)
+	owner := controller.cartOwner(c, false)
 
-	cart, err := controller.CartService.GetCart(c.Context(), userId)
+	cart, err := controller.CartService.GetCart(c.Context(), owner)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.GeneralResponse{
 			Code:    500,
@@ -54,6 +72,15 @@ func (controller CartController) GetCart(c *fiber.Ctx) error {
 		})
 	}
 
+	cart, err = controller.CurrencyService.ConvertCart(c.Context(), c.Query("currency"), cart)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
 		Code:    200,
 		Message: "Success",
@@ -68,6 +95,8 @@ func (controller CartController) GetCart(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param request body model.AddToCartModel true "Add to cart request"
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
 // @Success 201 {object} model.GeneralResponse
 // @Router /v1/api/cart/items [post]
 // @Security JWT
@@ -76,13 +105,10 @@ func (controller CartController) AddToCart(c *fiber.Ctx) error {
 	err := c.BodyParser(&request)
 	exception.PanicLogging(err)
 
-	// Get user ID from JWT token
-	user := c.Locals("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userIdFloat := claims["user_id"].(float64)
-	userId := uint(userIdFloat)
+	// Guests without a cart token get one with their first item
+	owner := controller.cartOwner(c, true)
 
-	cartItem, err := controller.CartService.AddToCart(c.Context(), userId, request)
+	cartItem, err := controller.CartService.AddToCart(c.Context(), owner, request)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
 			Code:    400,
@@ -106,6 +132,8 @@ func (controller CartController) AddToCart(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path int true "Cart Item ID"
 // @Param request body model.UpdateCartItemModel true "Update cart item request"
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
 // @Success 200 {object} model.GeneralResponse
 // @Router /v1/api/cart/items/{id} [put]
 // @Security JWT
@@ -123,13 +151,9 @@ func (controller CartController) UpdateCartItem(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get user ID from JWT token
-	user := c.Locals("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userIdFloat := claims["user_id"].(float64)
-	userId := uint(userIdFloat)
+	owner := controller.cartOwner(c, false)
 
-	cartItem, err := controller.CartService.UpdateCartItem(c.Context(), userId, uint(cartItemId), request)
+	cartItem, err := controller.CartService.UpdateCartItem(c.Context(), owner, uint(cartItemId), request)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
 			Code:    400,
@@ -152,6 +176,8 @@ func (controller CartController) UpdateCartItem(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "Cart Item ID"
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
 // @Success 200 {object} model.GeneralResponse
 // @Router /v1/api/cart/items/{id} [delete]
 // @Security JWT
@@ -165,13 +191,9 @@ func (controller CartController) RemoveFromCart(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get user ID from JWT token
-	user := c.Locals("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userIdFloat := claims["user_id"].(float64)
-	userId := uint(userIdFloat)
+	owner := controller.cartOwner(c, false)
 
-	err = controller.CartService.RemoveFromCart(c.Context(), userId, uint(cartItemId))
+	err = controller.CartService.RemoveFromCart(c.Context(), owner, uint(cartItemId))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
 			Code:    400,
@@ -193,17 +215,15 @@ func (controller CartController) RemoveFromCart(c *fiber.Ctx) error {
 // @Tags Cart
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
 // @Success 200 {object} model.GeneralResponse
 // @Router /v1/api/cart [delete]
 // @Security JWT
 func (controller CartController) ClearCart(c *fiber.Ctx) error {
-	// Get user ID from JWT token
-	user := c.Locals("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userIdFloat := claims["user_id"].(float64)
-	userId := uint(userIdFloat)
+	owner := controller.cartOwner(c, false)
 
-	err := controller.CartService.ClearCart(c.Context(), userId)
+	err := controller.CartService.ClearCart(c.Context(), owner)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.GeneralResponse{
 			Code:    500,
@@ -217,4 +237,407 @@ func (controller CartController) ClearCart(c *fiber.Ctx) error {
 		Message: "Cart cleared successfully",
 		Data:    nil,
 	})
-}
\ No newline at end of file
+}
+
+// ApplyCoupon godoc
+// @Summary Apply coupon to cart
+// @Description Apply a coupon code to the cart; the coupons of the cart tell whether each one discounts it
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param request body model.ApplyCouponModel true "Apply coupon request"
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
+// @Param currency query string false "Display currency, KES by default"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/cart/coupons [post]
+// @Security JWT
+func (controller CartController) ApplyCoupon(c *fiber.Ctx) error {
+	var request model.ApplyCouponModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	// Guests without a cart token get one with their first coupon
+	owner := controller.cartOwner(c, true)
+
+	cart, err := controller.CartService.ApplyCoupon(c.Context(), owner, request)
+	if err == nil {
+		cart, err = controller.CurrencyService.ConvertCart(c.Context(), c.Query("currency"), cart)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Coupon applied successfully",
+		Data:    cart,
+	})
+}
+
+// RemoveCoupon godoc
+// @Summary Remove coupon from cart
+// @Description Remove a coupon code from the cart
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param code path string true "Coupon code"
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
+// @Param currency query string false "Display currency, KES by default"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/cart/coupons/{code} [delete]
+// @Security JWT
+func (controller CartController) RemoveCoupon(c *fiber.Ctx) error {
+	owner := controller.cartOwner(c, false)
+
+	cart, err := controller.CartService.RemoveCoupon(c.Context(), owner, c.Params("code"))
+	if err == nil {
+		cart, err = controller.CurrencyService.ConvertCart(c.Context(), c.Query("currency"), cart)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Coupon removed successfully",
+		Data:    cart,
+	})
+}
+
+// GetShippingOptions godoc
+// @Summary Get shipping options for cart
+// @Description Quote the shipping methods that deliver the cart to a county and town, cheapest first
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param county query string true "County"
+// @Param town query string false "Town"
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
+// @Param currency query string false "Display currency, KES by default"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/cart/shipping-options [get]
+// @Security JWT
+func (controller CartController) GetShippingOptions(c *fiber.Ctx) error {
+	owner := controller.cartOwner(c, false)
+
+	quote, err := controller.CartService.GetShippingOptions(c.Context(), owner, c.Query("county"), c.Query("town"))
+	if err == nil {
+		quote, err = controller.CurrencyService.ConvertShippingQuote(c.Context(), c.Query("currency"), quote)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    quote,
+	})
+}
+
+// AcknowledgeChanges godoc
+// @Summary Acknowledge cart changes
+// @Description Accept the price and stock changes reported in the cart warnings, so the cart can be checked out. The body lists each changed line as it will be: its new price and quantity, or quantity 0 when it is removed. When the cart changed again since, nothing is changed and the response is 409 with the fresh warnings.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param request body model.CartAcknowledgeModel true "Request Body"
+// @Param X-Cart-Token header string false "Guest cart token, instead of a JWT"
+// @Param cart_id query int false "One of the customer's named carts, instead of their default cart"
+// @Param currency query string false "Display currency, KES by default"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/cart/acknowledge [post]
+// @Security JWT
+func (controller CartController) AcknowledgeChanges(c *fiber.Ctx) error {
+	var request model.CartAcknowledgeModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	owner := controller.cartOwner(c, false)
+
+	cart, err := controller.CartService.AcknowledgeChanges(c.Context(), owner, request)
+	var cartChanged exception.CartChangedError
+	if errors.As(err, &cartChanged) {
+		return c.Status(fiber.StatusConflict).JSON(model.GeneralResponse{
+			Code:    409,
+			Message: "cart has changed again, review the warnings and acknowledge them",
+			Data:    cartChanged.Warnings,
+		})
+	}
+	if err == nil {
+		cart, err = controller.CurrencyService.ConvertCart(c.Context(), c.Query("currency"), cart)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Cart changes acknowledged",
+		Data:    cart,
+	})
+}
+
+// MoveCartItem godoc
+// @Summary Move cart item
+// @Description Move an item to another cart of the customer, or to their save-for-later list. Items moved out of the save-for-later list take today's price. When the target already has the product the quantities add up, capped at the stock available, and limited is set.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param id path int true "Cart Item ID"
+// @Param request body model.MoveCartItemModel true "Move cart item request"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/cart/items/{id}/move [post]
+// @Security JWT
+func (controller CartController) MoveCartItem(c *fiber.Ctx) error {
+	var request model.MoveCartItemModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	cartItemId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid cart item ID",
+			Data:    err.Error(),
+		})
+	}
+
+	owner := controller.cartOwner(c, false)
+
+	response, err := controller.CartService.MoveCartItem(c.Context(), owner, uint(cartItemId), request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Item moved successfully",
+		Data:    response,
+	})
+}
+
+// GetSavedItems godoc
+// @Summary Get saved for later items
+// @Description Get the items the customer saved for later, at today's prices
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/cart/saved [get]
+// @Security JWT
+func (controller CartController) GetSavedItems(c *fiber.Ctx) error {
+	owner := controller.cartOwner(c, false)
+
+	items, err := controller.CartService.GetSavedItems(c.Context(), owner.UserId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.GeneralResponse{
+			Code:    500,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    items,
+	})
+}
+
+// FindCarts godoc
+// @Summary Get user's carts
+// @Description List the named carts of the customer, default cart first, and their save-for-later list
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/carts [get]
+// @Security JWT
+func (controller CartController) FindCarts(c *fiber.Ctx) error {
+	owner := controller.cartOwner(c, false)
+
+	carts, err := controller.CartService.FindCarts(c.Context(), owner.UserId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.GeneralResponse{
+			Code:    500,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    carts,
+	})
+}
+
+// CreateCart godoc
+// @Summary Create a named cart
+// @Description Create another cart; use it with the cart_id query parameter of the cart endpoints
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param request body model.CartCreateOrUpdateModel true "Create cart request"
+// @Success 201 {object} model.GeneralResponse
+// @Router /v1/api/carts [post]
+// @Security JWT
+func (controller CartController) CreateCart(c *fiber.Ctx) error {
+	var request model.CartCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	owner := controller.cartOwner(c, false)
+
+	cart, err := controller.CartService.CreateCart(c.Context(), owner.UserId, request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Cart created successfully",
+		Data:    cart,
+	})
+}
+
+// RenameCart godoc
+// @Summary Rename a cart
+// @Description Rename one of the customer's carts
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Param request body model.CartCreateOrUpdateModel true "Rename cart request"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/carts/{id} [put]
+// @Security JWT
+func (controller CartController) RenameCart(c *fiber.Ctx) error {
+	var request model.CartCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	cartId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid cart ID",
+			Data:    err.Error(),
+		})
+	}
+
+	owner := controller.cartOwner(c, false)
+
+	cart, err := controller.CartService.RenameCart(c.Context(), owner.UserId, uint(cartId), request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Cart renamed successfully",
+		Data:    cart,
+	})
+}
+
+// DeleteCart godoc
+// @Summary Delete a cart
+// @Description Delete a named cart with its items. The default cart and the save-for-later list cannot be deleted.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/carts/{id} [delete]
+// @Security JWT
+func (controller CartController) DeleteCart(c *fiber.Ctx) error {
+	cartId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid cart ID",
+			Data:    err.Error(),
+		})
+	}
+
+	owner := controller.cartOwner(c, false)
+
+	err = controller.CartService.DeleteCart(c.Context(), owner.UserId, uint(cartId))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Cart deleted successfully",
+		Data:    nil,
+	})
+}
+
+// cartOwner returns the customer of the JWT, with the cart picked by the cart_id query parameter, or
+// the guest of the cart token. With issue set, a guest without a token gets a new one in the
+// X-Cart-Token header and the cart_token cookie.
+func (controller CartController) cartOwner(c *fiber.Ctx, issue bool) model.CartOwnerModel {
+	if user, ok := c.Locals("user").(*jwt.Token); ok {
+		claims := user.Claims.(jwt.MapClaims)
+		cartId, _ := strconv.Atoi(c.Query("cart_id"))
+		return model.CartOwnerModel{
+			UserId: uint(claims["user_id"].(float64)),
+			CartId: uint(cartId),
+		}
+	}
+	if guestId, ok := c.Locals("cart_guest_id").(string); ok {
+		return model.CartOwnerModel{GuestId: guestId}
+	}
+	if !issue {
+		return model.CartOwnerModel{}
+	}
+
+	token, guestId := common.GenerateCartToken(controller.Config)
+	c.Set(common.CartTokenHeader, token)
+	c.Cookie(&fiber.Cookie{
+		Name:     common.CartTokenCookie,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   cartTokenMaxAge,
+		HTTPOnly: true,
+		SameSite: "Lax",
+	})
+	return model.CartOwnerModel{GuestId: guestId}
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewCartRecoveryController(cartRecoveryService *service.CartRecoveryService, config configuration.Config) *CartRecoveryController {
+	return &CartRecoveryController{CartRecoveryService: *cartRecoveryService, Config: config}
+}
+
+type CartRecoveryController struct {
+	service.CartRecoveryService
+	configuration.Config
+}
+
+func (controller CartRecoveryController) Route(app *fiber.App) {
+	app.Get("/v1/api/cart-recovery/report", middleware.AuthenticateJWT("admin", controller.Config), controller.Report)
+}
+
+// Report func reports abandoned carts and their recovery.
+// @Description Get the customer carts abandoned now, and the reminders sent between two days with the carts they recovered, by reminder stage. Defaults to the last 30 days.
+// @Summary report cart recovery
+// @Tags Cart Recovery
+// @Accept json
+// @Produce json
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/cart-recovery/report [get]
+func (controller CartRecoveryController) Report(c *fiber.Ctx) error {
+	var query model.CartRecoveryReportQueryModel
+	err := c.QueryParser(&query)
+	exception.PanicLogging(err)
+
+	response := controller.CartRecoveryService.Report(c.Context(), query)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
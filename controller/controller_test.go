@@ -41,18 +41,26 @@ var productRepository = impl.NewProductRepositoryImpl(database)
 var transactionRepository = impl.NewTransactionRepositoryImpl(database)
 var transactionDetailRepository = impl.NewTransactionDetailRepositoryImpl(database)
 var userRepository = impl.NewUserRepositoryImpl(database)
+var attributeRepository = impl.NewAttributeRepositoryImpl(database)
+var cartRepository = impl.NewCartRepositoryImpl(database)
+var promotionRepository = impl.NewPromotionRepositoryImpl(database)
+var taxRepository = impl.NewTaxRepositoryImpl(database)
+var shippingRepository = impl.NewShippingRepositoryImpl(database)
+var currencyRepository = impl.NewCurrencyRepositoryImpl(database)
 
 // service
-var productService = impl2.NewProductServiceImpl(&productRepository, redis)
+var productService = impl2.NewProductServiceImpl(&productRepository, &attributeRepository, &taxRepository, redis, config)
 var transactionService = impl2.NewTransactionServiceImpl(&transactionRepository)
 var transactionDetailService = impl2.NewTransactionDetailServiceImpl(&transactionDetailRepository)
 var userService = impl2.NewUserServiceImpl(&userRepository)
+var cartService = impl2.NewCartServiceImpl(&cartRepository, &productRepository, &promotionRepository, &taxRepository, &shippingRepository, database, config)
+var currencyService = impl2.NewCurrencyServiceImpl(&currencyRepository, redis)
 
 // controller
-var productController = NewProductController(&productService, config)
+var productController = NewProductController(&productService, &currencyService, config, redis)
 var transactionController = NewTransactionController(&transactionService, config)
 var transactionDetailController = NewTransactionDetailController(&transactionDetailService, config)
-var userController = NewUserController(&userService, config)
+var userController = NewUserController(&userService, &cartService, config)
 
 var appTest = createTestApp()
 
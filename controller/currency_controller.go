@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewCurrencyController(currencyService *service.CurrencyService, config configuration.Config) *CurrencyController {
+	return &CurrencyController{CurrencyService: *currencyService, Config: config}
+}
+
+type CurrencyController struct {
+	service.CurrencyService
+	configuration.Config
+}
+
+func (controller CurrencyController) Route(app *fiber.App) {
+	app.Get("/v1/api/currencies", controller.FindAll) // Public, for picking a display currency
+	app.Post("/v1/api/currencies", middleware.AuthenticateJWT("admin", controller.Config), controller.Create)
+	app.Put("/v1/api/currencies/:code", middleware.AuthenticateJWT("admin", controller.Config), controller.Update)
+}
+
+// FindAll func gets all currencies.
+// @Description Get all currencies with their exchange rates to KES. Pass an active one as the currency query parameter to see prices in it.
+// @Summary get all currencies
+// @Tags Currency
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/currencies [get]
+func (controller CurrencyController) FindAll(c *fiber.Ctx) error {
+	response := controller.CurrencyService.FindAll(c.Context())
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// Create func create a currency.
+// @Description create a display currency with its exchange rate to KES.
+// @Summary create a currency
+// @Tags Currency
+// @Accept json
+// @Produce json
+// @Param request body model.CurrencyCreateOrUpdateModel true "Request Body"
+// @Success 201 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/currencies [post]
+func (controller CurrencyController) Create(c *fiber.Ctx) error {
+	var request model.CurrencyCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.CurrencyService.Create(c.Context(), request)
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// Update func update a currency.
+// @Description update the exchange rate of a currency, or deactivate it. Placed orders keep the rate they were placed at.
+// @Summary update a currency
+// @Tags Currency
+// @Accept json
+// @Produce json
+// @Param code path string true "Currency Code"
+// @Param request body model.CurrencyCreateOrUpdateModel true "Request Body"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/currencies/{code} [put]
+func (controller CurrencyController) Update(c *fiber.Ctx) error {
+	var request model.CurrencyCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.CurrencyService.Update(c.Context(), c.Params("code"), request)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
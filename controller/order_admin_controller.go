@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+)
+
+func NewOrderAdminController(orderAdminService *service.OrderAdminService, orderDocumentService *service.OrderDocumentService, config configuration.Config) *OrderAdminController {
+	return &OrderAdminController{OrderAdminService: *orderAdminService, OrderDocumentService: *orderDocumentService, Config: config}
+}
+
+type OrderAdminController struct {
+	service.OrderAdminService
+	service.OrderDocumentService
+	configuration.Config
+}
+
+func (controller OrderAdminController) Route(app *fiber.App) {
+	app.Get("/v1/api/admin/orders", middleware.AuthenticateJWT("admin", controller.Config), controller.FindAll)
+	app.Put("/v1/api/admin/orders/status", middleware.AuthenticateJWT("admin", controller.Config), controller.UpdateStatuses)
+	app.Get("/v1/api/admin/orders/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.FindById)
+	app.Post("/v1/api/admin/orders/:id/notes", middleware.AuthenticateJWT("admin", controller.Config), controller.AddNote)
+	app.Get("/v1/api/admin/orders/:id/invoice", middleware.AuthenticateJWT("admin", controller.Config), controller.GetInvoice)
+}
+
+// FindAll func lists the orders of all customers.
+// @Description List the orders of all customers with who placed them and their payment status, filtered, sorted and paginated by page/limit or cursor.
+// @Summary list all orders
+// @Tags Order Admin
+// @Accept json
+// @Produce json
+// @Param status query string false "pending, confirmed, processing, shipped, delivered or cancelled"
+// @Param payment_status query string false "paid, unpaid (anything but paid), pending, failed or none"
+// @Param user_id query int false "Customer's user ID"
+// @Param customer query string false "Part of the customer's name or email"
+// @Param number query string false "Order number"
+// @Param from query string false "Placed on or after, YYYY-MM-DD"
+// @Param to query string false "Placed on or before, YYYY-MM-DD"
+// @Param min_total query string false "Minimum total in KES"
+// @Param max_total query string false "Maximum total in KES"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_by query string false "created_at, total or status"
+// @Param sort_order query string false "asc or desc"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/admin/orders [get]
+func (controller OrderAdminController) FindAll(c *fiber.Ctx) error {
+	var search model.OrderSearchModel
+	err := c.QueryParser(&search)
+	exception.PanicLogging(err)
+
+	orders, pageInfo := controller.OrderAdminService.FindAll(c.Context(), search)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"orders":      orders,
+			"total_count": pageInfo.TotalCount,
+			"page":        pageInfo.Page,
+			"limit":       pageInfo.Limit,
+			"next_cursor": pageInfo.NextCursor,
+		},
+	})
+}
+
+// FindById func gets any customer's order.
+// @Description Get an order whoever placed it, with its internal notes.
+// @Summary get any order
+// @Tags Order Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/admin/orders/{id} [get]
+func (controller OrderAdminController) FindById(c *fiber.Ctx) error {
+	orderId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid order ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.OrderAdminService.FindById(c.Context(), uint(orderId))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// AddNote func adds an internal note to an order.
+// @Description Leave a note on an order for other admins. Customers do not see notes.
+// @Summary add an order note
+// @Tags Order Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body model.OrderNoteCreateModel true "Request Body"
+// @Success 201 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/admin/orders/{id}/notes [post]
+func (controller OrderAdminController) AddNote(c *fiber.Ctx) error {
+	var request model.OrderNoteCreateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	orderId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid order ID",
+			Data:    err.Error(),
+		})
+	}
+
+	// Get admin user ID from JWT token
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIdFloat := claims["user_id"].(float64)
+	userId := uint(userIdFloat)
+
+	response := controller.OrderAdminService.AddNote(c.Context(), uint(orderId), userId, request)
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// GetInvoice func downloads the invoice or receipt of any customer's order.
+// @Description Download the invoice of any order, or with type=receipt the receipt of its M-Pesa payment, as a PDF. Documents are numbered and stored as on the customer download.
+// @Summary download any order's invoice or receipt
+// @Tags Order Admin
+// @Produce application/pdf
+// @Param id path int true "Order ID"
+// @Param type query string false "invoice (default) or receipt"
+// @Success 200 {file} file
+// @Security JWT
+// @Router /v1/api/admin/orders/{id}/invoice [get]
+func (controller OrderAdminController) GetInvoice(c *fiber.Ctx) error {
+	orderId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid order ID",
+			Data:    err.Error(),
+		})
+	}
+
+	document, err := controller.OrderDocumentService.GetAnyDocument(c.Context(), uint(orderId), c.Query("type", "invoice"))
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if err.Error() == "order not found" {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(model.GeneralResponse{
+			Code:    status,
+			Message: "Error getting order document",
+			Data:    err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+document.Number+`.pdf"`)
+	return c.Status(fiber.StatusOK).Send(document.Content)
+}
+
+// UpdateStatuses func changes the status of several orders.
+// @Description Set the status of up to 100 orders at once. Orders move one step along pending, confirmed, processing, shipped and delivered, or to cancelled before they ship. The response lists the orders changed, the ones already in the status, the ones refused with their current status and the ids not found.
+// @Summary bulk update order statuses
+// @Tags Order Admin
+// @Accept json
+// @Produce json
+// @Param request body model.OrderBulkStatusModel true "Request Body"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/admin/orders/status [put]
+func (controller OrderAdminController) UpdateStatuses(c *fiber.Ctx) error {
+	var request model.OrderBulkStatusModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.OrderAdminService.UpdateStatuses(c.Context(), request)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
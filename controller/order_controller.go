@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"github.com/tech-hive/ecommerce/configuration"
 	"github.com/tech-hive/ecommerce/exception"
 	"github.com/tech-hive/ecommerce/middleware"
@@ -11,12 +12,13 @@ import (
 	"strconv"
 )
 
-func NewOrderController(orderService *service.OrderService, config configuration.Config) *OrderController {
-	return &OrderController{OrderService: *orderService, Config: config}
+func NewOrderController(orderService *service.OrderService, orderDocumentService *service.OrderDocumentService, config configuration.Config) *OrderController {
+	return &OrderController{OrderService: *orderService, OrderDocumentService: *orderDocumentService, Config: config}
 }
 
 type OrderController struct {
 	service.OrderService
+	service.OrderDocumentService
 	configuration.Config
 }
 
@@ -25,6 +27,9 @@ func (controller OrderController) Route(app *fiber.App) {
 	app.Post("/v1/api/orders", middleware.AuthenticateJWT("customer", controller.Config), controller.CreateOrder)
 	app.Get("/v1/api/orders", middleware.AuthenticateJWT("customer", controller.Config), controller.GetUserOrders)
 	app.Get("/v1/api/orders/:id", middleware.AuthenticateJWT("customer", controller.Config), controller.GetOrderById)
+	app.Get("/v1/api/orders/number/:number", middleware.AuthenticateJWT("admin", controller.Config), controller.GetOrderByNumber)
+	app.Get("/v1/api/orders/:id/invoice", middleware.AuthenticateJWT("customer", controller.Config), controller.GetInvoice)
+	app.Post("/v1/api/orders/:id/reorder", middleware.AuthenticateJWT("customer", controller.Config), controller.Reorder)
 	app.Put("/v1/api/orders/:id/status", middleware.AuthenticateJWT("admin", controller.Config), controller.UpdateOrderStatus)
 	app.Delete("/v1/api/orders/:id", middleware.AuthenticateJWT("customer", controller.Config), controller.CancelOrder)
 }
@@ -36,6 +41,7 @@ func (controller OrderController) Route(app *fiber.App) {
 // @Accept json
 // @Produce json
 // @Param request body model.CreateOrderModel true "Create order request"
+// @Param currency query string false "Currency to place the order in, when the body has none"
 // @Success 201 {object} model.GeneralResponse
 // @Router /v1/api/orders [post]
 // @Security JWT
@@ -50,7 +56,20 @@ func (controller OrderController) CreateOrder(c *fiber.Ctx) error {
 	userIdFloat := claims["user_id"].(float64)
 	userId := uint(userIdFloat)
 
+	// The order is placed in the display currency unless the body names one
+	if request.Currency == "" {
+		request.Currency = c.Query("currency")
+	}
+
 	order, err := controller.OrderService.CreateOrder(c.Context(), userId, request)
+	var cartChanged exception.CartChangedError
+	if errors.As(err, &cartChanged) {
+		return c.Status(fiber.StatusConflict).JSON(model.GeneralResponse{
+			Code:    409,
+			Message: cartChanged.Error(),
+			Data:    cartChanged.Warnings,
+		})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
 			Code:    400,
@@ -68,10 +87,17 @@ func (controller OrderController) CreateOrder(c *fiber.Ctx) error {
 
 // GetUserOrders godoc
 // @Summary Get user's orders
-// @Description Get all orders for the authenticated user
+// @Description Get orders for the authenticated user, paginated by page/limit or cursor
 // @Tags Orders
 // @Accept json
 // @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_by query string false "created_at, total or status"
+// @Param sort_order query string false "asc or desc"
+// @Param status query string false "Order status"
+// @Param number query string false "Order number, with or without hyphens"
 // @Success 200 {object} model.GeneralResponse
 // @Router /v1/api/orders [get]
 // @Security JWT
@@ -82,8 +108,22 @@ func (controller OrderController) GetUserOrders(c *fiber.Ctx) error {
 	userIdFloat := claims["user_id"].(float64)
 	userId := uint(userIdFloat)
 
-	orders, err := controller.OrderService.GetOrdersByUserId(c.Context(), userId)
+	var listQuery model.ListQueryModel
+	err := c.QueryParser(&listQuery)
+	exception.PanicLogging(err)
+	listQuery.Filters = map[string]string{}
+	if status := c.Query("status"); status != "" {
+		listQuery.Filters["status"] = status
+	}
+	if number := c.Query("number"); number != "" {
+		listQuery.Filters["number"] = number
+	}
+
+	orders, pageInfo, err := controller.OrderService.GetOrdersByUserId(c.Context(), userId, listQuery)
 	if err != nil {
+		if _, ok := err.(exception.ValidationError); ok {
+			return err
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.GeneralResponse{
 			Code:    500,
 			Message: "Error retrieving orders",
@@ -94,7 +134,13 @@ func (controller OrderController) GetUserOrders(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
 		Code:    200,
 		Message: "Success",
-		Data:    orders,
+		Data: map[string]interface{}{
+			"orders":      orders,
+			"total_count": pageInfo.TotalCount,
+			"page":        pageInfo.Page,
+			"limit":       pageInfo.Limit,
+			"next_cursor": pageInfo.NextCursor,
+		},
 	})
 }
 
@@ -140,6 +186,33 @@ func (controller OrderController) GetOrderById(c *fiber.Ctx) error {
 	})
 }
 
+// GetOrderByNumber godoc
+// @Summary Find order by number
+// @Description Find any customer's order by its order number, e.g. as read out over the phone (admin only)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param number path string true "Order number, with or without hyphens"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/orders/number/{number} [get]
+// @Security JWT
+func (controller OrderController) GetOrderByNumber(c *fiber.Ctx) error {
+	order, err := controller.OrderService.GetOrderByNumber(c.Context(), c.Params("number"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.GeneralResponse{
+			Code:    404,
+			Message: "Order not found",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    order,
+	})
+}
+
 // UpdateOrderStatus godoc
 // @Summary Update order status
 // @Description Update the status of an order (admin only)
@@ -221,4 +294,103 @@ func (controller OrderController) CancelOrder(c *fiber.Ctx) error {
 		Message: "Order cancelled successfully",
 		Data:    nil,
 	})
+}
+
+// GetInvoice godoc
+// @Summary Download order invoice or receipt
+// @Description Download the invoice of an order, or with type=receipt the receipt of its M-Pesa payment, as a PDF. Documents are numbered when first downloaded and read the same afterwards.
+// @Tags Orders
+// @Produce application/pdf
+// @Param id path int true "Order ID"
+// @Param type query string false "invoice (default) or receipt"
+// @Success 200 {file} file
+// @Router /v1/api/orders/{id}/invoice [get]
+// @Security JWT
+func (controller OrderController) GetInvoice(c *fiber.Ctx) error {
+	orderId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid order ID",
+			Data:    err.Error(),
+		})
+	}
+
+	// Get user ID from JWT token
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIdFloat := claims["user_id"].(float64)
+	userId := uint(userIdFloat)
+
+	document, err := controller.OrderDocumentService.GetDocument(c.Context(), uint(orderId), userId, c.Query("type", "invoice"))
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if err.Error() == "order not found" {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(model.GeneralResponse{
+			Code:    status,
+			Message: "Error getting order document",
+			Data:    err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+document.Number+`.pdf"`)
+	return c.Status(fiber.StatusOK).Send(document.Content)
+}
+
+// Reorder godoc
+// @Summary Buy an order again
+// @Description Put the items of a past order back into the default cart at today's prices. Items no longer sold are left out and items short of stock are added as far as the stock goes; the response reports what was added and what was not.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/orders/{id}/reorder [post]
+// @Security JWT
+func (controller OrderController) Reorder(c *fiber.Ctx) error {
+	orderId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid order ID",
+			Data:    err.Error(),
+		})
+	}
+
+	// Get user ID from JWT token
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIdFloat := claims["user_id"].(float64)
+	userId := uint(userIdFloat)
+
+	result, err := controller.OrderService.Reorder(c.Context(), uint(orderId), userId)
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if err.Error() == "order not found" {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(model.GeneralResponse{
+			Code:    status,
+			Message: "Error reordering",
+			Data:    err.Error(),
+		})
+	}
+
+	message := "Items added to cart"
+	if len(result.NotAdded) > 0 {
+		message = "Some items could not be added to cart"
+	}
+	for _, item := range result.Added {
+		if item.Added < item.Ordered {
+			message = "Some items could not be added to cart"
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: message,
+		Data:    result,
+	})
 }
\ No newline at end of file
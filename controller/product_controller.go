@@ -6,24 +6,36 @@ import (
 	"github.com/tech-hive/ecommerce/middleware"
 	"github.com/tech-hive/ecommerce/model"
 	"github.com/tech-hive/ecommerce/service"
+	goredis "github.com/go-redis/redis/v9"
 	"github.com/gofiber/fiber/v2"
+	"strconv"
+	"time"
 )
 
 type ProductController struct {
 	service.ProductService
+	service.CurrencyService
 	configuration.Config
+	Cache *goredis.Client
 }
 
-func NewProductController(productService *service.ProductService, config configuration.Config) *ProductController {
-	return &ProductController{ProductService: *productService, Config: config}
+func NewProductController(productService *service.ProductService, currencyService *service.CurrencyService, config configuration.Config, cache *goredis.Client) *ProductController {
+	return &ProductController{ProductService: *productService, CurrencyService: *currencyService, Config: config, Cache: cache}
 }
 
 func (controller ProductController) Route(app *fiber.App) {
+  	catalogueCacheTtl, err := strconv.Atoi(controller.Config.Get("CACHE_PRODUCT_CATALOGUE_TTL_SECONDS"))
+  	exception.PanicLogging(err)
+  	catalogueCache := middleware.CacheResponse(controller.Cache, configuration.ProductCatalogueCacheNamespace, time.Duration(catalogueCacheTtl)*time.Second)
+
   	app.Post("/v1/api/product", middleware.AuthenticateJWT("admin", controller.Config), controller.Create)
   	app.Put("/v1/api/product/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.Update)
   	app.Delete("/v1/api/product/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.Delete)
+  	app.Get("/v1/api/product/archived", middleware.AuthenticateJWT("admin", controller.Config), controller.FindArchived)
+  	app.Put("/v1/api/product/:id/restore", middleware.AuthenticateJWT("admin", controller.Config), controller.Restore)
+  	app.Delete("/v1/api/product/:id/purge", middleware.AuthenticateJWT("admin", controller.Config), controller.Purge)
   	app.Get("/v1/api/product/:id", middleware.AuthenticateJWT("customer", controller.Config), controller.FindById)
-  	app.Get("/v1/api/product", controller.FindAll) // Public endpoint for browsing products
+  	app.Get("/v1/api/product", catalogueCache, controller.FindAll) // Public endpoint for browsing products
   	app.Post("/v1/api/product/search", middleware.AuthenticateJWT("customer", controller.Config), controller.Search)
   }
 
@@ -75,9 +87,9 @@ func (controller ProductController) Update(c *fiber.Ctx) error {
 	})
 }
 
-// Delete func delete one exists product.
-// @Description delete one exists product.
-// @Summary delete one exists product
+// Delete func archive one exists product.
+// @Description archive one exists product. It is hidden from the catalogue and removed from carts, but orders still show it.
+// @Summary archive one exists product
 // @Tags Product
 // @Accept json
 // @Produce json
@@ -95,6 +107,83 @@ func (controller ProductController) Delete(c *fiber.Ctx) error {
 	})
 }
 
+// Restore func restore one archived product.
+// @Description restore one archived product to the catalogue.
+// @Summary restore one archived product
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/product/{id}/restore [put]
+func (controller ProductController) Restore(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	result := controller.ProductService.Restore(c.Context(), id)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    result,
+	})
+}
+
+// Purge func permanently delete one archived product.
+// @Description permanently delete one archived product. Refused while any order refers to it.
+// @Summary permanently delete one archived product
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/product/{id}/purge [delete]
+func (controller ProductController) Purge(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	controller.ProductService.Purge(c.Context(), id)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+	})
+}
+
+// FindArchived func gets a page of archived products.
+// @Description Get a page of archived products.
+// @Summary get a page of archived products
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_by query string false "name, price, stock, rating or created_at"
+// @Param sort_order query string false "asc or desc"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/product/archived [get]
+func (controller ProductController) FindArchived(c *fiber.Ctx) error {
+	var request model.ListQueryModel
+	err := c.QueryParser(&request)
+	exception.PanicLogging(err)
+
+	products, pageInfo := controller.ProductService.FindArchived(c.Context(), request)
+
+	response := map[string]interface{}{
+		"products":    products,
+		"total_count": pageInfo.TotalCount,
+		"page":        pageInfo.Page,
+		"limit":       pageInfo.Limit,
+		"next_cursor": pageInfo.NextCursor,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
 // FindById func gets one exists product.
 // @Description Get one exists product.
 // @Summary get one exists product
@@ -102,6 +191,7 @@ func (controller ProductController) Delete(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Product Id"
+// @Param currency query string false "Display currency, KES by default"
 // @Success 200 {object} model.GeneralResponse
 // @Security JWT
 // @Router /v1/api/product/{id} [get]
@@ -109,6 +199,7 @@ func (controller ProductController) FindById(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	result := controller.ProductService.FindById(c.Context(), id)
+	result = controller.CurrencyService.ConvertProduct(c.Context(), c.Query("currency"), result)
 	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
 		Code:    200,
 		Message: "Success",
@@ -116,23 +207,37 @@ func (controller ProductController) FindById(c *fiber.Ctx) error {
 	})
 }
 
-// FindAll func gets all exists products.
-// @Description Get all exists products.
-// @Summary get all exists products
+// FindAll func gets a page of exists products.
+// @Description Get a page of exists products. Responses carry ETag/Last-Modified and are cached until a product changes.
+// @Summary get a page of exists products
 // @Tags Product
 // @Accept json
 // @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param category query string false "Product category"
+// @Param attr query []string false "Attribute filter: code:value, code:value1|value2 or code:min..max"
+// @Param sort_by query string false "name, price, stock or created_at"
+// @Param sort_order query string false "asc or desc"
+// @Param currency query string false "Display currency, KES by default"
 // @Success 200 {object} model.GeneralResponse
-// @Security JWT
+// @Success 304 "Not Modified"
 // @Router /v1/api/product [get]
 func (controller ProductController) FindAll(c *fiber.Ctx) error {
-  	products, totalCount := controller.ProductService.FindAll(c.Context())
+  	var request model.ProductSearchModel
+  	err := c.QueryParser(&request)
+  	exception.PanicLogging(err)
+
+  	products, pageInfo := controller.ProductService.FindAll(c.Context(), request)
+  	products = controller.CurrencyService.ConvertProducts(c.Context(), c.Query("currency"), products)
 
   	response := map[string]interface{}{
   		"products":    products,
-  		"total_count": totalCount,
-  		"page":        1,
-  		"limit":       20,
+  		"total_count": pageInfo.TotalCount,
+  		"page":        pageInfo.Page,
+  		"limit":       pageInfo.Limit,
+  		"next_cursor": pageInfo.NextCursor,
   	}
 
   	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
@@ -149,6 +254,7 @@ func (controller ProductController) FindAll(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param request body model.ProductSearchModel true "Search parameters"
+// @Param currency query string false "Display currency, KES by default"
 // @Success 200 {object} model.GeneralResponse
 // @Security JWT
 // @Router /v1/api/product/search [post]
@@ -157,13 +263,15 @@ func (controller ProductController) Search(c *fiber.Ctx) error {
  	err := c.BodyParser(&request)
  	exception.PanicLogging(err)
 
- 	products, totalCount := controller.ProductService.Search(c.Context(), request)
+ 	products, pageInfo := controller.ProductService.Search(c.Context(), request)
+ 	products = controller.CurrencyService.ConvertProducts(c.Context(), c.Query("currency"), products)
 
  	response := map[string]interface{}{
  		"products":    products,
- 		"total_count": totalCount,
- 		"page":        request.Page,
- 		"limit":       request.Limit,
+ 		"total_count": pageInfo.TotalCount,
+ 		"page":        pageInfo.Page,
+ 		"limit":       pageInfo.Limit,
+ 		"next_cursor": pageInfo.NextCursor,
  	}
 
  	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
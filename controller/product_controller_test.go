@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/tech-hive/ecommerce/configuration"
 	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"net/http/httptest"
@@ -19,7 +20,7 @@ func TestProductController_Create_Success(t *testing.T) {
 	mockProductService := new(MockProductService)
 
 	// Create controller with mock service
-	controller := NewProductController(&mockProductService, config)
+	controller := NewProductController(&mockProductService, &currencyService, config, nil)
 
 	// Create Fiber app
 	app := fiber.New()
@@ -31,7 +32,7 @@ func TestProductController_Create_Success(t *testing.T) {
 	productRequest := model.ProductCreateOrUpdateModel{
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       99.99,
+		Price:       money.New(99, 99),
 		Stock:       10,
 		ImageUrl:    "https://example.com/test.jpg",
 	}
@@ -61,7 +62,7 @@ func TestProductController_Create_ValidationError(t *testing.T) {
 	// Setup
 	config := configuration.New()
 	mockProductService := new(MockProductService)
-	controller := NewProductController(&mockProductService, config)
+	controller := NewProductController(&mockProductService, &currencyService, config, nil)
 	app := fiber.New()
 	controller.Route(app)
 
@@ -69,7 +70,7 @@ func TestProductController_Create_ValidationError(t *testing.T) {
 	productRequest := model.ProductCreateOrUpdateModel{
 		Name:        "", // Invalid: empty name
 		Description: "Test Description",
-		Price:       99.99,
+		Price:       money.New(99, 99),
 		Stock:       10,
 	}
 
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"bytes"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+)
+
+func NewProductImportController(importService *service.ProductImportService, config configuration.Config) *ProductImportController {
+	return &ProductImportController{ProductImportService: *importService, Config: config}
+}
+
+type ProductImportController struct {
+	service.ProductImportService
+	configuration.Config
+}
+
+// Route must be registered before ProductController so /v1/api/product/export isn't matched as a product id.
+func (controller ProductImportController) Route(app *fiber.App) {
+	app.Post("/v1/api/product/import", middleware.AuthenticateJWT("admin", controller.Config), controller.Import)
+	app.Get("/v1/api/product/import/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.FindJobById)
+	app.Get("/v1/api/product/export", middleware.AuthenticateJWT("admin", controller.Config), controller.Export)
+}
+
+// Import func bulk import products.
+// @Description Upload a CSV (with a header row) or JSON array of products. Rows are validated like a single product, matched by id then sku, and upserted in the background; poll the returned job for progress and per-row errors.
+// @Summary bulk import products
+// @Tags Product
+// @Accept plain
+// @Produce json
+// @Param format query string false "csv or json, defaults to the request Content-Type"
+// @Param request body string true "CSV or JSON file content"
+// @Success 202 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/product/import [post]
+func (controller ProductImportController) Import(c *fiber.Ctx) error {
+	format := productImportFormat(c)
+	if len(c.Body()) == 0 {
+		panic(common.NewValidationError("file", "this field is required"))
+	}
+
+	response := controller.ProductImportService.Import(c.Context(), format, c.Body())
+	return c.Status(fiber.StatusAccepted).JSON(model.GeneralResponse{
+		Code:    202,
+		Message: "Import started",
+		Data:    response,
+	})
+}
+
+// FindJobById func gets the status of a product import.
+// @Description Get the progress, counts and per-row errors of a product import.
+// @Summary get product import status
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Import Job Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/product/import/{id} [get]
+func (controller ProductImportController) FindJobById(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	result := controller.ProductImportService.FindJobById(c.Context(), id)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    result,
+	})
+}
+
+// Export func export all products.
+// @Description Download every product as CSV or JSON in the same layout the import accepts.
+// @Summary export products
+// @Tags Product
+// @Produce plain
+// @Param format query string false "csv (default) or json"
+// @Success 200 {string} string "Product file"
+// @Security JWT
+// @Router /v1/api/product/export [get]
+func (controller ProductImportController) Export(c *fiber.Ctx) error {
+	format := c.Query("format", "csv")
+
+	var buffer bytes.Buffer
+	err := controller.ProductImportService.Export(c.Context(), format, &buffer)
+	exception.PanicLogging(err)
+
+	c.Attachment("products." + format)
+	return c.Status(fiber.StatusOK).Send(buffer.Bytes())
+}
+
+// productImportFormat takes the format from the query string, falling back to the Content-Type.
+func productImportFormat(c *fiber.Ctx) string {
+	if format := c.Query("format"); format != "" {
+		return strings.ToLower(format)
+	}
+	contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+	switch {
+	case strings.Contains(contentType, "csv"):
+		return "csv"
+	case strings.Contains(contentType, "json"):
+		return "json"
+	}
+	return ""
+}
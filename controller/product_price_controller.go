@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+func NewProductPriceController(priceService *service.ProductPriceService, config configuration.Config) *ProductPriceController {
+	return &ProductPriceController{ProductPriceService: *priceService, Config: config}
+}
+
+type ProductPriceController struct {
+	service.ProductPriceService
+	configuration.Config
+}
+
+func (controller ProductPriceController) Route(app *fiber.App) {
+	app.Get("/v1/api/product/:id/price-history", middleware.AuthenticateJWT("admin", controller.Config), controller.FindHistory)
+	app.Get("/v1/api/product/:id/price-schedules", middleware.AuthenticateJWT("admin", controller.Config), controller.FindSchedules)
+	app.Post("/v1/api/product/:id/price-schedules", middleware.AuthenticateJWT("admin", controller.Config), controller.CreateSchedule)
+	app.Delete("/v1/api/price-schedules/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.CancelSchedule)
+}
+
+// CreateSchedule func schedule a price change or sale.
+// @Description schedule a new regular price from starts_at, or a sale price between starts_at and ends_at. Schedules already due apply immediately.
+// @Summary schedule a price change or sale
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product Id"
+// @Param request body model.ProductPriceScheduleCreateModel true "Request Body"
+// @Success 201 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/product/{id}/price-schedules [post]
+func (controller ProductPriceController) CreateSchedule(c *fiber.Ctx) error {
+	var request model.ProductPriceScheduleCreateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.ProductPriceService.CreateSchedule(c.Context(), c.Params("id"), request)
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// FindSchedules func gets a page of price schedules of a product.
+// @Description Get a page of price schedules of a product, optionally by type or status.
+// @Summary get price schedules of a product
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product Id"
+// @Param type query string false "price or sale"
+// @Param status query string false "pending, active, completed or cancelled"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_by query string false "starts_at or created_at"
+// @Param sort_order query string false "asc or desc"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/product/{id}/price-schedules [get]
+func (controller ProductPriceController) FindSchedules(c *fiber.Ctx) error {
+	var listQuery model.ListQueryModel
+	err := c.QueryParser(&listQuery)
+	exception.PanicLogging(err)
+	listQuery.Filters = map[string]string{}
+	for _, key := range []string{"type", "status"} {
+		if value := c.Query(key); value != "" {
+			listQuery.Filters[key] = value
+		}
+	}
+
+	schedules, pageInfo := controller.ProductPriceService.FindSchedules(c.Context(), c.Params("id"), listQuery)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"schedules":   schedules,
+			"total_count": pageInfo.TotalCount,
+			"page":        pageInfo.Page,
+			"limit":       pageInfo.Limit,
+			"next_cursor": pageInfo.NextCursor,
+		},
+	})
+}
+
+// CancelSchedule func cancel a price schedule.
+// @Description cancel a pending price schedule, or end a running sale now and restore the regular price.
+// @Summary cancel a price schedule
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/price-schedules/{id} [delete]
+func (controller ProductPriceController) CancelSchedule(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid schedule ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.ProductPriceService.CancelSchedule(c.Context(), uint(id))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// FindHistory func gets a page of price changes of a product.
+// @Description Get a page of price changes of a product, newest first by default.
+// @Summary get price history of a product
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product Id"
+// @Param reason query string false "manual, import, scheduled, sale_started or sale_ended"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_order query string false "asc or desc"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/product/{id}/price-history [get]
+func (controller ProductPriceController) FindHistory(c *fiber.Ctx) error {
+	var listQuery model.ListQueryModel
+	err := c.QueryParser(&listQuery)
+	exception.PanicLogging(err)
+	if reason := c.Query("reason"); reason != "" {
+		listQuery.Filters = map[string]string{"reason": reason}
+	}
+
+	histories, pageInfo := controller.ProductPriceService.FindHistory(c.Context(), c.Params("id"), listQuery)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"history":     histories,
+			"total_count": pageInfo.TotalCount,
+			"page":        pageInfo.Page,
+			"limit":       pageInfo.Limit,
+			"next_cursor": pageInfo.NextCursor,
+		},
+	})
+}
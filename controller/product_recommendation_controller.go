@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewProductRecommendationController(recommendationService *service.ProductRecommendationService) *ProductRecommendationController {
+	return &ProductRecommendationController{ProductRecommendationService: *recommendationService}
+}
+
+type ProductRecommendationController struct {
+	service.ProductRecommendationService
+}
+
+func (controller ProductRecommendationController) Route(app *fiber.App) {
+	app.Get("/v1/api/product/:id/recommendations", controller.FindByProductId) // Public endpoint
+}
+
+// FindByProductId func gets recommendations for a product.
+// @Description Get products frequently bought together with a product and related products. Recommendations are refreshed periodically from order history and categories.
+// @Summary get product recommendations
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product Id"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/product/{id}/recommendations [get]
+func (controller ProductRecommendationController) FindByProductId(c *fiber.Ctx) error {
+	response := controller.ProductRecommendationService.FindByProductId(c.Context(), c.Params("id"))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+)
+
+func NewProductReviewController(reviewService *service.ProductReviewService, config configuration.Config) *ProductReviewController {
+	return &ProductReviewController{ProductReviewService: *reviewService, Config: config}
+}
+
+type ProductReviewController struct {
+	service.ProductReviewService
+	configuration.Config
+}
+
+func (controller ProductReviewController) Route(app *fiber.App) {
+	app.Post("/v1/api/product/:id/reviews", middleware.AuthenticateJWT("customer", controller.Config), controller.Create)
+	app.Get("/v1/api/product/:id/reviews", controller.FindByProductId) // Public endpoint for approved reviews
+	app.Get("/v1/api/reviews", middleware.AuthenticateJWT("admin", controller.Config), controller.FindAll)
+	app.Put("/v1/api/reviews/:id/status", middleware.AuthenticateJWT("admin", controller.Config), controller.Moderate)
+}
+
+// Create godoc
+// @Summary Review a product
+// @Description Leave a 1-5 star review for a product from one of the user's delivered orders. Reviews are published after moderation.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Product Id"
+// @Param request body model.ProductReviewCreateModel true "Review"
+// @Success 201 {object} model.GeneralResponse
+// @Router /v1/api/product/{id}/reviews [post]
+// @Security JWT
+func (controller ProductReviewController) Create(c *fiber.Ctx) error {
+	var request model.ProductReviewCreateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	// Get user ID from JWT token
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIdFloat := claims["user_id"].(float64)
+	userId := uint(userIdFloat)
+
+	review, err := controller.ProductReviewService.Create(c.Context(), userId, c.Params("id"), request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error creating review",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Review submitted for moderation",
+		Data:    review,
+	})
+}
+
+// FindByProductId godoc
+// @Summary Get product reviews
+// @Description Get approved reviews of a product
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Product Id"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_by query string false "created_at or rating"
+// @Param sort_order query string false "asc or desc"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/product/{id}/reviews [get]
+func (controller ProductReviewController) FindByProductId(c *fiber.Ctx) error {
+	var listQuery model.ListQueryModel
+	err := c.QueryParser(&listQuery)
+	exception.PanicLogging(err)
+
+	reviews, pageInfo, err := controller.ProductReviewService.FindByProductId(c.Context(), c.Params("id"), listQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"reviews":     reviews,
+			"total_count": pageInfo.TotalCount,
+			"page":        pageInfo.Page,
+			"limit":       pageInfo.Limit,
+			"next_cursor": pageInfo.NextCursor,
+		},
+	})
+}
+
+// FindAll godoc
+// @Summary Get reviews for moderation
+// @Description Get reviews of all products, optionally by status (admin only)
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param status query string false "pending, approved or hidden"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_by query string false "created_at or rating"
+// @Param sort_order query string false "asc or desc"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/reviews [get]
+// @Security JWT
+func (controller ProductReviewController) FindAll(c *fiber.Ctx) error {
+	var listQuery model.ListQueryModel
+	err := c.QueryParser(&listQuery)
+	exception.PanicLogging(err)
+	if status := c.Query("status"); status != "" {
+		listQuery.Filters = map[string]string{"status": status}
+	}
+
+	reviews, pageInfo, err := controller.ProductReviewService.FindAll(c.Context(), listQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"reviews":     reviews,
+			"total_count": pageInfo.TotalCount,
+			"page":        pageInfo.Page,
+			"limit":       pageInfo.Limit,
+			"next_cursor": pageInfo.NextCursor,
+		},
+	})
+}
+
+// Moderate godoc
+// @Summary Moderate a review
+// @Description Approve or hide a review (admin only). Only approved reviews count towards the product rating.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param request body model.ProductReviewModerationModel true "Moderation decision"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/reviews/{id}/status [put]
+// @Security JWT
+func (controller ProductReviewController) Moderate(c *fiber.Ctx) error {
+	var request model.ProductReviewModerationModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	reviewId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid review ID",
+			Data:    err.Error(),
+		})
+	}
+
+	review, err := controller.ProductReviewService.Moderate(c.Context(), uint(reviewId), request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error moderating review",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Review moderated successfully",
+		Data:    review,
+	})
+}
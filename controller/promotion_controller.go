@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+func NewPromotionController(promotionService *service.PromotionService, config configuration.Config) *PromotionController {
+	return &PromotionController{PromotionService: *promotionService, Config: config}
+}
+
+type PromotionController struct {
+	service.PromotionService
+	configuration.Config
+}
+
+func (controller PromotionController) Route(app *fiber.App) {
+	app.Post("/v1/api/promotion", middleware.AuthenticateJWT("admin", controller.Config), controller.Create)
+	app.Get("/v1/api/promotion", middleware.AuthenticateJWT("admin", controller.Config), controller.FindAll)
+	app.Get("/v1/api/promotion/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.FindById)
+	app.Put("/v1/api/promotion/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.Update)
+	app.Delete("/v1/api/promotion/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.Delete)
+}
+
+// Create func create a promotion.
+// @Description create a coupon, or an automatic promotion when code is empty. Types are percentage, fixed, free_shipping and buy_x_get_y, scoped to the order, a product or a category.
+// @Summary create a promotion
+// @Tags Promotion
+// @Accept json
+// @Produce json
+// @Param request body model.PromotionCreateOrUpdateModel true "Request Body"
+// @Success 201 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/promotion [post]
+func (controller PromotionController) Create(c *fiber.Ctx) error {
+	var request model.PromotionCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.PromotionService.Create(c.Context(), request)
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// Update func update a promotion.
+// @Description update a promotion. Orders keep the discounts they were given.
+// @Summary update a promotion
+// @Tags Promotion
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion Id"
+// @Param request body model.PromotionCreateOrUpdateModel true "Request Body"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/promotion/{id} [put]
+func (controller PromotionController) Update(c *fiber.Ctx) error {
+	var request model.PromotionCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid promotion ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.PromotionService.Update(c.Context(), uint(id), request)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// Delete func delete a promotion.
+// @Description delete a promotion. Orders keep the discounts they were given.
+// @Summary delete a promotion
+// @Tags Promotion
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/promotion/{id} [delete]
+func (controller PromotionController) Delete(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid promotion ID",
+			Data:    err.Error(),
+		})
+	}
+
+	controller.PromotionService.Delete(c.Context(), uint(id))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+	})
+}
+
+// FindById func gets one promotion.
+// @Description Get one promotion with its usage count.
+// @Summary get one promotion
+// @Tags Promotion
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/promotion/{id} [get]
+func (controller PromotionController) FindById(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid promotion ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.PromotionService.FindById(c.Context(), uint(id))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// FindAll func gets a page of promotions.
+// @Description Get a page of promotions, optionally by type or scope.
+// @Summary get all promotions
+// @Tags Promotion
+// @Accept json
+// @Produce json
+// @Param type query string false "percentage, fixed, free_shipping or buy_x_get_y"
+// @Param scope query string false "order, product or category"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_by query string false "created_at or name"
+// @Param sort_order query string false "asc or desc"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/promotion [get]
+func (controller PromotionController) FindAll(c *fiber.Ctx) error {
+	var listQuery model.ListQueryModel
+	err := c.QueryParser(&listQuery)
+	exception.PanicLogging(err)
+	listQuery.Filters = map[string]string{}
+	for _, key := range []string{"type", "scope"} {
+		if value := c.Query(key); value != "" {
+			listQuery.Filters[key] = value
+		}
+	}
+
+	promotions, pageInfo := controller.PromotionService.FindAll(c.Context(), listQuery)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"promotions":  promotions,
+			"total_count": pageInfo.TotalCount,
+			"page":        pageInfo.Page,
+			"limit":       pageInfo.Limit,
+			"next_cursor": pageInfo.NextCursor,
+		},
+	})
+}
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+func NewShippingController(shippingService *service.ShippingService, config configuration.Config) *ShippingController {
+	return &ShippingController{ShippingService: *shippingService, Config: config}
+}
+
+type ShippingController struct {
+	service.ShippingService
+	configuration.Config
+}
+
+func (controller ShippingController) Route(app *fiber.App) {
+	app.Get("/v1/api/shipping/zones", middleware.AuthenticateJWT("admin", controller.Config), controller.FindZones)
+	app.Post("/v1/api/shipping/zones", middleware.AuthenticateJWT("admin", controller.Config), controller.CreateZone)
+	app.Get("/v1/api/shipping/zones/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.FindZoneById)
+	app.Put("/v1/api/shipping/zones/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.UpdateZone)
+	app.Delete("/v1/api/shipping/zones/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.DeleteZone)
+	app.Post("/v1/api/shipping/zones/:id/methods", middleware.AuthenticateJWT("admin", controller.Config), controller.CreateMethod)
+	app.Put("/v1/api/shipping/methods/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.UpdateMethod)
+	app.Delete("/v1/api/shipping/methods/:id", middleware.AuthenticateJWT("admin", controller.Config), controller.DeleteMethod)
+}
+
+// FindZones func gets all shipping zones.
+// @Description Get all shipping zones with their counties, towns and shipping methods.
+// @Summary get all shipping zones
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/shipping/zones [get]
+func (controller ShippingController) FindZones(c *fiber.Ctx) error {
+	response := controller.ShippingService.FindZones(c.Context())
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// CreateZone func create a shipping zone.
+// @Description create a shipping zone of counties and towns. A location without a town covers the rest of its county.
+// @Summary create a shipping zone
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param request body model.ShippingZoneCreateOrUpdateModel true "Request Body"
+// @Success 201 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/shipping/zones [post]
+func (controller ShippingController) CreateZone(c *fiber.Ctx) error {
+	var request model.ShippingZoneCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.ShippingService.CreateZone(c.Context(), request)
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// FindZoneById func gets one shipping zone.
+// @Description Get one shipping zone with its counties, towns and shipping methods.
+// @Summary get one shipping zone
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping Zone Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/shipping/zones/{id} [get]
+func (controller ShippingController) FindZoneById(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid shipping zone ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.ShippingService.FindZoneById(c.Context(), uint(id))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// UpdateZone func update a shipping zone.
+// @Description rename a shipping zone and replace its counties and towns.
+// @Summary update a shipping zone
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping Zone Id"
+// @Param request body model.ShippingZoneCreateOrUpdateModel true "Request Body"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/shipping/zones/{id} [put]
+func (controller ShippingController) UpdateZone(c *fiber.Ctx) error {
+	var request model.ShippingZoneCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid shipping zone ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.ShippingService.UpdateZone(c.Context(), uint(id), request)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// DeleteZone func delete a shipping zone.
+// @Description delete a shipping zone with its methods. Orders keep the shipping they were charged.
+// @Summary delete a shipping zone
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping Zone Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/shipping/zones/{id} [delete]
+func (controller ShippingController) DeleteZone(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid shipping zone ID",
+			Data:    err.Error(),
+		})
+	}
+
+	controller.ShippingService.DeleteZone(c.Context(), uint(id))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+	})
+}
+
+// CreateMethod func create a shipping method.
+// @Description create a standard, express or pickup station method of a zone, charged a flat fee or by weight or order value brackets.
+// @Summary create a shipping method
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping Zone Id"
+// @Param request body model.ShippingMethodCreateOrUpdateModel true "Request Body"
+// @Success 201 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/shipping/zones/{id}/methods [post]
+func (controller ShippingController) CreateMethod(c *fiber.Ctx) error {
+	var request model.ShippingMethodCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	zoneId, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid shipping zone ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.ShippingService.CreateMethod(c.Context(), uint(zoneId), request)
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// UpdateMethod func update a shipping method.
+// @Description update a shipping method and replace its rate brackets. Orders keep the shipping they were charged.
+// @Summary update a shipping method
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping Method Id"
+// @Param request body model.ShippingMethodCreateOrUpdateModel true "Request Body"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/shipping/methods/{id} [put]
+func (controller ShippingController) UpdateMethod(c *fiber.Ctx) error {
+	var request model.ShippingMethodCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid shipping method ID",
+			Data:    err.Error(),
+		})
+	}
+
+	response := controller.ShippingService.UpdateMethod(c.Context(), uint(id), request)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// DeleteMethod func delete a shipping method.
+// @Description delete a shipping method. Orders keep the shipping they were charged.
+// @Summary delete a shipping method
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping Method Id"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/shipping/methods/{id} [delete]
+func (controller ShippingController) DeleteMethod(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Invalid shipping method ID",
+			Data:    err.Error(),
+		})
+	}
+
+	controller.ShippingService.DeleteMethod(c.Context(), uint(id))
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+	})
+}
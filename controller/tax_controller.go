@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewTaxController(taxService *service.TaxService, config configuration.Config) *TaxController {
+	return &TaxController{TaxService: *taxService, Config: config}
+}
+
+type TaxController struct {
+	service.TaxService
+	configuration.Config
+}
+
+func (controller TaxController) Route(app *fiber.App) {
+	app.Get("/v1/api/tax-class", middleware.AuthenticateJWT("admin", controller.Config), controller.FindAll)
+	app.Post("/v1/api/tax-class", middleware.AuthenticateJWT("admin", controller.Config), controller.Create)
+	app.Put("/v1/api/tax-class/:code", middleware.AuthenticateJWT("admin", controller.Config), controller.Update)
+}
+
+// FindAll func gets all tax classes.
+// @Description Get all tax classes with their VAT rates.
+// @Summary get all tax classes
+// @Tags Tax
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/tax-class [get]
+func (controller TaxController) FindAll(c *fiber.Ctx) error {
+	response := controller.TaxService.FindAll(c.Context())
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// Create func create a tax class.
+// @Description create a tax class that products can be assigned to.
+// @Summary create a tax class
+// @Tags Tax
+// @Accept json
+// @Produce json
+// @Param request body model.TaxClassCreateOrUpdateModel true "Request Body"
+// @Success 201 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/tax-class [post]
+func (controller TaxController) Create(c *fiber.Ctx) error {
+	var request model.TaxClassCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.TaxService.Create(c.Context(), request)
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    response,
+	})
+}
+
+// Update func update a tax class.
+// @Description update the name or rate of a tax class. Placed orders keep the tax they were charged.
+// @Summary update a tax class
+// @Tags Tax
+// @Accept json
+// @Produce json
+// @Param code path string true "Tax Class Code"
+// @Param request body model.TaxClassCreateOrUpdateModel true "Request Body"
+// @Success 200 {object} model.GeneralResponse
+// @Security JWT
+// @Router /v1/api/tax-class/{code} [put]
+func (controller TaxController) Update(c *fiber.Ctx) error {
+	var request model.TaxClassCreateOrUpdateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	response := controller.TaxService.Update(c.Context(), c.Params("code"), request)
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    response,
+	})
+}
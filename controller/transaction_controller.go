@@ -95,14 +95,29 @@ func (controller TransactionController) FindById(c *fiber.Ctx) error {
 // @Tags Transaction
 // @Accept json
 // @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_by query string false "id or total_price"
+// @Param sort_order query string false "asc or desc"
 // @Success 200 {object} model.GeneralResponse
 // @Security JWT
 // @Router /v1/api/transaction [get]
 func (controller TransactionController) FindAll(c *fiber.Ctx) error {
-	result := controller.TransactionService.FindAll(c.Context())
+	var listQuery model.ListQueryModel
+	err := c.QueryParser(&listQuery)
+	exception.PanicLogging(err)
+
+	result, pageInfo := controller.TransactionService.FindAll(c.Context(), listQuery)
 	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
 		Code:    200,
 		Message: "Success",
-		Data:    result,
+		Data: map[string]interface{}{
+			"transactions": result,
+			"total_count":  pageInfo.TotalCount,
+			"page":         pageInfo.Page,
+			"limit":        pageInfo.Limit,
+			"next_cursor":  pageInfo.NextCursor,
+		},
 	})
 }
@@ -9,12 +9,13 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewUserController(userService *service.UserService, config configuration.Config) *UserController {
-	return &UserController{UserService: *userService, Config: config}
+func NewUserController(userService *service.UserService, cartService *service.CartService, config configuration.Config) *UserController {
+	return &UserController{UserService: *userService, CartService: *cartService, Config: config}
 }
 
 type UserController struct {
 	service.UserService
+	service.CartService
 	configuration.Config
 }
 
@@ -24,12 +25,13 @@ func (controller UserController) Route(app *fiber.App) {
 }
 
 // Authentication func Authenticate user.
-// @Description authenticate user.
+// @Description authenticate user. A guest cart sent with the cart token is merged into the customer's cart.
 // @Summary authenticate user
 // @Tags Authenticate user
 // @Accept json
 // @Produce json
 // @Param request body model.UserModel true "Request Body"
+// @Param X-Cart-Token header string false "Guest cart token"
 // @Success 200 {object} model.GeneralResponse
 // @Router /v1/api/authentication [post]
 func (controller UserController) Authentication(c *fiber.Ctx) error {
@@ -38,6 +40,9 @@ func (controller UserController) Authentication(c *fiber.Ctx) error {
 	exception.PanicLogging(err)
 
 	result := controller.UserService.Authentication(c.Context(), request)
+	if result.Role == "customer" {
+		controller.mergeGuestCart(c, result.Id)
+	}
 	tokenJwtResult := common.GenerateToken(result.Email, result.Role, result.Id, controller.Config)
 	resultWithToken := map[string]interface{}{
 		"token": tokenJwtResult,
@@ -57,12 +62,13 @@ func (controller UserController) Authentication(c *fiber.Ctx) error {
 }
 
 // Register func Register new user.
-// @Description register new user.
+// @Description register new user. A guest cart sent with the cart token is merged into the new customer's cart.
 // @Summary register new user
 // @Tags Register user
 // @Accept json
 // @Produce json
 // @Param request body model.UserRegistrationModel true "Request Body"
+// @Param X-Cart-Token header string false "Guest cart token"
 // @Success 201 {object} model.GeneralResponse
 // @Router /v1/api/users [post]
 func (controller UserController) Register(c *fiber.Ctx) error {
@@ -71,9 +77,35 @@ func (controller UserController) Register(c *fiber.Ctx) error {
 	exception.PanicLogging(err)
 
 	result := controller.UserService.Register(c.Context(), request)
+	if result.Role == "customer" {
+		controller.mergeGuestCart(c, result.Id)
+	}
 	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
 		Code:    201,
 		Message: "User registered successfully",
 		Data:    result,
 	})
 }
+
+// mergeGuestCart moves the cart of the guest's cart token into the customer's cart and drops the
+// token, when a guest logs in or registers. Login and registration still succeed if the merge fails;
+// the guest cart is kept for a later attempt.
+func (controller UserController) mergeGuestCart(c *fiber.Ctx, userId uint) {
+	token := c.Get(common.CartTokenHeader)
+	if token == "" {
+		token = c.Cookies(common.CartTokenCookie)
+	}
+	if token == "" {
+		return
+	}
+	guestId, err := common.ParseCartToken(token, controller.Config)
+	if err != nil {
+		return
+	}
+
+	if _, err := controller.CartService.MergeGuestCart(c.Context(), userId, guestId); err != nil {
+		common.NewLogger().Error("Failed to merge guest cart into cart of user ", userId, ": ", err.Error())
+		return
+	}
+	c.ClearCookie(common.CartTokenCookie)
+}
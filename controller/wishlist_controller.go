@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/middleware"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func NewWishlistController(wishlistService *service.WishlistService, config configuration.Config) *WishlistController {
+	return &WishlistController{WishlistService: *wishlistService, Config: config}
+}
+
+type WishlistController struct {
+	service.WishlistService
+	configuration.Config
+}
+
+func (controller WishlistController) Route(app *fiber.App) {
+	app.Get("/v1/api/wishlist", middleware.AuthenticateJWT("customer", controller.Config), controller.FindAll)
+	app.Post("/v1/api/wishlist", middleware.AuthenticateJWT("customer", controller.Config), controller.Add)
+	app.Delete("/v1/api/wishlist/:productId", middleware.AuthenticateJWT("customer", controller.Config), controller.Remove)
+}
+
+// FindAll godoc
+// @Summary Get user's wishlist
+// @Description Get a page of the products the current user is watching
+// @Tags Wishlist
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor from a previous page"
+// @Param sort_order query string false "asc or desc"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/wishlist [get]
+// @Security JWT
+func (controller WishlistController) FindAll(c *fiber.Ctx) error {
+	var listQuery model.ListQueryModel
+	err := c.QueryParser(&listQuery)
+	exception.PanicLogging(err)
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := uint(claims["user_id"].(float64))
+
+	items, pageInfo, err := controller.WishlistService.FindAll(c.Context(), userId, listQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"items":       items,
+			"total_count": pageInfo.TotalCount,
+			"page":        pageInfo.Page,
+			"limit":       pageInfo.Limit,
+			"next_cursor": pageInfo.NextCursor,
+		},
+	})
+}
+
+// Add godoc
+// @Summary Add product to wishlist
+// @Description Watch a product for price drops and restocks. Adding a product already in the wishlist updates its alert settings.
+// @Tags Wishlist
+// @Accept json
+// @Produce json
+// @Param request body model.WishlistItemCreateModel true "Wishlist item"
+// @Success 201 {object} model.GeneralResponse
+// @Router /v1/api/wishlist [post]
+// @Security JWT
+func (controller WishlistController) Add(c *fiber.Ctx) error {
+	var request model.WishlistItemCreateModel
+	err := c.BodyParser(&request)
+	exception.PanicLogging(err)
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := uint(claims["user_id"].(float64))
+
+	item, err := controller.WishlistService.Add(c.Context(), userId, request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.GeneralResponse{
+		Code:    201,
+		Message: "Product added to wishlist",
+		Data:    item,
+	})
+}
+
+// Remove godoc
+// @Summary Remove product from wishlist
+// @Description Stop watching a product
+// @Tags Wishlist
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product Id"
+// @Success 200 {object} model.GeneralResponse
+// @Router /v1/api/wishlist/{productId} [delete]
+// @Security JWT
+func (controller WishlistController) Remove(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := uint(claims["user_id"].(float64))
+
+	err := controller.WishlistService.Remove(c.Context(), userId, c.Params("productId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.GeneralResponse{
+			Code:    400,
+			Message: "Error",
+			Data:    err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.GeneralResponse{
+		Code:    200,
+		Message: "Product removed from wishlist",
+		Data:    nil,
+	})
+}
@@ -0,0 +1,5 @@
+-- Remove category and last update time from products
+ALTER TABLE tb_product
+    DROP INDEX idx_tb_product_category,
+    DROP COLUMN updated_at,
+    DROP COLUMN category;
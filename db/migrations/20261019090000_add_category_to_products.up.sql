@@ -0,0 +1,5 @@
+-- Add category and last update time to products for catalogue filtering and HTTP caching
+ALTER TABLE tb_product
+    ADD COLUMN category VARCHAR(100) NULL AFTER description,
+    ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP AFTER created_at,
+    ADD INDEX idx_tb_product_category (category);
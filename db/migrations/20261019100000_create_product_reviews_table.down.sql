@@ -0,0 +1,7 @@
+-- Drop product reviews table and aggregated rating columns
+ALTER TABLE tb_product
+    DROP INDEX idx_tb_product_rating_average,
+    DROP COLUMN rating_count,
+    DROP COLUMN rating_average;
+
+DROP TABLE IF EXISTS tb_product_review;
@@ -0,0 +1,27 @@
+-- Create product reviews table and aggregated rating columns on products
+CREATE TABLE tb_product_review
+(
+    id INT AUTO_INCREMENT,
+    product_id VARCHAR(36) NOT NULL,
+    user_id INT NOT NULL,
+    order_id INT NOT NULL,
+    rating INT NOT NULL,
+    title VARCHAR(150) NOT NULL,
+    body TEXT,
+    images TEXT,
+    status VARCHAR(50) DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT uk_tb_product_review_product_user UNIQUE (product_id, user_id),
+    INDEX idx_tb_product_review_status (status),
+    CONSTRAINT fk_tb_product_reviews FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_user_reviews FOREIGN KEY (user_id) REFERENCES tb_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_product_review_rating CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT chk_tb_product_review_status CHECK (status IN ('pending', 'approved', 'hidden'))
+);
+
+ALTER TABLE tb_product
+    ADD COLUMN rating_average DECIMAL(3,2) NOT NULL DEFAULT 0 AFTER quantity,
+    ADD COLUMN rating_count INT NOT NULL DEFAULT 0 AFTER rating_average,
+    ADD INDEX idx_tb_product_rating_average (rating_average);
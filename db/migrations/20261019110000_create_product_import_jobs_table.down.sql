@@ -0,0 +1,6 @@
+-- Drop product import jobs table and product SKU
+DROP TABLE IF EXISTS tb_product_import_job;
+
+ALTER TABLE tb_product
+    DROP INDEX uk_tb_product_sku,
+    DROP COLUMN sku;
@@ -0,0 +1,22 @@
+-- Add SKU to products and track bulk product import jobs
+ALTER TABLE tb_product
+    ADD COLUMN sku VARCHAR(64) NULL AFTER product_id,
+    ADD CONSTRAINT uk_tb_product_sku UNIQUE (sku);
+
+CREATE TABLE tb_product_import_job
+(
+    id VARCHAR(36) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(50) DEFAULT 'pending',
+    total_rows INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    created_count INT NOT NULL DEFAULT 0,
+    updated_count INT NOT NULL DEFAULT 0,
+    failed_count INT NOT NULL DEFAULT 0,
+    errors LONGTEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT chk_tb_product_import_job_format CHECK (format IN ('csv', 'json')),
+    CONSTRAINT chk_tb_product_import_job_status CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
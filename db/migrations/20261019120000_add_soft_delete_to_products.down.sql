@@ -0,0 +1,10 @@
+-- Restore cascading product deletes and drop product archiving
+ALTER TABLE tb_order_item
+    DROP FOREIGN KEY fk_tb_product_order_items;
+
+ALTER TABLE tb_order_item
+    ADD CONSTRAINT fk_tb_product_order_items FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE tb_product
+    DROP INDEX idx_tb_product_deleted_at,
+    DROP COLUMN deleted_at;
@@ -0,0 +1,10 @@
+-- Archive products instead of deleting them, and stop product deletes from removing order history
+ALTER TABLE tb_product
+    ADD COLUMN deleted_at TIMESTAMP NULL AFTER updated_at,
+    ADD INDEX idx_tb_product_deleted_at (deleted_at);
+
+ALTER TABLE tb_order_item
+    DROP FOREIGN KEY fk_tb_product_order_items;
+
+ALTER TABLE tb_order_item
+    ADD CONSTRAINT fk_tb_product_order_items FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE RESTRICT ON UPDATE CASCADE;
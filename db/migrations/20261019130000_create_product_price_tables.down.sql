@@ -0,0 +1,7 @@
+-- Drop product price history, schedules and sale columns
+DROP TABLE IF EXISTS tb_product_price_history;
+DROP TABLE IF EXISTS tb_product_price_schedule;
+
+ALTER TABLE tb_product
+    DROP COLUMN sale_ends_at,
+    DROP COLUMN compare_at_price;
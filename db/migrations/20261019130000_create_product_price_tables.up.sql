@@ -0,0 +1,37 @@
+-- Record product price changes, schedule future prices and time-boxed sales
+ALTER TABLE tb_product
+    ADD COLUMN compare_at_price DECIMAL(10,2) NULL AFTER price,
+    ADD COLUMN sale_ends_at TIMESTAMP NULL AFTER compare_at_price;
+
+CREATE TABLE tb_product_price_schedule
+(
+    id INT AUTO_INCREMENT,
+    product_id VARCHAR(36) NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    price DECIMAL(10,2) NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NULL,
+    status VARCHAR(50) DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_tb_product_price_schedule_due (status, starts_at),
+    CONSTRAINT fk_tb_product_price_schedules FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_product_price_schedule_type CHECK (type IN ('price', 'sale')),
+    CONSTRAINT chk_tb_product_price_schedule_status CHECK (status IN ('pending', 'active', 'completed', 'cancelled'))
+);
+
+CREATE TABLE tb_product_price_history
+(
+    id INT AUTO_INCREMENT,
+    product_id VARCHAR(36) NOT NULL,
+    old_price DECIMAL(10,2) NOT NULL,
+    new_price DECIMAL(10,2) NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    schedule_id INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_tb_product_price_history_product (product_id, created_at),
+    CONSTRAINT fk_tb_product_price_histories FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_product_price_schedule_histories FOREIGN KEY (schedule_id) REFERENCES tb_product_price_schedule (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_product_price_history_reason CHECK (reason IN ('manual', 'import', 'scheduled', 'sale_started', 'sale_ended'))
+);
@@ -0,0 +1,4 @@
+-- Drop product attribute tables
+DROP TABLE IF EXISTS tb_product_attribute_value;
+DROP TABLE IF EXISTS tb_category_attribute;
+DROP TABLE IF EXISTS tb_attribute_definition;
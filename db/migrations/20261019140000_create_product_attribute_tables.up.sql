@@ -0,0 +1,36 @@
+-- Typed attribute definitions, their attachment to categories and per-product values
+CREATE TABLE tb_attribute_definition
+(
+    id INT AUTO_INCREMENT,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    unit VARCHAR(20),
+    options TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT uk_tb_attribute_definition_code UNIQUE (code),
+    CONSTRAINT chk_tb_attribute_definition_type CHECK (type IN ('text', 'number', 'enum', 'boolean'))
+);
+
+CREATE TABLE tb_category_attribute
+(
+    category VARCHAR(100) NOT NULL,
+    attribute_id INT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (category, attribute_id),
+    CONSTRAINT fk_tb_attribute_definition_categories FOREIGN KEY (attribute_id) REFERENCES tb_attribute_definition (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE tb_product_attribute_value
+(
+    product_id VARCHAR(36) NOT NULL,
+    attribute_id INT NOT NULL,
+    value_text VARCHAR(255) NOT NULL,
+    value_number DECIMAL(18,4) NULL,
+    PRIMARY KEY (product_id, attribute_id),
+    INDEX idx_tb_product_attribute_value_text (attribute_id, value_text),
+    INDEX idx_tb_product_attribute_value_number (attribute_id, value_number),
+    CONSTRAINT fk_tb_product_attribute_values FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_attribute_definition_values FOREIGN KEY (attribute_id) REFERENCES tb_attribute_definition (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
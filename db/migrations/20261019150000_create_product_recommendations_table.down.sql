@@ -0,0 +1,2 @@
+-- Drop product recommendations table
+DROP TABLE IF EXISTS tb_product_recommendation;
@@ -0,0 +1,15 @@
+-- Precomputed product recommendations, rebuilt periodically from order history and categories
+CREATE TABLE tb_product_recommendation
+(
+    product_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    recommended_product_id VARCHAR(36) NOT NULL,
+    score DECIMAL(10,4) NOT NULL,
+    `rank` INT NOT NULL,
+    computed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, kind, recommended_product_id),
+    INDEX idx_tb_product_recommendation_rank (product_id, kind, `rank`),
+    CONSTRAINT fk_tb_product_recommendations FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_product_recommended FOREIGN KEY (recommended_product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_product_recommendation_kind CHECK (kind IN ('bought_together', 'related'))
+);
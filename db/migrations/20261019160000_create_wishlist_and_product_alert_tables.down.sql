@@ -0,0 +1,3 @@
+-- Drop product alert and wishlist tables
+DROP TABLE IF EXISTS tb_product_alert;
+DROP TABLE IF EXISTS tb_wishlist_item;
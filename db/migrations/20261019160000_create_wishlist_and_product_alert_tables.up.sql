@@ -0,0 +1,36 @@
+-- Create customer wishlists and the queue of price-drop and back-in-stock alerts sent to them
+CREATE TABLE tb_wishlist_item
+(
+    id INT AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    product_id VARCHAR(36) NOT NULL,
+    notify_price_drop BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_back_in_stock BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT uk_tb_wishlist_item_user_product UNIQUE (user_id, product_id),
+    INDEX idx_tb_wishlist_item_product_id (product_id),
+    CONSTRAINT fk_tb_user_wishlist_items FOREIGN KEY (user_id) REFERENCES tb_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_product_wishlist_items FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE tb_product_alert
+(
+    id INT AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    product_id VARCHAR(36) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    old_price DECIMAL(10,2),
+    new_price DECIMAL(10,2),
+    status VARCHAR(50) DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP NULL,
+    PRIMARY KEY (id),
+    INDEX idx_tb_product_alert_status (status, id),
+    CONSTRAINT fk_tb_user_product_alerts FOREIGN KEY (user_id) REFERENCES tb_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_product_alerts FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_product_alert_type CHECK (type IN ('price_drop', 'back_in_stock')),
+    CONSTRAINT chk_tb_product_alert_status CHECK (status IN ('pending', 'sent', 'failed'))
+);
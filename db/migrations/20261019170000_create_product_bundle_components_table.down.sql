@@ -0,0 +1,6 @@
+-- Drop bundle components and product types
+DROP TABLE IF EXISTS tb_product_bundle_component;
+
+ALTER TABLE tb_product
+    DROP CHECK chk_tb_product_type,
+    DROP COLUMN type;
@@ -0,0 +1,16 @@
+-- Add product types and the components bundles are made of
+ALTER TABLE tb_product
+    ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'simple' AFTER sku,
+    ADD CONSTRAINT chk_tb_product_type CHECK (type IN ('simple', 'bundle'));
+
+CREATE TABLE tb_product_bundle_component
+(
+    bundle_product_id VARCHAR(36) NOT NULL,
+    component_product_id VARCHAR(36) NOT NULL,
+    quantity INT NOT NULL,
+    PRIMARY KEY (bundle_product_id, component_product_id),
+    INDEX idx_tb_product_bundle_component_component (component_product_id),
+    CONSTRAINT fk_tb_product_bundle_components FOREIGN KEY (bundle_product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_product_bundled_in FOREIGN KEY (component_product_id) REFERENCES tb_product (product_id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_product_bundle_component_quantity CHECK (quantity > 0)
+);
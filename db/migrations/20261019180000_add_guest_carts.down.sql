@@ -0,0 +1,7 @@
+-- Drop guest carts and make carts belong to users again
+DELETE FROM tb_cart WHERE user_id IS NULL;
+
+ALTER TABLE tb_cart
+    DROP INDEX uk_tb_cart_guest_id,
+    DROP COLUMN guest_id,
+    MODIFY user_id INT NOT NULL;
@@ -0,0 +1,5 @@
+-- Let carts belong to a guest, identified by the id inside a signed cart token, instead of a user
+ALTER TABLE tb_cart
+    MODIFY user_id INT NULL,
+    ADD COLUMN guest_id VARCHAR(36) NULL AFTER user_id,
+    ADD CONSTRAINT uk_tb_cart_guest_id UNIQUE (guest_id);
@@ -0,0 +1,10 @@
+-- Drop promotions and order discounts
+ALTER TABLE tb_order
+    DROP COLUMN free_shipping,
+    DROP COLUMN discount_total,
+    DROP COLUMN subtotal;
+
+DROP TABLE IF EXISTS tb_order_discount;
+DROP TABLE IF EXISTS tb_promotion_redemption;
+DROP TABLE IF EXISTS tb_cart_coupon;
+DROP TABLE IF EXISTS tb_promotion;
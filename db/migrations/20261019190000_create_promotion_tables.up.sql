@@ -0,0 +1,76 @@
+-- Create coupon codes and automatic promotions, the coupons applied to carts, and the discounts snapshotted on orders
+CREATE TABLE tb_promotion
+(
+    id INT AUTO_INCREMENT,
+    code VARCHAR(50) NULL,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    value DECIMAL(10,2) NOT NULL DEFAULT 0,
+    min_spend DECIMAL(10,2) NOT NULL DEFAULT 0,
+    scope VARCHAR(20) NOT NULL DEFAULT 'order',
+    scope_product_id VARCHAR(36) NULL,
+    scope_category VARCHAR(100) NULL,
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    usage_limit INT NULL,
+    usage_limit_per_user INT NULL,
+    usage_count INT NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP NULL,
+    ends_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT uk_tb_promotion_code UNIQUE (code),
+    INDEX idx_tb_promotion_active (active, code),
+    CONSTRAINT fk_tb_product_promotions FOREIGN KEY (scope_product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_promotion_type CHECK (type IN ('percentage', 'fixed', 'free_shipping', 'buy_x_get_y')),
+    CONSTRAINT chk_tb_promotion_scope CHECK (scope IN ('order', 'product', 'category'))
+);
+
+CREATE TABLE tb_cart_coupon
+(
+    cart_id INT NOT NULL,
+    promotion_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cart_id, promotion_id),
+    CONSTRAINT fk_tb_cart_coupons FOREIGN KEY (cart_id) REFERENCES tb_cart (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_promotion_cart_coupons FOREIGN KEY (promotion_id) REFERENCES tb_promotion (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE tb_promotion_redemption
+(
+    id INT AUTO_INCREMENT,
+    promotion_id INT NOT NULL,
+    user_id INT NOT NULL,
+    order_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_tb_promotion_redemption_user (promotion_id, user_id),
+    CONSTRAINT fk_tb_promotion_redemptions FOREIGN KEY (promotion_id) REFERENCES tb_promotion (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_user_promotion_redemptions FOREIGN KEY (user_id) REFERENCES tb_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_order_promotion_redemptions FOREIGN KEY (order_id) REFERENCES tb_order (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE tb_order_discount
+(
+    id INT AUTO_INCREMENT,
+    order_id INT NOT NULL,
+    promotion_id INT NULL,
+    code VARCHAR(50) NULL,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tb_order_discounts FOREIGN KEY (order_id) REFERENCES tb_order (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_promotion_order_discounts FOREIGN KEY (promotion_id) REFERENCES tb_promotion (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+ALTER TABLE tb_order
+    ADD COLUMN subtotal DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER user_id,
+    ADD COLUMN discount_total DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER subtotal,
+    ADD COLUMN free_shipping BOOLEAN NOT NULL DEFAULT FALSE AFTER discount_total;
+
+UPDATE tb_order SET subtotal = total;
@@ -0,0 +1,18 @@
+-- Drop tax classes and order tax amounts
+DROP TABLE IF EXISTS tb_order_tax;
+
+ALTER TABLE tb_order_item
+    DROP COLUMN tax_amount,
+    DROP COLUMN discount_amount,
+    DROP COLUMN tax_rate,
+    DROP COLUMN tax_class;
+
+ALTER TABLE tb_order
+    DROP COLUMN prices_include_tax,
+    DROP COLUMN tax_total;
+
+ALTER TABLE tb_product
+    DROP FOREIGN KEY fk_tb_tax_class_products,
+    DROP COLUMN tax_class;
+
+DROP TABLE IF EXISTS tb_tax_class;
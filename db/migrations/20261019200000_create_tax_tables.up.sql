@@ -0,0 +1,45 @@
+-- Create tax classes with Kenyan VAT rates, the tax class of products, and the tax amounts persisted on orders
+CREATE TABLE tb_tax_class
+(
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    exempt BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (code),
+    CONSTRAINT chk_tb_tax_class_rate CHECK (rate >= 0 AND rate <= 100)
+);
+
+INSERT INTO tb_tax_class (code, name, rate, exempt) VALUES
+    ('standard', 'Standard VAT', 16.00, FALSE),
+    ('zero_rated', 'Zero-rated', 0.00, FALSE),
+    ('exempt', 'Exempt', 0.00, TRUE);
+
+ALTER TABLE tb_product
+    ADD COLUMN tax_class VARCHAR(20) NOT NULL DEFAULT 'standard' AFTER category,
+    ADD CONSTRAINT fk_tb_tax_class_products FOREIGN KEY (tax_class) REFERENCES tb_tax_class (code) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+ALTER TABLE tb_order
+    ADD COLUMN tax_total DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER free_shipping,
+    ADD COLUMN prices_include_tax BOOLEAN NOT NULL DEFAULT TRUE AFTER tax_total;
+
+ALTER TABLE tb_order_item
+    ADD COLUMN tax_class VARCHAR(20) NULL AFTER price,
+    ADD COLUMN tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0 AFTER tax_class,
+    ADD COLUMN discount_amount DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER tax_rate,
+    ADD COLUMN tax_amount DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER discount_amount;
+
+CREATE TABLE tb_order_tax
+(
+    id INT AUTO_INCREMENT,
+    order_id INT NOT NULL,
+    tax_class VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    rate DECIMAL(5,2) NOT NULL,
+    exempt BOOLEAN NOT NULL DEFAULT FALSE,
+    net_amount DECIMAL(10,2) NOT NULL,
+    tax_amount DECIMAL(10,2) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tb_order_taxes FOREIGN KEY (order_id) REFERENCES tb_order (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
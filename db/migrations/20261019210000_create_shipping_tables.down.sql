@@ -0,0 +1,18 @@
+-- Drop shipping zones, methods and rates, and the shipping of orders
+ALTER TABLE tb_order
+    DROP FOREIGN KEY fk_tb_shipping_method_orders,
+    DROP COLUMN shipping_town,
+    DROP COLUMN shipping_county,
+    DROP COLUMN shipping_address,
+    DROP COLUMN shipping_cost,
+    DROP COLUMN shipping_type,
+    DROP COLUMN shipping_method,
+    DROP COLUMN shipping_method_id;
+
+ALTER TABLE tb_product
+    DROP COLUMN weight;
+
+DROP TABLE IF EXISTS tb_shipping_rate;
+DROP TABLE IF EXISTS tb_shipping_method;
+DROP TABLE IF EXISTS tb_shipping_zone_location;
+DROP TABLE IF EXISTS tb_shipping_zone;
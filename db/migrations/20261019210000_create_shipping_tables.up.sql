@@ -0,0 +1,68 @@
+-- Create shipping zones by county and town, the shipping methods of each zone with their rate rules,
+-- the weight of products, and the shipping chosen for orders
+CREATE TABLE tb_shipping_zone
+(
+    id INT AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_tb_shipping_zone_name (name)
+);
+
+-- An empty town covers the rest of the county
+CREATE TABLE tb_shipping_zone_location
+(
+    id INT AUTO_INCREMENT,
+    zone_id INT NOT NULL,
+    county VARCHAR(100) NOT NULL,
+    town VARCHAR(100) NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_tb_shipping_zone_location (county, town),
+    CONSTRAINT fk_tb_shipping_zone_locations FOREIGN KEY (zone_id) REFERENCES tb_shipping_zone (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE tb_shipping_method
+(
+    id INT AUTO_INCREMENT,
+    zone_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    rate_type VARCHAR(20) NOT NULL,
+    flat_fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+    min_days INT NOT NULL DEFAULT 0,
+    max_days INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tb_shipping_zone_methods FOREIGN KEY (zone_id) REFERENCES tb_shipping_zone (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_shipping_method_type CHECK (type IN ('standard', 'express', 'pickup_station')),
+    CONSTRAINT chk_tb_shipping_method_rate_type CHECK (rate_type IN ('flat', 'weight', 'order_value'))
+);
+
+-- Rate brackets of weight and order_value methods, from min_value up to but excluding max_value
+CREATE TABLE tb_shipping_rate
+(
+    id INT AUTO_INCREMENT,
+    method_id INT NOT NULL,
+    min_value DECIMAL(10,3) NOT NULL DEFAULT 0,
+    max_value DECIMAL(10,3) NULL,
+    fee DECIMAL(10,2) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tb_shipping_method_rates FOREIGN KEY (method_id) REFERENCES tb_shipping_method (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_shipping_rate_fee CHECK (fee >= 0)
+);
+
+ALTER TABLE tb_product
+    ADD COLUMN weight DECIMAL(8,3) NOT NULL DEFAULT 0 AFTER price;
+
+ALTER TABLE tb_order
+    ADD COLUMN shipping_method_id INT NULL AFTER tax_total,
+    ADD COLUMN shipping_method VARCHAR(100) NULL AFTER shipping_method_id,
+    ADD COLUMN shipping_type VARCHAR(20) NULL AFTER shipping_method,
+    ADD COLUMN shipping_cost DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER shipping_type,
+    ADD COLUMN shipping_address TEXT NULL AFTER shipping_cost,
+    ADD COLUMN shipping_county VARCHAR(100) NULL AFTER shipping_address,
+    ADD COLUMN shipping_town VARCHAR(100) NULL AFTER shipping_county,
+    ADD CONSTRAINT fk_tb_shipping_method_orders FOREIGN KEY (shipping_method_id) REFERENCES tb_shipping_method (id) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,7 @@
+-- Drop the display currencies and the currency of orders
+ALTER TABLE tb_order
+    DROP COLUMN currency_decimals,
+    DROP COLUMN exchange_rate,
+    DROP COLUMN currency;
+
+DROP TABLE IF EXISTS tb_currency;
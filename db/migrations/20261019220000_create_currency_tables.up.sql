@@ -0,0 +1,24 @@
+-- Create the exchange rates of the display currencies, and lock the currency and rate of each order
+CREATE TABLE tb_currency
+(
+    code VARCHAR(3) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    rate DECIMAL(18,6) NOT NULL,
+    decimals TINYINT NOT NULL DEFAULT 2,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (code),
+    CONSTRAINT chk_tb_currency_rate CHECK (rate > 0),
+    CONSTRAINT chk_tb_currency_decimals CHECK (decimals >= 0 AND decimals <= 2)
+);
+
+INSERT INTO tb_currency (code, name, rate, decimals, active) VALUES
+    ('KES', 'Kenyan Shilling', 1.000000, 2, TRUE),
+    ('UGX', 'Ugandan Shilling', 28.500000, 0, TRUE),
+    ('TZS', 'Tanzanian Shilling', 19.800000, 0, TRUE);
+
+ALTER TABLE tb_order
+    ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'KES' AFTER total,
+    ADD COLUMN exchange_rate DECIMAL(18,6) NOT NULL DEFAULT 1 AFTER currency,
+    ADD COLUMN currency_decimals TINYINT NOT NULL DEFAULT 2 AFTER exchange_rate;
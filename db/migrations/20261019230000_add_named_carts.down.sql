@@ -0,0 +1,12 @@
+-- Drop named carts and save-for-later lists, keeping the default cart of each customer
+DELETE FROM tb_cart WHERE type = 'saved';
+DELETE cart FROM tb_cart cart JOIN tb_cart first_cart ON first_cart.user_id = cart.user_id AND first_cart.id < cart.id;
+
+ALTER TABLE tb_cart
+    ADD CONSTRAINT uk_tb_cart_user_id UNIQUE (user_id);
+
+ALTER TABLE tb_cart
+    DROP INDEX uk_tb_cart_user_type_name,
+    DROP CHECK chk_tb_cart_type,
+    DROP COLUMN type,
+    DROP COLUMN name;
@@ -0,0 +1,9 @@
+-- Let customers keep several named carts and a save-for-later list; their first cart is the default one
+ALTER TABLE tb_cart
+    ADD COLUMN name VARCHAR(100) NOT NULL DEFAULT 'Cart' AFTER guest_id,
+    ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'cart' AFTER name,
+    ADD CONSTRAINT uk_tb_cart_user_type_name UNIQUE (user_id, type, name),
+    ADD CONSTRAINT chk_tb_cart_type CHECK (type IN ('cart', 'saved'));
+
+ALTER TABLE tb_cart
+    DROP INDEX uk_tb_cart_user_id;
@@ -0,0 +1,6 @@
+-- Drop abandoned cart reminders and cart activity
+DROP TABLE IF EXISTS tb_cart_reminder;
+
+ALTER TABLE tb_cart
+    DROP INDEX idx_tb_cart_last_activity_at,
+    DROP COLUMN last_activity_at;
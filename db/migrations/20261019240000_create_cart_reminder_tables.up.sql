@@ -0,0 +1,35 @@
+-- Track when carts were last changed, and the reminders sent for abandoned carts with the orders they recovered
+ALTER TABLE tb_cart
+    ADD COLUMN last_activity_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP AFTER type,
+    ADD INDEX idx_tb_cart_last_activity_at (last_activity_at);
+
+UPDATE tb_cart cart
+SET last_activity_at = (SELECT COALESCE(MAX(item.created_at), cart.created_at) FROM tb_cart_item item WHERE item.cart_id = cart.id);
+
+CREATE TABLE tb_cart_reminder
+(
+    id INT AUTO_INCREMENT,
+    cart_id INT NULL,
+    user_id INT NOT NULL,
+    stage INT NOT NULL,
+    cart_activity_at TIMESTAMP NOT NULL,
+    cart_value DECIMAL(10,2) NOT NULL,
+    promotion_id INT NULL,
+    coupon_code VARCHAR(50) NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP NULL,
+    order_id INT NULL,
+    recovered_at TIMESTAMP NULL,
+    PRIMARY KEY (id),
+    INDEX idx_tb_cart_reminder_status (status),
+    INDEX idx_tb_cart_reminder_sent_at (sent_at),
+    CONSTRAINT uk_tb_cart_reminder_cart_stage UNIQUE (cart_id, stage, cart_activity_at),
+    CONSTRAINT fk_tb_cart_reminders FOREIGN KEY (cart_id) REFERENCES tb_cart (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_user_cart_reminders FOREIGN KEY (user_id) REFERENCES tb_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_promotion_cart_reminders FOREIGN KEY (promotion_id) REFERENCES tb_promotion (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_order_cart_reminders FOREIGN KEY (order_id) REFERENCES tb_order (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_cart_reminder_status CHECK (status IN ('pending', 'sent', 'failed', 'cancelled'))
+);
@@ -0,0 +1,2 @@
+ALTER TABLE tb_order
+    DROP INDEX idx_tb_order_status_created_at;
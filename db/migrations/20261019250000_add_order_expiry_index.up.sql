@@ -0,0 +1,3 @@
+-- Find pending orders by age, for cancelling the ones not paid in time
+ALTER TABLE tb_order
+    ADD INDEX idx_tb_order_status_created_at (status, created_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS tb_order_document;
+DROP TABLE IF EXISTS tb_document_sequence;
+
+ALTER TABLE tb_payment
+    DROP COLUMN receipt_number;
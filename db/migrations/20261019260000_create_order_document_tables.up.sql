@@ -0,0 +1,30 @@
+-- Keep the M-Pesa receipt number of payments, and the invoices and receipts issued for orders
+ALTER TABLE tb_payment
+    ADD COLUMN receipt_number VARCHAR(20) NULL AFTER transaction_id;
+
+-- The last number issued of each document type; numbers are taken in the transaction that stores the document, so they have no gaps
+CREATE TABLE tb_document_sequence
+(
+    type VARCHAR(20) NOT NULL,
+    last_number INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (type)
+);
+
+INSERT INTO tb_document_sequence (type, last_number) VALUES ('invoice', 0), ('receipt', 0);
+
+CREATE TABLE tb_order_document
+(
+    id INT AUTO_INCREMENT,
+    number VARCHAR(20) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    order_id INT NOT NULL,
+    payment_id INT NULL,
+    content LONGBLOB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT uk_tb_order_document_number UNIQUE (number),
+    CONSTRAINT uk_tb_order_document_order_type UNIQUE (order_id, type),
+    CONSTRAINT fk_tb_order_documents FOREIGN KEY (order_id) REFERENCES tb_order (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_payment_order_documents FOREIGN KEY (payment_id) REFERENCES tb_payment (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_order_document_type CHECK (type IN ('invoice', 'receipt'))
+);
@@ -0,0 +1,3 @@
+ALTER TABLE tb_order
+    DROP INDEX uk_tb_order_number,
+    DROP COLUMN number;
@@ -0,0 +1,23 @@
+-- Human-readable order numbers: prefix, order date as YYMMDD, six random digits and a Luhn check
+-- digit over the date and random digits, e.g. TH-261019-4827315
+ALTER TABLE tb_order
+    ADD COLUMN number VARCHAR(20) NULL AFTER id;
+
+-- Existing orders are numbered from their id instead of random digits, under the default prefix
+UPDATE tb_order o
+    JOIN (SELECT id, CONCAT(DATE_FORMAT(created_at, '%y%m%d'), LPAD(id MOD 1000000, 6, '0')) AS digits
+          FROM tb_order) d ON d.id = o.id
+SET o.number = CONCAT('TH-', LEFT(d.digits, 6), '-', RIGHT(d.digits, 6), (10 - (
+        SUBSTRING(d.digits, 1, 1) + SUBSTRING(d.digits, 3, 1) + SUBSTRING(d.digits, 5, 1)
+      + SUBSTRING(d.digits, 7, 1) + SUBSTRING(d.digits, 9, 1) + SUBSTRING(d.digits, 11, 1)
+      + SUBSTRING(d.digits, 2, 1) * 2 - 9 * (SUBSTRING(d.digits, 2, 1) > 4)
+      + SUBSTRING(d.digits, 4, 1) * 2 - 9 * (SUBSTRING(d.digits, 4, 1) > 4)
+      + SUBSTRING(d.digits, 6, 1) * 2 - 9 * (SUBSTRING(d.digits, 6, 1) > 4)
+      + SUBSTRING(d.digits, 8, 1) * 2 - 9 * (SUBSTRING(d.digits, 8, 1) > 4)
+      + SUBSTRING(d.digits, 10, 1) * 2 - 9 * (SUBSTRING(d.digits, 10, 1) > 4)
+      + SUBSTRING(d.digits, 12, 1) * 2 - 9 * (SUBSTRING(d.digits, 12, 1) > 4)
+    ) MOD 10) MOD 10);
+
+ALTER TABLE tb_order
+    MODIFY COLUMN number VARCHAR(20) NOT NULL,
+    ADD CONSTRAINT uk_tb_order_number UNIQUE (number);
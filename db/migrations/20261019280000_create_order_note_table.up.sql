@@ -0,0 +1,13 @@
+-- Internal notes admins leave on orders
+CREATE TABLE tb_order_note
+(
+    id INT AUTO_INCREMENT,
+    order_id INT NOT NULL,
+    author_id INT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_tb_order_note_order_id (order_id),
+    CONSTRAINT fk_tb_order_notes FOREIGN KEY (order_id) REFERENCES tb_order (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_user_order_notes FOREIGN KEY (author_id) REFERENCES tb_user (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
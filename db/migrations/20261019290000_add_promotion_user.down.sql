@@ -0,0 +1,3 @@
+ALTER TABLE tb_promotion
+    DROP FOREIGN KEY fk_tb_user_promotions,
+    DROP COLUMN user_id;
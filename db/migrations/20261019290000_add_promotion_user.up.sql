@@ -0,0 +1,11 @@
+-- Coupons issued to one customer, such as the cart recovery coupons, can only be redeemed by them
+ALTER TABLE tb_promotion
+    ADD COLUMN user_id INT NULL AFTER scope_category,
+    ADD CONSTRAINT fk_tb_user_promotions FOREIGN KEY (user_id) REFERENCES tb_user (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- Recovery coupons already issued belong to the customer they were sent to; the ones whose reminder
+-- was never sent are switched off
+UPDATE tb_promotion p
+    JOIN tb_cart_reminder r ON r.promotion_id = p.id
+SET p.user_id = r.user_id,
+    p.active  = p.active AND r.status IN ('pending', 'sent');
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tb_order_stock;
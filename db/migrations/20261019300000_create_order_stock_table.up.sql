@@ -0,0 +1,25 @@
+-- The stock each order took from each product at checkout, so a cancelled order puts back what it
+-- took even after its bundles were edited
+CREATE TABLE tb_order_stock
+(
+    order_id INT NOT NULL,
+    product_id VARCHAR(36) NOT NULL,
+    quantity INT NOT NULL,
+    PRIMARY KEY (order_id, product_id),
+    CONSTRAINT fk_tb_order_stock FOREIGN KEY (order_id) REFERENCES tb_order (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tb_product_order_stock FOREIGN KEY (product_id) REFERENCES tb_product (product_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_tb_order_stock_quantity CHECK (quantity > 0)
+);
+
+-- Orders placed before are recorded from their bundles as they are now, the best record there is
+INSERT INTO tb_order_stock (order_id, product_id, quantity)
+SELECT taken.order_id, taken.product_id, SUM(taken.quantity)
+FROM (SELECT i.order_id, i.product_id, i.quantity
+      FROM tb_order_item i
+          JOIN tb_product p ON p.product_id = i.product_id
+      WHERE p.type <> 'bundle'
+      UNION ALL
+      SELECT i.order_id, c.component_product_id, i.quantity * c.quantity
+      FROM tb_order_item i
+          JOIN tb_product_bundle_component c ON c.bundle_product_id = i.product_id) taken
+GROUP BY taken.order_id, taken.product_id;
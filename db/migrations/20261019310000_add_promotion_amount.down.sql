@@ -0,0 +1,4 @@
+UPDATE tb_promotion SET value = amount WHERE type = 'fixed';
+
+ALTER TABLE tb_promotion
+    DROP COLUMN amount;
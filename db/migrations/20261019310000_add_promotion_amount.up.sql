@@ -0,0 +1,6 @@
+-- Fixed promotions keep the amount they take off to the cent, apart from the percent of the others
+ALTER TABLE tb_promotion
+    ADD COLUMN amount DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER value;
+
+UPDATE tb_promotion SET amount = value WHERE type = 'fixed';
+UPDATE tb_promotion SET value = 0 WHERE type = 'fixed';
@@ -0,0 +1,10 @@
+UPDATE tb_shipping_rate r
+    JOIN tb_shipping_method m ON m.id = r.method_id
+SET r.min_weight = r.min_order_value, r.max_weight = r.max_order_value
+WHERE m.rate_type = 'order_value';
+
+ALTER TABLE tb_shipping_rate
+    DROP COLUMN max_order_value,
+    DROP COLUMN min_order_value,
+    RENAME COLUMN max_weight TO max_value,
+    RENAME COLUMN min_weight TO min_value;
@@ -0,0 +1,16 @@
+-- Order value brackets are bounded by amounts to the cent; weight brackets stay in kg
+ALTER TABLE tb_shipping_rate
+    RENAME COLUMN min_value TO min_weight,
+    RENAME COLUMN max_value TO max_weight,
+    ADD COLUMN min_order_value DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER max_weight,
+    ADD COLUMN max_order_value DECIMAL(10,2) NULL AFTER min_order_value;
+
+UPDATE tb_shipping_rate r
+    JOIN tb_shipping_method m ON m.id = r.method_id
+SET r.min_order_value = r.min_weight, r.max_order_value = r.max_weight
+WHERE m.rate_type = 'order_value';
+
+UPDATE tb_shipping_rate r
+    JOIN tb_shipping_method m ON m.id = r.method_id
+SET r.min_weight = 0, r.max_weight = NULL
+WHERE m.rate_type = 'order_value';
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type AttributeDefinition struct {
+	Id        uint      `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	Code      string    `gorm:"column:code;type:varchar(50);unique;not null"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null"`
+	Type      string    `gorm:"column:type;type:varchar(10);not null;check:type IN ('text', 'number', 'enum', 'boolean')"`
+	Unit      string    `gorm:"column:unit;type:varchar(20)"`
+	Options   string    `gorm:"column:options;type:text"` // JSON array of allowed enum values
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (AttributeDefinition) TableName() string {
+	return "tb_attribute_definition"
+}
@@ -3,10 +3,14 @@ package entity
 import "time"
 
 type Cart struct {
- 	Id        uint       `gorm:"primaryKey;column:id;type:int;autoIncrement"`
- 	UserId    uint       `gorm:"column:user_id;type:int;not null"`
- 	CreatedAt time.Time  `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
- 	CartItems []CartItem `gorm:"ForeignKey:CartId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+ 	Id             uint       `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+ 	UserId         *uint      `gorm:"column:user_id;type:int"`                 // nil for guest carts
+ 	GuestId        *string    `gorm:"column:guest_id;type:varchar(36);unique"` // from the cart token of a guest cart
+ 	Name           string     `gorm:"column:name;type:varchar(100);not null;default:Cart"`
+ 	Type           string     `gorm:"column:type;type:varchar(20);not null;default:cart;check:type IN ('cart', 'saved')"`                           // saved for the save-for-later list
+ 	LastActivityAt time.Time  `gorm:"index:idx_tb_cart_last_activity_at;column:last_activity_at;type:timestamp;not null;default:CURRENT_TIMESTAMP"` // when the items or coupons last changed
+ 	CreatedAt      time.Time  `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+ 	CartItems      []CartItem `gorm:"ForeignKey:CartId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (Cart) TableName() string {
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type CartCoupon struct {
+	CartId      uint      `gorm:"primaryKey;column:cart_id;type:int"`
+	PromotionId uint      `gorm:"primaryKey;column:promotion_id;type:int"`
+	Promotion   Promotion `gorm:"ForeignKey:PromotionId;References:Id"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (CartCoupon) TableName() string {
+	return "tb_cart_coupon"
+}
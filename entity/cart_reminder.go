@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+// CartReminder is a reminder sent to a customer whose cart sat untouched, and the order that recovered
+// the cart, if any.
+type CartReminder struct {
+	Id             uint        `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	CartId         *uint       `gorm:"column:cart_id;type:int"` // nil once the cart is deleted
+	Cart           *Cart       `gorm:"ForeignKey:CartId;References:Id"`
+	UserId         uint        `gorm:"column:user_id;type:int;not null"`
+	User           User        `gorm:"ForeignKey:UserId;References:Id"`
+	Stage          int         `gorm:"column:stage;type:int;not null"`                  // 1 for the first reminder of an idle cart
+	CartActivityAt time.Time   `gorm:"column:cart_activity_at;type:timestamp;not null"` // the last activity of the cart it reminds of
+	CartValue      money.Money `gorm:"column:cart_value;type:decimal(10,2);not null"`   // the cart's items at their cart prices
+	PromotionId    *uint       `gorm:"column:promotion_id;type:int"`                    // the one-time coupon, if one was sent
+	Promotion      *Promotion  `gorm:"ForeignKey:PromotionId;References:Id"`
+	CouponCode     *string     `gorm:"column:coupon_code;type:varchar(50)"`
+	Status         string      `gorm:"index:idx_tb_cart_reminder_status;column:status;type:varchar(50);default:pending;check:status IN ('pending', 'sent', 'failed', 'cancelled')"`
+	Attempts       int32       `gorm:"column:attempts;type:int;default:0;not null"`
+	LastError      string      `gorm:"column:last_error;type:text"`
+	CreatedAt      time.Time   `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	SentAt         *time.Time  `gorm:"index:idx_tb_cart_reminder_sent_at;column:sent_at;type:timestamp"`
+	OrderId        *uint       `gorm:"column:order_id;type:int"` // the order that recovered the cart
+	RecoveredAt    *time.Time  `gorm:"column:recovered_at;type:timestamp"`
+}
+
+func (CartReminder) TableName() string {
+	return "tb_cart_reminder"
+}
@@ -0,0 +1,12 @@
+package entity
+
+type CategoryAttribute struct {
+	Category    string              `gorm:"primaryKey;column:category;type:varchar(100)"`
+	AttributeId uint                `gorm:"primaryKey;column:attribute_id;type:int"`
+	Attribute   AttributeDefinition `gorm:"ForeignKey:AttributeId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Required    bool                `gorm:"column:required;default:false;not null"`
+}
+
+func (CategoryAttribute) TableName() string {
+	return "tb_category_attribute"
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type Currency struct {
+	Code      string    `gorm:"primaryKey;column:code;type:varchar(3)"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null"`
+	Rate      float64   `gorm:"column:rate;type:decimal(18,6);not null"` // units of the currency per KES
+	Decimals  int       `gorm:"column:decimals;type:tinyint;not null"`   // 0 for currencies quoted in whole units
+	Active    bool      `gorm:"column:active;type:boolean;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (Currency) TableName() string {
+	return "tb_currency"
+}
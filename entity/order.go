@@ -1,17 +1,37 @@
 package entity
 
 import (
+	"github.com/tech-hive/ecommerce/money"
 	"time"
 )
 
 type Order struct {
-   	Id        uint        `gorm:"primaryKey;column:id;type:int;autoIncrement"`
-   	UserId    uint        `gorm:"column:user_id;type:int;not null"`
-   	Total     float64     `gorm:"column:total;type:decimal(10,2);not null;check:total >= 0"`
-   	Status    string      `gorm:"column:status;type:varchar(50);default:pending;check:status IN ('pending', 'confirmed', 'processing', 'shipped', 'delivered', 'cancelled')"`
-   	CreatedAt time.Time   `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
-   	OrderItems []OrderItem `gorm:"ForeignKey:OrderId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-   	Payments   []Payment   `gorm:"ForeignKey:OrderId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+   	Id               uint            `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+   	Number           string          `gorm:"column:number;type:varchar(20);unique;not null"` // e.g. TH-261019-4827315, see common.NewOrderNumber
+   	UserId           uint            `gorm:"column:user_id;type:int;not null"`
+   	User             *User           `gorm:"ForeignKey:UserId;References:Id"`
+   	Subtotal         money.Money     `gorm:"column:subtotal;type:decimal(10,2);not null"`
+   	DiscountTotal    money.Money     `gorm:"column:discount_total;type:decimal(10,2);not null"`
+   	FreeShipping     bool            `gorm:"column:free_shipping;type:boolean;not null"`
+   	TaxTotal         money.Money     `gorm:"column:tax_total;type:decimal(10,2);not null"`
+   	ShippingMethodId *uint           `gorm:"column:shipping_method_id;type:int"` // nil once the method is deleted
+   	ShippingMethod   *string         `gorm:"column:shipping_method;type:varchar(100)"`
+   	ShippingType     *string         `gorm:"column:shipping_type;type:varchar(20)"`
+   	ShippingCost     money.Money     `gorm:"column:shipping_cost;type:decimal(10,2);not null"`
+   	ShippingAddress  *string         `gorm:"column:shipping_address;type:text"`
+   	ShippingCounty   *string         `gorm:"column:shipping_county;type:varchar(100)"`
+   	ShippingTown     *string         `gorm:"column:shipping_town;type:varchar(100)"`
+   	PricesIncludeTax bool            `gorm:"column:prices_include_tax;type:boolean;not null"` // whether Total already includes TaxTotal
+   	Total            money.Money     `gorm:"column:total;type:decimal(10,2);not null;check:total >= 0"`
+   	Currency         string          `gorm:"column:currency;type:varchar(3);not null;default:KES"`       // the customer's currency, locked with its rate when the order is placed
+   	ExchangeRate     float64         `gorm:"column:exchange_rate;type:decimal(18,6);not null;default:1"` // Currency per KES; amounts are kept in KES
+   	CurrencyDecimals int             `gorm:"column:currency_decimals;type:tinyint;not null;default:2"`
+   	Status           string          `gorm:"column:status;type:varchar(50);default:pending;check:status IN ('pending', 'confirmed', 'processing', 'shipped', 'delivered', 'cancelled')"`
+   	CreatedAt        time.Time       `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+   	OrderItems       []OrderItem     `gorm:"ForeignKey:OrderId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+   	Payments         []Payment       `gorm:"ForeignKey:OrderId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+   	Discounts        []OrderDiscount `gorm:"ForeignKey:OrderId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+   	Taxes            []OrderTax      `gorm:"ForeignKey:OrderId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
    }
 
 func (Order) TableName() string {
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+// OrderDiscount snapshots a promotion applied to an order, so the order keeps its discounts when
+// the promotion is changed or deleted.
+type OrderDiscount struct {
+	Id          uint        `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	OrderId     uint        `gorm:"column:order_id;type:int;not null"`
+	PromotionId *uint       `gorm:"column:promotion_id;type:int"` // nil once the promotion is deleted
+	Code        *string     `gorm:"column:code;type:varchar(50)"`
+	Name        string      `gorm:"column:name;type:varchar(255);not null"`
+	Type        string      `gorm:"column:type;type:varchar(20);not null"`
+	Amount      money.Money `gorm:"column:amount;type:decimal(10,2);not null"`
+	CreatedAt   time.Time   `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (OrderDiscount) TableName() string {
+	return "tb_order_discount"
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// OrderDocument is an invoice or a payment receipt issued for an order, kept as the PDF that was
+// issued so it reads the same when the order or the catalogue change later.
+type OrderDocument struct {
+	Id        uint      `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	Number    string    `gorm:"column:number;type:varchar(20);unique;not null"` // e.g. INV-000042, numbered per type without gaps
+	Type      string    `gorm:"column:type;type:varchar(20);not null;check:type IN ('invoice', 'receipt')"`
+	OrderId   uint      `gorm:"column:order_id;type:int;not null"`
+	PaymentId *uint     `gorm:"column:payment_id;type:int"` // the payment a receipt is for
+	Content   []byte    `gorm:"column:content;type:longblob;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (OrderDocument) TableName() string {
+	return "tb_order_document"
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// OrderNote is an internal note an admin left on an order; customers never see them.
+type OrderNote struct {
+	Id        uint      `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	OrderId   uint      `gorm:"index;column:order_id;type:int;not null"`
+	AuthorId  *uint     `gorm:"column:author_id;type:int"` // nil once the admin's account is deleted
+	Author    *User     `gorm:"ForeignKey:AuthorId;References:Id"`
+	Body      string    `gorm:"column:body;type:text;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (OrderNote) TableName() string {
+	return "tb_order_note"
+}
@@ -0,0 +1,13 @@
+package entity
+
+// OrderStock is the stock an order took from a product at checkout: the units of a product on the
+// order, or of a component of a bundle on it. Cancelling the order puts exactly these units back.
+type OrderStock struct {
+	OrderId   uint   `gorm:"primaryKey;column:order_id;type:int"`
+	ProductId string `gorm:"primaryKey;column:product_id;type:varchar(36)"`
+	Quantity  int32  `gorm:"column:quantity;type:int;not null;check:quantity > 0"`
+}
+
+func (OrderStock) TableName() string {
+	return "tb_order_stock"
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "github.com/tech-hive/ecommerce/money"
+
+// OrderTax is the tax of one tax class on an order, at the rate charged when the order was placed.
+type OrderTax struct {
+	Id        uint        `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	OrderId   uint        `gorm:"column:order_id;type:int;not null"`
+	TaxClass  string      `gorm:"column:tax_class;type:varchar(20);not null"`
+	Name      string      `gorm:"column:name;type:varchar(100);not null"`
+	Rate      float64     `gorm:"column:rate;type:decimal(5,2);not null"`
+	Exempt    bool        `gorm:"column:exempt;type:boolean;not null"`
+	NetAmount money.Money `gorm:"column:net_amount;type:decimal(10,2);not null"`
+	TaxAmount money.Money `gorm:"column:tax_amount;type:decimal(10,2);not null"`
+}
+
+func (OrderTax) TableName() string {
+	return "tb_order_tax"
+}
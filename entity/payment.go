@@ -9,6 +9,7 @@ type Payment struct {
  	OrderId       uint      `gorm:"column:order_id;type:int;not null"`
  	Order         Order     `gorm:"ForeignKey:OrderId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
  	TransactionId string    `gorm:"column:transaction_id;type:varchar(255);unique"`
+ 	ReceiptNumber *string   `gorm:"column:receipt_number;type:varchar(20)"` // the M-Pesa receipt of a successful payment
  	Status        string    `gorm:"column:status;type:varchar(50);default:pending;check:status IN ('pending', 'success', 'failed', 'cancelled')"`
  	PaidAt        time.Time `gorm:"column:paid_at;type:timestamp;default:CURRENT_TIMESTAMP"`
  }
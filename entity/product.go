@@ -1,20 +1,35 @@
 package entity
 
 import (
+	"github.com/tech-hive/ecommerce/money"
 	"time"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Product struct {
- 	Id          uint          `gorm:"primaryKey;column:id;type:int;autoIncrement"`
- 	ProductId   uuid.UUID     `gorm:"column:product_id;type:varchar(36);unique;not null"`
- 	Name        string        `gorm:"index;column:name;type:varchar(100);not null"`
- 	Description string        `gorm:"column:description;type:text"`
- 	Price       float64       `gorm:"column:price;type:decimal(10,2);not null"`
- 	Stock       int32         `gorm:"column:quantity;type:int;default:0;not null"`
- 	ImageUrl    string        `gorm:"column:image_url;type:text"`
- 	CreatedAt   time.Time     `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
- 	OrderItems  []OrderItem   `gorm:"ForeignKey:ProductId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+ 	Id             uint                     `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+ 	ProductId      uuid.UUID                `gorm:"column:product_id;type:varchar(36);unique;not null"`
+ 	Sku            *string                  `gorm:"column:sku;type:varchar(64);unique"`
+ 	Type           string                   `gorm:"column:type;type:varchar(20);default:simple;not null;check:type IN ('simple', 'bundle')"`
+ 	Name           string                   `gorm:"index;column:name;type:varchar(100);not null"`
+ 	Description    string                   `gorm:"column:description;type:text"`
+ 	Category       string                   `gorm:"index;column:category;type:varchar(100)"`
+ 	TaxClass       string                   `gorm:"column:tax_class;type:varchar(20);default:standard;not null"`
+ 	Price          money.Money              `gorm:"column:price;type:decimal(10,2);not null"`
+ 	Weight         float64                  `gorm:"column:weight;type:decimal(8,3);not null"`   // kg
+ 	CompareAtPrice *money.Money             `gorm:"column:compare_at_price;type:decimal(10,2)"` // regular price while a sale runs
+ 	SaleEndsAt     *time.Time               `gorm:"column:sale_ends_at;type:timestamp"`
+ 	Stock          int32                    `gorm:"column:quantity;type:int;default:0;not null"` // derived from components for bundles
+ 	RatingAverage  float64                  `gorm:"index;column:rating_average;type:decimal(3,2);default:0;not null"`
+ 	RatingCount    int32                    `gorm:"column:rating_count;type:int;default:0;not null"`
+ 	ImageUrl       string                   `gorm:"column:image_url;type:text"`
+ 	CreatedAt      time.Time                `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+ 	UpdatedAt      time.Time                `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+ 	DeletedAt      gorm.DeletedAt           `gorm:"index;column:deleted_at;type:timestamp"` // set when archived
+ 	OrderItems     []OrderItem              `gorm:"ForeignKey:ProductId;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+ 	Attributes     []ProductAttributeValue  `gorm:"ForeignKey:ProductId;References:ProductId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+ 	Components     []ProductBundleComponent `gorm:"ForeignKey:BundleProductId;References:ProductId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
  }
 
 func (Product) TableName() string {
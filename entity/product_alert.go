@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+type ProductAlert struct {
+	Id        uint         `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	UserId    uint         `gorm:"column:user_id;type:int;not null"`
+	User      User         `gorm:"ForeignKey:UserId;References:Id"`
+	ProductId string       `gorm:"column:product_id;type:varchar(36);not null"`
+	Product   Product      `gorm:"ForeignKey:ProductId;References:ProductId"`
+	Type      string       `gorm:"column:type;type:varchar(20);not null;check:type IN ('price_drop', 'back_in_stock')"`
+	OldPrice  *money.Money `gorm:"column:old_price;type:decimal(10,2)"` // price drops only
+	NewPrice  *money.Money `gorm:"column:new_price;type:decimal(10,2)"`
+	Status    string       `gorm:"index:idx_tb_product_alert_status,priority:1;column:status;type:varchar(50);default:pending;check:status IN ('pending', 'sent', 'failed')"`
+	Attempts  int32        `gorm:"column:attempts;type:int;default:0;not null"`
+	LastError string       `gorm:"column:last_error;type:text"`
+	CreatedAt time.Time    `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	SentAt    *time.Time   `gorm:"column:sent_at;type:timestamp"`
+}
+
+func (ProductAlert) TableName() string {
+	return "tb_product_alert"
+}
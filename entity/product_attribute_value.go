@@ -0,0 +1,14 @@
+package entity
+
+type ProductAttributeValue struct {
+	ProductId   string              `gorm:"primaryKey;column:product_id;type:varchar(36)"`
+	AttributeId uint                `gorm:"primaryKey;column:attribute_id;type:int"`
+	Attribute   AttributeDefinition `gorm:"ForeignKey:AttributeId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	// ValueText holds every value in canonical text form, so equality filters work for any type.
+	ValueText   string   `gorm:"column:value_text;type:varchar(255);not null"`
+	ValueNumber *float64 `gorm:"column:value_number;type:decimal(18,4)"` // number attributes only, for range filters
+}
+
+func (ProductAttributeValue) TableName() string {
+	return "tb_product_attribute_value"
+}
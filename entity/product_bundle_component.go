@@ -0,0 +1,12 @@
+package entity
+
+type ProductBundleComponent struct {
+	BundleProductId    string  `gorm:"primaryKey;column:bundle_product_id;type:varchar(36)"`
+	ComponentProductId string  `gorm:"primaryKey;index;column:component_product_id;type:varchar(36)"`
+	Component          Product `gorm:"ForeignKey:ComponentProductId;References:ProductId;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Quantity           int32   `gorm:"column:quantity;type:int;not null;check:quantity > 0"`
+}
+
+func (ProductBundleComponent) TableName() string {
+	return "tb_product_bundle_component"
+}
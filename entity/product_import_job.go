@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+	"github.com/google/uuid"
+)
+
+type ProductImportJob struct {
+	Id            uuid.UUID  `gorm:"primaryKey;column:id;type:varchar(36)"`
+	Format        string     `gorm:"column:format;type:varchar(10);not null;check:format IN ('csv', 'json')"`
+	Status        string     `gorm:"column:status;type:varchar(50);default:pending;check:status IN ('pending', 'running', 'completed', 'failed')"`
+	TotalRows     int        `gorm:"column:total_rows;type:int;default:0;not null"`
+	ProcessedRows int        `gorm:"column:processed_rows;type:int;default:0;not null"`
+	CreatedCount  int        `gorm:"column:created_count;type:int;default:0;not null"`
+	UpdatedCount  int        `gorm:"column:updated_count;type:int;default:0;not null"`
+	FailedCount   int        `gorm:"column:failed_count;type:int;default:0;not null"`
+	Errors        string     `gorm:"column:errors;type:longtext"` // JSON array of per-row errors
+	CreatedAt     time.Time  `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	FinishedAt    *time.Time `gorm:"column:finished_at;type:timestamp"`
+}
+
+func (ProductImportJob) TableName() string {
+	return "tb_product_import_job"
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+type ProductPriceHistory struct {
+	Id         uint        `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	ProductId  string      `gorm:"column:product_id;type:varchar(36);not null"`
+	OldPrice   money.Money `gorm:"column:old_price;type:decimal(10,2);not null"`
+	NewPrice   money.Money `gorm:"column:new_price;type:decimal(10,2);not null"`
+	Reason     string      `gorm:"column:reason;type:varchar(50);not null;check:reason IN ('manual', 'import', 'scheduled', 'sale_started', 'sale_ended')"`
+	ScheduleId *uint       `gorm:"column:schedule_id;type:int"`
+	CreatedAt  time.Time   `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (ProductPriceHistory) TableName() string {
+	return "tb_product_price_history"
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+type ProductPriceSchedule struct {
+	Id        uint        `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	ProductId string      `gorm:"column:product_id;type:varchar(36);not null"`
+	Type      string      `gorm:"column:type;type:varchar(10);not null;check:type IN ('price', 'sale')"`
+	Price     money.Money `gorm:"column:price;type:decimal(10,2);not null"`
+	StartsAt  time.Time   `gorm:"index:idx_tb_product_price_schedule_due,priority:2;column:starts_at;type:timestamp;not null"`
+	EndsAt    *time.Time  `gorm:"column:ends_at;type:timestamp"` // sales only
+	Status    string      `gorm:"index:idx_tb_product_price_schedule_due,priority:1;column:status;type:varchar(50);default:pending;check:status IN ('pending', 'active', 'completed', 'cancelled')"`
+	CreatedAt time.Time   `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (ProductPriceSchedule) TableName() string {
+	return "tb_product_price_schedule"
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type ProductRecommendation struct {
+	ProductId            string    `gorm:"primaryKey;column:product_id;type:varchar(36)"`
+	Kind                 string    `gorm:"primaryKey;column:kind;type:varchar(20);check:kind IN ('bought_together', 'related')"`
+	RecommendedProductId string    `gorm:"primaryKey;column:recommended_product_id;type:varchar(36)"`
+	RecommendedProduct   Product   `gorm:"ForeignKey:RecommendedProductId;References:ProductId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Score                float64   `gorm:"column:score;type:decimal(10,4);not null"`
+	Rank                 int       `gorm:"column:rank;type:int;not null"`
+	ComputedAt           time.Time `gorm:"column:computed_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (ProductRecommendation) TableName() string {
+	return "tb_product_recommendation"
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type ProductReview struct {
+	Id        uint      `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	ProductId string    `gorm:"column:product_id;type:varchar(36);not null;uniqueIndex:uk_tb_product_review_product_user"`
+	UserId    uint      `gorm:"column:user_id;type:int;not null;uniqueIndex:uk_tb_product_review_product_user"`
+	User      User      `gorm:"ForeignKey:UserId;References:Id"`
+	OrderId   uint      `gorm:"column:order_id;type:int;not null"`
+	Rating    int32     `gorm:"column:rating;type:int;not null;check:rating BETWEEN 1 AND 5"`
+	Title     string    `gorm:"column:title;type:varchar(150);not null"`
+	Body      string    `gorm:"column:body;type:text"`
+	Images    string    `gorm:"column:images;type:text"` // JSON array of image URLs
+	Status    string    `gorm:"index;column:status;type:varchar(50);default:pending;check:status IN ('pending', 'approved', 'hidden')"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (ProductReview) TableName() string {
+	return "tb_product_review"
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+type Promotion struct {
+	Id                uint        `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	Code              *string     `gorm:"column:code;type:varchar(50);unique"` // nil for automatic promotions
+	Name              string      `gorm:"column:name;type:varchar(255);not null"`
+	Type              string      `gorm:"column:type;type:varchar(20);not null;check:type IN ('percentage', 'fixed', 'free_shipping', 'buy_x_get_y')"`
+	Value             float64     `gorm:"column:value;type:decimal(10,2);not null"` // percent off, or percent off the free items of buy_x_get_y
+	Amount            money.Money `gorm:"column:amount;type:decimal(10,2);not null"` // amount off, fixed promotions only
+	MinSpend          money.Money `gorm:"column:min_spend;type:decimal(10,2);not null"`
+	Scope             string      `gorm:"column:scope;type:varchar(20);not null;check:scope IN ('order', 'product', 'category')"`
+	ScopeProductId    *string     `gorm:"column:scope_product_id;type:varchar(36)"`
+	ScopeCategory     *string     `gorm:"column:scope_category;type:varchar(100)"`
+	UserId            *uint       `gorm:"column:user_id;type:int"` // the only customer who may redeem it; nil for anyone
+	BuyQuantity       int32       `gorm:"column:buy_quantity;type:int;not null"`
+	GetQuantity       int32       `gorm:"column:get_quantity;type:int;not null"`
+	UsageLimit        *int32      `gorm:"column:usage_limit;type:int"`
+	UsageLimitPerUser *int32      `gorm:"column:usage_limit_per_user;type:int"`
+	UsageCount        int32       `gorm:"column:usage_count;type:int;not null"`
+	Stackable         bool        `gorm:"column:stackable;type:boolean;not null"`
+	Active            bool        `gorm:"column:active;type:boolean;not null"`
+	StartsAt          *time.Time  `gorm:"column:starts_at;type:timestamp"`
+	EndsAt            *time.Time  `gorm:"column:ends_at;type:timestamp"`
+	CreatedAt         time.Time   `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time   `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (Promotion) TableName() string {
+	return "tb_promotion"
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type PromotionRedemption struct {
+	Id          uint      `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	PromotionId uint      `gorm:"column:promotion_id;type:int;not null"`
+	UserId      uint      `gorm:"column:user_id;type:int;not null"`
+	OrderId     uint      `gorm:"column:order_id;type:int;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (PromotionRedemption) TableName() string {
+	return "tb_promotion_redemption"
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+type ShippingMethod struct {
+	Id        uint           `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	ZoneId    uint           `gorm:"column:zone_id;type:int;not null"`
+	Name      string         `gorm:"column:name;type:varchar(100);not null"`
+	Type      string         `gorm:"column:type;type:varchar(20);not null;check:type IN ('standard', 'express', 'pickup_station')"`
+	RateType  string         `gorm:"column:rate_type;type:varchar(20);not null;check:rate_type IN ('flat', 'weight', 'order_value')"`
+	FlatFee   money.Money    `gorm:"column:flat_fee;type:decimal(10,2);not null"` // flat rate methods only
+	MinDays   int32          `gorm:"column:min_days;type:int;not null"`
+	MaxDays   int32          `gorm:"column:max_days;type:int;not null"`
+	Active    bool           `gorm:"column:active;type:boolean;not null"`
+	CreatedAt time.Time      `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	Rates     []ShippingRate `gorm:"ForeignKey:MethodId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (ShippingMethod) TableName() string {
+	return "tb_shipping_method"
+}
+
+// ShippingRate is the fee of a weight (kg) or order value bracket, from its min up to but excluding
+// its max; a nil max has no upper bound. Weight methods use the weight bounds, order value methods
+// the order value ones.
+type ShippingRate struct {
+	Id            uint         `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	MethodId      uint         `gorm:"column:method_id;type:int;not null"`
+	MinWeight     float64      `gorm:"column:min_weight;type:decimal(10,3);not null"`
+	MaxWeight     *float64     `gorm:"column:max_weight;type:decimal(10,3)"`
+	MinOrderValue money.Money  `gorm:"column:min_order_value;type:decimal(10,2);not null"`
+	MaxOrderValue *money.Money `gorm:"column:max_order_value;type:decimal(10,2)"`
+	Fee           money.Money  `gorm:"column:fee;type:decimal(10,2);not null"`
+}
+
+func (ShippingRate) TableName() string {
+	return "tb_shipping_rate"
+}
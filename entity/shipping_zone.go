@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// ShippingZone groups the counties and towns that share the same shipping methods.
+type ShippingZone struct {
+	Id        uint                   `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	Name      string                 `gorm:"column:name;type:varchar(100);unique;not null"`
+	CreatedAt time.Time              `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time              `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	Locations []ShippingZoneLocation `gorm:"ForeignKey:ZoneId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Methods   []ShippingMethod       `gorm:"ForeignKey:ZoneId;References:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (ShippingZone) TableName() string {
+	return "tb_shipping_zone"
+}
+
+// ShippingZoneLocation is a town of a county, or the whole county when Town is empty. A town belongs to
+// the zone that lists it before the zone of its county.
+type ShippingZoneLocation struct {
+	Id     uint   `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	ZoneId uint   `gorm:"column:zone_id;type:int;not null"`
+	County string `gorm:"column:county;type:varchar(100);not null"`
+	Town   string `gorm:"column:town;type:varchar(100);not null"`
+}
+
+func (ShippingZoneLocation) TableName() string {
+	return "tb_shipping_zone_location"
+}
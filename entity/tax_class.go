@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type TaxClass struct {
+	Code      string    `gorm:"primaryKey;column:code;type:varchar(20)"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null"`
+	Rate      float64   `gorm:"column:rate;type:decimal(5,2);not null"` // percent
+	Exempt    bool      `gorm:"column:exempt;type:boolean;not null"`    // outside VAT, unlike a zero rate
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (TaxClass) TableName() string {
+	return "tb_tax_class"
+}
@@ -1,12 +1,15 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/google/uuid"
+)
 
 type TransactionDetail struct {
-	Id            uuid.UUID `gorm:"primaryKey;column:transaction_detail_id;type:varchar(36)"`
-	SubTotalPrice int64     `gorm:"column:sub_total_price"`
-	Price         int64     `gorm:"column:price"`
-	Quantity      int32     `gorm:"column:quantity"`
+	Id            uuid.UUID   `gorm:"primaryKey;column:transaction_detail_id;type:varchar(36)"`
+	SubTotalPrice money.Money `gorm:"column:sub_total_price"`
+	Price         money.Money `gorm:"column:price"`
+	Quantity      int32       `gorm:"column:quantity"`
 	TransactionId uuid.UUID
 	ProductId     uuid.UUID
 	Product       Product
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type WishlistItem struct {
+	Id                uint      `gorm:"primaryKey;column:id;type:int;autoIncrement"`
+	UserId            uint      `gorm:"column:user_id;type:int;not null;uniqueIndex:uk_tb_wishlist_item_user_product"`
+	ProductId         string    `gorm:"index;column:product_id;type:varchar(36);not null;uniqueIndex:uk_tb_wishlist_item_user_product"`
+	Product           Product   `gorm:"ForeignKey:ProductId;References:ProductId"`
+	NotifyPriceDrop   bool      `gorm:"column:notify_price_drop;type:boolean;not null"`
+	NotifyBackInStock bool      `gorm:"column:notify_back_in_stock;type:boolean;not null"`
+	CreatedAt         time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (WishlistItem) TableName() string {
+	return "tb_wishlist_item"
+}
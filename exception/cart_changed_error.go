@@ -0,0 +1,12 @@
+package exception
+
+import "github.com/tech-hive/ecommerce/model"
+
+// CartChangedError stops a checkout whose cart has lines that changed since they were added.
+type CartChangedError struct {
+	Warnings []model.CartWarningModel
+}
+
+func (cartChangedError CartChangedError) Error() string {
+	return "cart has changed, review and acknowledge the changes before placing the order"
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"github.com/tech-hive/ecommerce/client/notifier"
 	"github.com/tech-hive/ecommerce/client/restclient"
 	"github.com/tech-hive/ecommerce/configuration"
 	"github.com/tech-hive/ecommerce/controller"
@@ -42,31 +44,73 @@ func main() {
 		userRepository := repository.NewUserRepositoryImpl(database)
 		cartRepository := repository.NewCartRepositoryImpl(database)
 		orderRepository := repository.NewOrderRepositoryImpl(database)
+		productReviewRepository := repository.NewProductReviewRepositoryImpl(database)
+		productImportJobRepository := repository.NewProductImportJobRepositoryImpl(database)
+		productPriceRepository := repository.NewProductPriceRepositoryImpl(database)
+		attributeRepository := repository.NewAttributeRepositoryImpl(database)
+		productRecommendationRepository := repository.NewProductRecommendationRepositoryImpl(database)
+		wishlistRepository := repository.NewWishlistRepositoryImpl(database)
+		productAlertRepository := repository.NewProductAlertRepositoryImpl(database)
+		promotionRepository := repository.NewPromotionRepositoryImpl(database)
+		taxRepository := repository.NewTaxRepositoryImpl(database)
+		shippingRepository := repository.NewShippingRepositoryImpl(database)
+		currencyRepository := repository.NewCurrencyRepositoryImpl(database)
+		orderDocumentRepository := repository.NewOrderDocumentRepositoryImpl(database)
+		orderNoteRepository := repository.NewOrderNoteRepositoryImpl(database)
+		cartReminderRepository := repository.NewCartReminderRepositoryImpl(database)
 
 	//rest client
 	httpBinRestClient := restclient.NewHttpBinRestClient()
+	logNotifier := notifier.NewLogNotifier()
 
 	//service
-		productService := service.NewProductServiceImpl(&productRepository, redis)
+		productService := service.NewProductServiceImpl(&productRepository, &attributeRepository, &taxRepository, redis, config)
 		transactionService := service.NewTransactionServiceImpl(&transactionRepository)
 		transactionDetailService := service.NewTransactionDetailServiceImpl(&transactionDetailRepository)
 		userService := service.NewUserServiceImpl(&userRepository)
-		cartService := service.NewCartServiceImpl(&cartRepository, &productRepository, database)
-		orderService := service.NewOrderServiceImpl(&orderRepository, &cartRepository, &productRepository, database)
+		cartService := service.NewCartServiceImpl(&cartRepository, &productRepository, &promotionRepository, &taxRepository, &shippingRepository, database, config)
+		orderService := service.NewOrderServiceImpl(&orderRepository, &cartRepository, &productRepository, &promotionRepository, &taxRepository, &shippingRepository, &currencyRepository, &cartReminderRepository, database, redis, config)
 		mpesaService := service.NewMpesaServiceImpl(config, &orderRepository, database)
 		seedService := service.NewSeedServiceImpl(&userRepository, &productRepository, database)
 		httpBinService := service.NewHttpBinServiceImpl(&httpBinRestClient)
+		productReviewService := service.NewProductReviewServiceImpl(&productReviewRepository, &productRepository, redis)
+		productImportService := service.NewProductImportServiceImpl(&productRepository, &productImportJobRepository, &attributeRepository, redis)
+		productPriceService := service.NewProductPriceServiceImpl(&productPriceRepository, &productRepository, redis)
+		attributeService := service.NewAttributeServiceImpl(&attributeRepository)
+		productRecommendationService := service.NewProductRecommendationServiceImpl(&productRecommendationRepository, &productRepository, redis)
+		wishlistService := service.NewWishlistServiceImpl(&wishlistRepository, &productRepository)
+		productAlertService := service.NewProductAlertServiceImpl(&productAlertRepository, &logNotifier)
+		promotionService := service.NewPromotionServiceImpl(&promotionRepository, &productRepository)
+		taxService := service.NewTaxServiceImpl(&taxRepository)
+		shippingService := service.NewShippingServiceImpl(&shippingRepository)
+		currencyService := service.NewCurrencyServiceImpl(&currencyRepository, redis)
+		orderDocumentService := service.NewOrderDocumentServiceImpl(&orderRepository, &orderDocumentRepository, configuration.NewInvoiceIssuer(config))
+		orderExpiryService := service.NewOrderExpiryServiceImpl(&orderRepository, &promotionRepository, &logNotifier, redis, configuration.NewOrderExpiryPolicy(config))
+		cartRecoveryService := service.NewCartRecoveryServiceImpl(&cartReminderRepository, &logNotifier, redis, configuration.NewCartRecoveryPolicy(config))
+		orderAdminService := service.NewOrderAdminServiceImpl(&orderRepository, &orderNoteRepository, &promotionRepository, redis)
 
 	//controller
-		productController := controller.NewProductController(&productService, config)
+		productController := controller.NewProductController(&productService, &currencyService, config, redis)
 		transactionController := controller.NewTransactionController(&transactionService, config)
 		transactionDetailController := controller.NewTransactionDetailController(&transactionDetailService, config)
-		userController := controller.NewUserController(&userService, config)
-		cartController := controller.NewCartController(&cartService, config)
-		orderController := controller.NewOrderController(&orderService, config)
+		userController := controller.NewUserController(&userService, &cartService, config)
+		cartController := controller.NewCartController(&cartService, &currencyService, config)
+		orderController := controller.NewOrderController(&orderService, &orderDocumentService, config)
 		mpesaController := controller.NewMpesaController(&mpesaService, config)
 		seedController := controller.NewSeedController(&seedService, config)
 		httpBinController := controller.NewHttpBinController(&httpBinService)
+		productReviewController := controller.NewProductReviewController(&productReviewService, config)
+		productImportController := controller.NewProductImportController(&productImportService, config)
+		productPriceController := controller.NewProductPriceController(&productPriceService, config)
+		attributeController := controller.NewAttributeController(&attributeService, config)
+		productRecommendationController := controller.NewProductRecommendationController(&productRecommendationService)
+		wishlistController := controller.NewWishlistController(&wishlistService, config)
+		promotionController := controller.NewPromotionController(&promotionService, config)
+		taxController := controller.NewTaxController(&taxService, config)
+		shippingController := controller.NewShippingController(&shippingService, config)
+		currencyController := controller.NewCurrencyController(&currencyService, config)
+		cartRecoveryController := controller.NewCartRecoveryController(&cartRecoveryService, config)
+		orderAdminController := controller.NewOrderAdminController(&orderAdminService, &orderDocumentService, config)
 
 	//setup fiber
 	app := fiber.New(configuration.NewFiberConfiguration())
@@ -74,11 +118,13 @@ func main() {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "http://localhost:3000, http://127.0.0.1:3000, http://localhost:9999, http://app:9999",
 		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+		AllowHeaders: "Origin,Content-Type,Accept,Authorization,X-Cart-Token",
+		ExposeHeaders: "X-Cart-Token",
 		AllowCredentials: true,
 	}))
 
 	//routing
+		productImportController.Route(app)
 		productController.Route(app)
 		transactionController.Route(app)
 		transactionDetailController.Route(app)
@@ -88,6 +134,24 @@ func main() {
 		mpesaController.Route(app)
 		seedController.Route(app)
 		httpBinController.Route(app)
+		productReviewController.Route(app)
+		productPriceController.Route(app)
+		attributeController.Route(app)
+		productRecommendationController.Route(app)
+		wishlistController.Route(app)
+		promotionController.Route(app)
+		taxController.Route(app)
+		shippingController.Route(app)
+		currencyController.Route(app)
+		cartRecoveryController.Route(app)
+		orderAdminController.Route(app)
+
+	//scheduler
+	configuration.NewScheduler(config, "product_price").Start(context.Background(), productPriceService.ApplyDueSchedules)
+	configuration.NewScheduler(config, "product_recommendation").Start(context.Background(), productRecommendationService.Refresh)
+	configuration.NewScheduler(config, "product_alert").Start(context.Background(), productAlertService.DispatchPending)
+	configuration.NewScheduler(config, "cart_recovery").Start(context.Background(), cartRecoveryService.SendReminders)
+	configuration.NewScheduler(config, "order_expiry").Start(context.Background(), orderExpiryService.ExpireUnpaid)
 
 	//swagger
 	app.Get("/swagger/*", swagger.HandlerDefault)
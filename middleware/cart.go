@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuthenticateCart lets customers reach their cart with a JWT and guests with a signed cart token
+// from the X-Cart-Token header or the cart_token cookie. The guest id is stored in the
+// "cart_guest_id" local. Requests with neither, or with an expired cart token, carry on without a
+// cart, so guests can start one.
+func AuthenticateCart(config configuration.Config) func(*fiber.Ctx) error {
+	authenticateCustomer := AuthenticateJWT("customer", config)
+	return func(ctx *fiber.Ctx) error {
+		if ctx.Get(fiber.HeaderAuthorization) != "" {
+			return authenticateCustomer(ctx)
+		}
+
+		token := ctx.Get(common.CartTokenHeader)
+		if token == "" {
+			token = ctx.Cookies(common.CartTokenCookie)
+		}
+		if token == "" {
+			return ctx.Next()
+		}
+
+		guestId, err := common.ParseCartToken(token, config)
+		if err == common.ErrCartTokenExpired {
+			ctx.ClearCookie(common.CartTokenCookie)
+			return ctx.Next()
+		}
+		if err != nil {
+			return ctx.
+				Status(fiber.StatusUnauthorized).
+				JSON(model.GeneralResponse{
+					Code:    401,
+					Message: "Unauthorized",
+					Data:    "Invalid cart token",
+				})
+		}
+		ctx.Locals("cart_guest_id", guestId)
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/go-redis/redis/v9"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type httpCacheEntry struct {
+	Body         []byte `json:"body"`
+	ContentType  string `json:"content_type"`
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+}
+
+// CacheResponse serves GET responses from Redis and answers conditional requests
+// with 304 Not Modified. Entries are keyed by the namespace version, so bumping it
+// with configuration.InvalidateCacheNamespace drops every cached page at once.
+// When Redis is unavailable the request simply goes to the handler.
+func CacheResponse(cacheManager *redis.Client, namespace string, ttl time.Duration) func(*fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		if ctx.Method() != fiber.MethodGet {
+			return ctx.Next()
+		}
+
+		version, modifiedAt, err := configuration.GetCacheNamespace(cacheManager, ctx.Context(), namespace)
+		if err != nil {
+			common.NewLogger().Error("Response cache unavailable: ", err.Error())
+			return ctx.Next()
+		}
+		key := namespace + "_response_" + strconv.FormatInt(version, 10) + "_" + ctx.OriginalURL()
+
+		var entry httpCacheEntry
+		if data, err := cacheManager.Get(ctx.Context(), key).Bytes(); err == nil && json.Unmarshal(data, &entry) == nil {
+			ctx.Set("X-Cache", "HIT")
+			return writeCacheEntry(ctx, entry)
+		}
+
+		if err := ctx.Next(); err != nil {
+			return err
+		}
+		if ctx.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+
+		body := append([]byte(nil), ctx.Response().Body()...)
+		checksum := sha1.Sum(body)
+		entry = httpCacheEntry{
+			Body:         body,
+			ContentType:  string(ctx.Response().Header.ContentType()),
+			ETag:         `"` + hex.EncodeToString(checksum[:]) + `"`,
+			LastModified: modifiedAt.UTC().Format(http.TimeFormat),
+		}
+		if data, err := json.Marshal(entry); err == nil {
+			if err := cacheManager.Set(ctx.Context(), key, data, ttl).Err(); err != nil {
+				common.NewLogger().Error("Failed to store cached response: ", err.Error())
+			}
+		}
+
+		ctx.Set("X-Cache", "MISS")
+		return writeCacheEntry(ctx, entry)
+	}
+}
+
+func writeCacheEntry(ctx *fiber.Ctx, entry httpCacheEntry) error {
+	ctx.Set(fiber.HeaderETag, entry.ETag)
+	ctx.Set(fiber.HeaderLastModified, entry.LastModified)
+	ctx.Set(fiber.HeaderCacheControl, "public, no-cache")
+
+	if ctx.Fresh() {
+		ctx.Response().ResetBody()
+		ctx.Status(fiber.StatusNotModified)
+		return nil
+	}
+
+	ctx.Set(fiber.HeaderContentType, entry.ContentType)
+	ctx.Status(fiber.StatusOK)
+	return ctx.Send(entry.Body)
+}
@@ -0,0 +1,33 @@
+package model
+
+type AttributeDefinitionCreateModel struct {
+	Code    string   `json:"code" validate:"required,max=50"`
+	Name    string   `json:"name" validate:"required,max=100"`
+	Type    string   `json:"type" validate:"required,oneof=text number enum boolean"`
+	Unit    string   `json:"unit" validate:"max=20"`
+	Options []string `json:"options" validate:"required_if=Type enum,dive,required,max=255"`
+}
+
+type AttributeDefinitionModel struct {
+	Id      uint     `json:"id"`
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Unit    string   `json:"unit,omitempty"`
+	Options []string `json:"options,omitempty"`
+	// Required is only set when listing the attributes of a category.
+	Required bool `json:"required,omitempty"`
+}
+
+type CategoryAttributeModel struct {
+	Required bool `json:"required"`
+}
+
+// ProductAttributeModel is one line of a product's specification sheet.
+type ProductAttributeModel struct {
+	Code  string      `json:"code"`
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Unit  string      `json:"unit,omitempty"`
+	Value interface{} `json:"value"`
+}
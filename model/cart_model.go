@@ -1,21 +1,63 @@
 package model
 
+import "github.com/tech-hive/ecommerce/money"
+
 type CartModel struct {
-	Id        uint                   `json:"id"`
-	UserId    uint                   `json:"user_id"`
-	Items     []CartItemModel        `json:"items"`
-	Total     float64                `json:"total"`
-	CreatedAt string                 `json:"created_at"`
+	Id               uint                `json:"id"`
+	UserId           uint                `json:"user_id"`
+	Name             string              `json:"name"`
+	Items            []CartItemModel     `json:"items"`
+	Subtotal         money.Money         `json:"subtotal"`
+	Discounts        []DiscountModel     `json:"discounts"`
+	DiscountTotal    money.Money         `json:"discount_total"`
+	FreeShipping     bool                `json:"free_shipping"`
+	Taxes            []TaxBreakdownModel `json:"taxes"`
+	TaxTotal         money.Money         `json:"tax_total"`
+	PricesIncludeTax bool                `json:"prices_include_tax"` // whether Total already includes TaxTotal
+	Coupons          []CartCouponModel   `json:"coupons"`
+	Total            money.Money         `json:"total"`
+	Currency         string              `json:"currency"`
+	Warnings         []CartWarningModel  `json:"warnings"` // lines that changed since they were added; checkout needs them acknowledged
+	CreatedAt        string              `json:"created_at"`
 }
 
 type CartItemModel struct {
-	Id         uint    `json:"id"`
-	CartId     uint    `json:"cart_id"`
-	ProductId  string  `json:"product_id"`
-	Product    ProductModel `json:"product"`
-	Quantity   int32   `json:"quantity"`
-	Price      float64 `json:"price"`
-	CreatedAt  string  `json:"created_at"`
+	Id             uint         `json:"id"`
+	CartId         uint         `json:"cart_id"`
+	ProductId      string       `json:"product_id"`
+	Product        ProductModel `json:"product"`
+	Quantity       int32        `json:"quantity"`
+	Price          money.Money  `json:"price"`
+	TaxClass       string       `json:"tax_class"`
+	TaxRate        float64      `json:"tax_rate"`
+	DiscountAmount money.Money  `json:"discount_amount"`
+	TaxAmount      money.Money  `json:"tax_amount"`
+	CreatedAt      string       `json:"created_at"`
+}
+
+// CartWarningModel reports a cart line that no longer matches its product: the price changed, there
+// is not enough stock left for the quantity, or the product can no longer be bought.
+type CartWarningModel struct {
+	CartItemId        uint         `json:"cart_item_id"`
+	ProductId         string       `json:"product_id"`
+	Code              string       `json:"code"` // price_changed, insufficient_stock, out_of_stock or product_unavailable
+	Message           string       `json:"message"`
+	OldPrice          *money.Money `json:"old_price,omitempty"`
+	NewPrice          *money.Money `json:"new_price,omitempty"`
+	Quantity          int32        `json:"quantity,omitempty"`
+	AvailableQuantity int32        `json:"available_quantity,omitempty"`
+}
+
+// CartAcknowledgeModel lists the cart changes a customer accepts, as the cart warnings show them.
+type CartAcknowledgeModel struct {
+	Changes []CartChangeModel `json:"changes" validate:"dive"`
+}
+
+// CartChangeModel is a cart line as it will be once its warnings are acknowledged.
+type CartChangeModel struct {
+	CartItemId uint        `json:"cart_item_id" validate:"required"`
+	Price      money.Money `json:"price"`                     // the new_price warned of, or the line's price when only its stock changed
+	Quantity   int32       `json:"quantity" validate:"gte=0"` // the available_quantity warned of, the line's quantity when only its price changed, or 0 for lines removed
 }
 
 type AddToCartModel struct {
@@ -23,6 +65,43 @@ type AddToCartModel struct {
 	Quantity  int32  `json:"quantity" validate:"required,min=1"`
 }
 
+// CartOwnerModel identifies a cart: a customer's by UserId, or a guest's by the GuestId of its cart token.
+// CartId picks one of the named carts of a customer instead of their default cart.
+type CartOwnerModel struct {
+	UserId  uint
+	GuestId string
+	CartId  uint
+}
+
+// CartSummaryModel lists a named cart, or the save-for-later list, of a customer.
+type CartSummaryModel struct {
+	Id        uint   `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`    // cart, or saved for the save-for-later list
+	Default   bool   `json:"default"` // the cart used when no cart_id is given
+	ItemCount int    `json:"item_count"`
+	Quantity  int32  `json:"quantity"`
+	CreatedAt string `json:"created_at"`
+}
+
+type CartCreateOrUpdateModel struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// MoveCartItemModel moves a cart item to another cart of the customer, or to their save-for-later list.
+type MoveCartItemModel struct {
+	CartId       uint `json:"cart_id" validate:"required_without=SaveForLater"`
+	SaveForLater bool `json:"save_for_later"`
+}
+
+// MoveCartItemResultModel tells where a moved item ended up. Quantity is the product's quantity in
+// the target cart; Limited is set when adding the item to a line already there was capped at stock.
+type MoveCartItemResultModel struct {
+	CartId   uint  `json:"cart_id"`
+	Quantity int32 `json:"quantity"`
+	Limited  bool  `json:"limited"`
+}
+
 type UpdateCartItemModel struct {
 	Quantity int32 `json:"quantity" validate:"required,min=1"`
 }
\ No newline at end of file
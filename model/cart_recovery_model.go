@@ -0,0 +1,57 @@
+package model
+
+import "github.com/tech-hive/ecommerce/money"
+
+// CartReminderModel is what notifiers receive for a customer's abandoned cart.
+type CartReminderModel struct {
+	Id             uint                    `json:"id"`
+	Stage          int                     `json:"stage"` // 1 for the first reminder
+	UserId         uint                    `json:"user_id"`
+	Email          string                  `json:"email"`
+	Name           string                  `json:"name"`
+	CartId         uint                    `json:"cart_id"`
+	CartName       string                  `json:"cart_name"`
+	Items          []CartReminderItemModel `json:"items"`
+	CartValue      money.Money             `json:"cart_value"`
+	CouponCode     string                  `json:"coupon_code,omitempty"`
+	CouponPercent  float64                 `json:"coupon_percent,omitempty"`
+	CouponEndsAt   string                  `json:"coupon_ends_at,omitempty"`
+	LastActivityAt string                  `json:"last_activity_at"`
+}
+
+type CartReminderItemModel struct {
+	ProductId   string      `json:"product_id"`
+	ProductName string      `json:"product_name"`
+	ImageUrl    string      `json:"image_url"`
+	Quantity    int32       `json:"quantity"`
+	Price       money.Money `json:"price"`
+}
+
+// CartRecoveryReportModel reports the carts abandoned now, and the reminders sent in a period with
+// the carts they recovered. Rates are percentages.
+type CartRecoveryReportModel struct {
+	From             string                   `json:"from"`
+	To               string                   `json:"to"`
+	AbandonedCarts   int64                    `json:"abandoned_carts"` // carts with items untouched since the first reminder delay
+	AbandonedValue   money.Money              `json:"abandoned_value"`
+	RemindersSent    int64                    `json:"reminders_sent"`
+	CartsReminded    int64                    `json:"carts_reminded"`
+	CartsRecovered   int64                    `json:"carts_recovered"` // ordered within the recovery window of a reminder
+	RecoveryRate     float64                  `json:"recovery_rate"`
+	RecoveredRevenue money.Money              `json:"recovered_revenue"`
+	CouponsSent      int64                    `json:"coupons_sent"`
+	CouponsRedeemed  int64                    `json:"coupons_redeemed"`
+	Stages           []CartRecoveryStageModel `json:"stages"`
+}
+
+type CartRecoveryStageModel struct {
+	Stage        int     `json:"stage"`
+	Sent         int64   `json:"sent"`
+	Recovered    int64   `json:"recovered"` // orders attributed to this reminder, the last one before the order
+	RecoveryRate float64 `json:"recovery_rate"`
+}
+
+type CartRecoveryReportQueryModel struct {
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02"` // 30 days ago when empty
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02"`   // today when empty, inclusive
+}
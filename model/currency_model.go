@@ -0,0 +1,18 @@
+package model
+
+type CurrencyModel struct {
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Rate      float64 `json:"rate"` // units of the currency per KES
+	Decimals  int     `json:"decimals"`
+	Active    bool    `json:"active"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type CurrencyCreateOrUpdateModel struct {
+	Code     string  `json:"code" validate:"omitempty,len=3,uppercase"` // ISO 4217, on create only
+	Name     string  `json:"name" validate:"required,max=100"`
+	Rate     float64 `json:"rate" validate:"required,gt=0"`
+	Decimals int     `json:"decimals" validate:"min=0,max=2"`
+	Active   *bool   `json:"active"` // true when omitted
+}
@@ -0,0 +1,17 @@
+package model
+
+type ListQueryModel struct {
+	Page      int               `json:"page,omitempty" query:"page"`
+	Limit     int               `json:"limit,omitempty" query:"limit"`
+	Cursor    string            `json:"cursor,omitempty" query:"cursor"`
+	SortBy    string            `json:"sort_by,omitempty" query:"sort_by"`
+	SortOrder string            `json:"sort_order,omitempty" query:"sort_order"` // asc, desc
+	Filters   map[string]string `json:"filters,omitempty" query:"-"`
+}
+
+type PageInfoModel struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	TotalCount int64  `json:"total_count"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
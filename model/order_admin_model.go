@@ -0,0 +1,64 @@
+package model
+
+import "github.com/tech-hive/ecommerce/money"
+
+// OrderSearchModel filters the orders of all customers in the admin order console.
+type OrderSearchModel struct {
+	Status         string      `json:"status,omitempty" query:"status" validate:"omitempty,oneof=pending confirmed processing shipped delivered cancelled"`
+	PaymentStatus  string      `json:"payment_status,omitempty" query:"payment_status" validate:"omitempty,oneof=paid unpaid pending failed none"` // unpaid is any but paid
+	UserId         uint        `json:"user_id,omitempty" query:"user_id"`
+	Customer       string      `json:"customer,omitempty" query:"customer"` // part of the customer's name or email
+	Number         string      `json:"number,omitempty" query:"number"`
+	From           string      `json:"from,omitempty" query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To             string      `json:"to,omitempty" query:"to" validate:"omitempty,datetime=2006-01-02"` // inclusive
+	MinTotal       money.Money `json:"min_total,omitempty" query:"min_total"`                            // in KES
+	MaxTotal       money.Money `json:"max_total,omitempty" query:"max_total"`
+	ListQueryModel             // page, limit, cursor, sort_by (created_at, total, status), sort_order
+}
+
+// AdminOrderModel is an order as admins see it: with who placed it, how far its payment got and,
+// on a single order, its internal notes.
+type AdminOrderModel struct {
+	OrderModel
+	Customer      *OrderCustomerModel `json:"customer,omitempty"`
+	PaymentStatus string              `json:"payment_status"` // paid, pending, failed, or none without a payment attempt
+	Notes         []OrderNoteModel    `json:"notes,omitempty"`
+}
+
+type OrderCustomerModel struct {
+	Id    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type OrderNoteModel struct {
+	Id         uint   `json:"id"`
+	OrderId    uint   `json:"order_id"`
+	AuthorId   *uint  `json:"author_id"`
+	AuthorName string `json:"author_name,omitempty"`
+	Body       string `json:"body"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type OrderNoteCreateModel struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+type OrderBulkStatusModel struct {
+	OrderIds []uint `json:"order_ids" validate:"required,min=1,max=100"`
+	Status   string `json:"status" validate:"required,oneof=pending confirmed processing shipped delivered cancelled"`
+}
+
+// OrderBulkStatusResultModel tells which orders a bulk status update changed.
+type OrderBulkStatusResultModel struct {
+	Status    string                    `json:"status"`
+	Updated   []uint                    `json:"updated"`
+	Unchanged []uint                    `json:"unchanged"` // already in the status
+	Refused   []OrderStatusRefusalModel `json:"refused"`   // the status cannot follow theirs
+	NotFound  []uint                    `json:"not_found"`
+}
+
+type OrderStatusRefusalModel struct {
+	OrderId uint   `json:"order_id"`
+	Status  string `json:"status"` // the order's current status
+}
@@ -0,0 +1,10 @@
+package model
+
+// OrderDocumentModel is an invoice or a payment receipt of an order, as the PDF that was issued.
+type OrderDocumentModel struct {
+	Number    string `json:"number"`
+	Type      string `json:"type"`
+	OrderId   uint   `json:"order_id"`
+	Content   []byte `json:"-"`
+	CreatedAt string `json:"created_at"`
+}
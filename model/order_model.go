@@ -1,28 +1,51 @@
 package model
 
+import "github.com/tech-hive/ecommerce/money"
+
 type OrderModel struct {
-	Id         uint                   `json:"id"`
-	UserId     uint                   `json:"user_id"`
-	Total      float64                `json:"total"`
-	Status     string                 `json:"status"`
-	CreatedAt  string                 `json:"created_at"`
-	OrderItems []OrderItemModel       `json:"order_items"`
-	Payment    *PaymentModel          `json:"payment,omitempty"`
+	Id               uint                `json:"id"`
+	Number           string              `json:"number"` // e.g. TH-261019-4827315, also the M-Pesa account reference
+	UserId           uint                `json:"user_id"`
+	Subtotal         money.Money         `json:"subtotal"`
+	Discounts        []DiscountModel     `json:"discounts"`
+	DiscountTotal    money.Money         `json:"discount_total"`
+	FreeShipping     bool                `json:"free_shipping"`
+	Taxes            []TaxBreakdownModel `json:"taxes"`
+	TaxTotal         money.Money         `json:"tax_total"`
+	Shipping         *OrderShippingModel `json:"shipping,omitempty"` // omitted for orders placed before shipping was charged
+	ShippingCost     money.Money         `json:"shipping_cost"`
+	PricesIncludeTax bool                `json:"prices_include_tax"` // whether Total already includes TaxTotal
+	Total            money.Money         `json:"total"`
+	Currency         string              `json:"currency"`      // the amounts are in the currency the order was placed in
+	ExchangeRate     float64             `json:"exchange_rate"` // Currency per KES, locked when the order was placed
+	BaseTotal        money.Money         `json:"base_total"`    // Total in KES, the amount that is paid
+	Status           string              `json:"status"`
+	CreatedAt        string              `json:"created_at"`
+	OrderItems       []OrderItemModel    `json:"order_items"`
+	Payment          *PaymentModel       `json:"payment,omitempty"`
 }
 
 type OrderItemModel struct {
-	Id         uint    `json:"id"`
-	OrderId    uint    `json:"order_id"`
-	ProductId  string  `json:"product_id"`
-	Product    ProductModel `json:"product"`
-	Quantity   int32   `json:"quantity"`
-	Price      float64 `json:"price"`
-	CreatedAt  string  `json:"created_at"`
+	Id             uint         `json:"id"`
+	OrderId        uint         `json:"order_id"`
+	ProductId      string       `json:"product_id"`
+	Product        ProductModel `json:"product"`
+	Quantity       int32        `json:"quantity"`
+	Price          money.Money  `json:"price"`
+	TaxClass       string       `json:"tax_class,omitempty"`
+	TaxRate        float64      `json:"tax_rate"`
+	DiscountAmount money.Money  `json:"discount_amount"`
+	TaxAmount      money.Money  `json:"tax_amount"`
+	CreatedAt      string       `json:"created_at"`
 }
 
 type CreateOrderModel struct {
-	CartId     uint    `json:"cart_id" validate:"required"`
-	ShippingAddress string `json:"shipping_address" validate:"required"`
+	CartId           uint   `json:"cart_id"` // any of the customer's named carts; the default cart when omitted
+	ShippingAddress  string `json:"shipping_address" validate:"required"`
+	County           string `json:"county" validate:"required,max=100"`
+	Town             string `json:"town" validate:"max=100"`
+	ShippingMethodId uint   `json:"shipping_method_id" validate:"required"` // from the cart's shipping options for the county and town
+	Currency         string `json:"currency" validate:"omitempty,len=3"`    // KES when empty; locked on the order with today's rate
 }
 
 type UpdateOrderStatusModel struct {
@@ -33,6 +56,38 @@ type PaymentModel struct {
 	Id            uint   `json:"id"`
 	OrderId       uint   `json:"order_id"`
 	TransactionId string `json:"transaction_id"`
+	ReceiptNumber string `json:"receipt_number,omitempty"` // M-Pesa receipt number, once paid
 	Status        string `json:"status"`
 	PaidAt        string `json:"paid_at"`
+}
+
+// ReorderResultModel reports how the items of a past order went back into the customer's cart.
+type ReorderResultModel struct {
+	OrderId  uint               `json:"order_id"`
+	CartId   uint               `json:"cart_id"`
+	Added    []ReorderItemModel `json:"added"`     // in full, or in part when stock ran short
+	NotAdded []ReorderItemModel `json:"not_added"` // none of it could be added
+}
+
+type ReorderItemModel struct {
+	ProductId    string       `json:"product_id"`
+	Name         string       `json:"name"`
+	Ordered      int32        `json:"ordered"` // quantity on the order
+	Added        int32        `json:"added"`
+	OrderedPrice money.Money  `json:"ordered_price"`
+	Price        *money.Money `json:"price,omitempty"`  // today's price, which the cart charges
+	Reason       string       `json:"reason,omitempty"` // why not all was added: unavailable, out_of_stock or low_stock
+}
+
+// OrderNotificationModel is what notifiers receive when an order changes without the customer doing
+// it: "expired" when it was cancelled for not being paid in time.
+type OrderNotificationModel struct {
+	Type      string      `json:"type"`
+	OrderId   uint        `json:"order_id"`
+	Number    string      `json:"number"`
+	UserId    uint        `json:"user_id"`
+	Email     string      `json:"email"`
+	Name      string      `json:"name"`
+	Total     money.Money `json:"total"` // in KES
+	CreatedAt string      `json:"created_at"`
 }
\ No newline at end of file
@@ -1,9 +1,11 @@
 package model
 
+import "github.com/tech-hive/ecommerce/money"
+
 type MpesaPaymentRequest struct {
-	OrderId     uint    `json:"order_id" validate:"required"`
-	PhoneNumber string  `json:"phone_number" validate:"required,len=12" example:"254712345678"`
-	Amount      float64 `json:"amount" validate:"required,min=1" example:"1000.00"`
+	OrderId     uint        `json:"order_id" validate:"required"`
+	PhoneNumber string      `json:"phone_number" validate:"required,len=12" example:"254712345678"`
+	Amount      money.Money `json:"amount" validate:"required,min=100" example:"1000.00"` // the order total; min is in cents
 }
 
 type MpesaPaymentResponse struct {
@@ -12,6 +14,7 @@ type MpesaPaymentResponse struct {
 	ResponseCode      string `json:"response_code"`
 	ResponseMessage   string `json:"response_message"`
 	CustomerMessage   string `json:"customer_message"`
+	AccountReference  string `json:"account_reference"` // the order number's date and random digits, shown to the customer on M-Pesa
 }
 
 type MpesaCallbackRequest struct {
@@ -36,7 +39,7 @@ type MpesaSTKPushRequest struct {
 	Password          string  `json:"password"`
 	Timestamp         string  `json:"timestamp"`
 	TransactionType   string  `json:"transaction_type"`
-	Amount            float64 `json:"amount"`
+	Amount            int64   `json:"amount"` // whole shillings; M-Pesa takes no cents
 	PartyA            string  `json:"party_a"`
 	PartyB            string  `json:"party_b"`
 	PhoneNumber       string  `json:"phone_number"`
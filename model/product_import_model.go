@@ -0,0 +1,28 @@
+package model
+
+// ProductImportRowModel is one row of a bulk import or export. Rows are matched to
+// existing products by Id first and then by Sku; unmatched rows create a product.
+type ProductImportRowModel struct {
+	Id string `json:"id" validate:"omitempty,uuid"`
+	ProductCreateOrUpdateModel
+}
+
+type ProductImportRowErrorModel struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ProductImportJobModel struct {
+	Id            string                       `json:"id"`
+	Format        string                       `json:"format"`
+	Status        string                       `json:"status"`
+	TotalRows     int                          `json:"total_rows"`
+	ProcessedRows int                          `json:"processed_rows"`
+	CreatedCount  int                          `json:"created_count"`
+	UpdatedCount  int                          `json:"updated_count"`
+	FailedCount   int                          `json:"failed_count"`
+	Errors        []ProductImportRowErrorModel `json:"errors"`
+	CreatedAt     string                       `json:"created_at"`
+	FinishedAt    string                       `json:"finished_at,omitempty"`
+}
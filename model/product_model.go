@@ -1,29 +1,57 @@
 package model
 
+import "github.com/tech-hive/ecommerce/money"
+
 type ProductModel struct {
-	Id          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Stock       int32   `json:"stock"`
-	ImageUrl    string  `json:"image_url"`
+	Id            string                        `json:"id"`
+	Sku           string                        `json:"sku"`
+	Type          string                        `json:"type"`
+	Name          string                        `json:"name"`
+	Description   string                        `json:"description"`
+	Category      string                        `json:"category"`
+	TaxClass      string                        `json:"tax_class"`
+	Price         money.Money                   `json:"price"`
+	Currency      string                        `json:"currency,omitempty"`       // set when the prices are converted to a display currency, KES otherwise
+	Weight        float64                       `json:"weight"`                   // kg
+	OriginalPrice *money.Money                  `json:"original_price,omitempty"` // regular price while on sale
+	SaleEndsAt    string                        `json:"sale_ends_at,omitempty"`
+	Stock         int32                         `json:"stock"`
+	ImageUrl      string                        `json:"image_url"`
+	RatingAverage float64                       `json:"rating_average"`
+	RatingCount   int32                         `json:"rating_count"`
+	ArchivedAt    string                        `json:"archived_at,omitempty"`
+	Attributes    []ProductAttributeModel       `json:"attributes,omitempty"`
+	Components    []ProductBundleComponentModel `json:"components,omitempty"`
 }
 
 type ProductCreateOrUpdateModel struct {
- 	Name        string  `json:"name" validate:"required"`
- 	Description string  `json:"description"`
- 	Price       float64 `json:"price" validate:"required,min=0"`
- 	Stock       int32   `json:"stock" validate:"required,min=0"`
- 	ImageUrl    string  `json:"image_url"`
+ 	Sku         string                        `json:"sku" validate:"max=64"`
+ 	Type        string                        `json:"type" validate:"omitempty,oneof=simple bundle"`
+ 	Name        string                        `json:"name" validate:"required"`
+ 	Description string                        `json:"description"`
+ 	Category    string                        `json:"category" validate:"max=100"`
+ 	TaxClass    string                        `json:"tax_class" validate:"max=20"` // standard when empty
+ 	Price       money.Money                   `json:"price" validate:"required,min=0"`
+ 	Weight      float64                       `json:"weight" validate:"min=0"`                            // kg, for shipping rates by weight
+ 	Stock       int32                         `json:"stock" validate:"required_unless=Type bundle,min=0"` // ignored for bundles
+ 	ImageUrl    string                        `json:"image_url"`
+ 	Attributes  map[string]interface{}        `json:"attributes,omitempty"`                 // by attribute code; omit on update to keep the current values
+ 	Components  []ProductBundleComponentModel `json:"components,omitempty" validate:"dive"` // bundles only; omit on update to keep the current ones
  }
 
+type ProductBundleComponentModel struct {
+	ProductId string `json:"product_id" validate:"required"`
+	Name      string `json:"name,omitempty"`
+	Quantity  int32  `json:"quantity" validate:"required,min=1"`
+}
+
 type ProductSearchModel struct {
- 	Name        string  `json:"name,omitempty"`
- 	MinPrice    float64 `json:"min_price,omitempty"`
- 	MaxPrice    float64 `json:"max_price,omitempty"`
- 	InStock     *bool   `json:"in_stock,omitempty"`
- 	Page        int     `json:"page,omitempty"`
- 	Limit       int     `json:"limit,omitempty"`
- 	SortBy      string  `json:"sort_by,omitempty"`      // name, price, created_at
- 	SortOrder   string  `json:"sort_order,omitempty"`   // asc, desc
+ 	Name           string      `json:"name,omitempty" query:"name"`
+ 	Category       string      `json:"category,omitempty" query:"category"`
+ 	MinPrice       money.Money `json:"min_price,omitempty" query:"min_price"`
+ 	MaxPrice       money.Money `json:"max_price,omitempty" query:"max_price"`
+ 	InStock        *bool       `json:"in_stock,omitempty" query:"in_stock"`
+ 	MinRating      float64     `json:"min_rating,omitempty" query:"min_rating"`
+ 	Attributes     []string    `json:"attributes,omitempty" query:"attr"` // code:value, code:value1|value2 or code:min..max
+ 	ListQueryModel             // page, limit, cursor, sort_by (name, price, stock, rating, created_at), sort_order
  }
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+type ProductPriceScheduleCreateModel struct {
+	Type     string      `json:"type" validate:"required,oneof=price sale"`
+	Price    money.Money `json:"price" validate:"required,gt=0"`
+	StartsAt time.Time   `json:"starts_at" validate:"required"`
+	EndsAt   *time.Time  `json:"ends_at" validate:"required_if=Type sale"`
+}
+
+type ProductPriceScheduleModel struct {
+	Id        uint        `json:"id"`
+	ProductId string      `json:"product_id"`
+	Type      string      `json:"type"`
+	Price     money.Money `json:"price"`
+	StartsAt  string      `json:"starts_at"`
+	EndsAt    string      `json:"ends_at,omitempty"`
+	Status    string      `json:"status"`
+	CreatedAt string      `json:"created_at"`
+}
+
+type ProductPriceHistoryModel struct {
+	Id         uint        `json:"id"`
+	ProductId  string      `json:"product_id"`
+	OldPrice   money.Money `json:"old_price"`
+	NewPrice   money.Money `json:"new_price"`
+	Reason     string      `json:"reason"`
+	ScheduleId *uint       `json:"schedule_id,omitempty"`
+	CreatedAt  string      `json:"created_at"`
+}
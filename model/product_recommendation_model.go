@@ -0,0 +1,6 @@
+package model
+
+type ProductRecommendationModel struct {
+	FrequentlyBoughtTogether []ProductModel `json:"frequently_bought_together"`
+	Related                  []ProductModel `json:"related"`
+}
@@ -0,0 +1,26 @@
+package model
+
+type ProductReviewModel struct {
+	Id           uint     `json:"id"`
+	ProductId    string   `json:"product_id"`
+	UserId       uint     `json:"user_id"`
+	ReviewerName string   `json:"reviewer_name"`
+	OrderId      uint     `json:"order_id"`
+	Rating       int32    `json:"rating"`
+	Title        string   `json:"title"`
+	Body         string   `json:"body"`
+	Images       []string `json:"images"`
+	Status       string   `json:"status"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+type ProductReviewCreateModel struct {
+	Rating int32    `json:"rating" validate:"required,min=1,max=5"`
+	Title  string   `json:"title" validate:"required,max=150"`
+	Body   string   `json:"body" validate:"max=5000"`
+	Images []string `json:"images" validate:"max=5,dive,url"`
+}
+
+type ProductReviewModerationModel struct {
+	Status string `json:"status" validate:"required,oneof=approved hidden"`
+}
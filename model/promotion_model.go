@@ -0,0 +1,73 @@
+package model
+
+import (
+	"github.com/tech-hive/ecommerce/money"
+	"time"
+)
+
+type PromotionCreateOrUpdateModel struct {
+	Code              string      `json:"code" validate:"omitempty,max=50"` // empty for an automatic promotion
+	Name              string      `json:"name" validate:"required,max=255"`
+	Type              string      `json:"type" validate:"required,oneof=percentage fixed free_shipping buy_x_get_y"`
+	Value             float64     `json:"value" validate:"min=0"`  // percent off, percentage and buy_x_get_y only
+	Amount            money.Money `json:"amount" validate:"min=0"` // amount off, fixed only
+	MinSpend          money.Money `json:"min_spend" validate:"min=0"`
+	Scope             string      `json:"scope" validate:"omitempty,oneof=order product category"`
+	ProductId         string      `json:"product_id" validate:"required_if=Scope product"`
+	Category          string      `json:"category" validate:"required_if=Scope category"`
+	BuyQuantity       int32       `json:"buy_quantity" validate:"required_if=Type buy_x_get_y,min=0"`
+	GetQuantity       int32       `json:"get_quantity" validate:"required_if=Type buy_x_get_y,min=0"`
+	UsageLimit        *int32      `json:"usage_limit" validate:"omitempty,min=1"`
+	UsageLimitPerUser *int32      `json:"usage_limit_per_user" validate:"omitempty,min=1"`
+	Stackable         bool        `json:"stackable"`
+	Active            *bool       `json:"active"`
+	StartsAt          *time.Time  `json:"starts_at"`
+	EndsAt            *time.Time  `json:"ends_at"`
+}
+
+type PromotionModel struct {
+	Id                uint         `json:"id"`
+	Code              string       `json:"code,omitempty"`
+	Automatic         bool         `json:"automatic"`
+	Name              string       `json:"name"`
+	Type              string       `json:"type"`
+	Value             float64      `json:"value,omitempty"`
+	Amount            *money.Money `json:"amount,omitempty"`
+	MinSpend          money.Money  `json:"min_spend"`
+	Scope             string       `json:"scope"`
+	ProductId         string       `json:"product_id,omitempty"`
+	Category          string       `json:"category,omitempty"`
+	UserId            *uint        `json:"user_id,omitempty"` // the only customer who may redeem it
+	BuyQuantity       int32        `json:"buy_quantity,omitempty"`
+	GetQuantity       int32        `json:"get_quantity,omitempty"`
+	UsageLimit        *int32       `json:"usage_limit,omitempty"`
+	UsageLimitPerUser *int32       `json:"usage_limit_per_user,omitempty"`
+	UsageCount        int32        `json:"usage_count"`
+	Stackable         bool         `json:"stackable"`
+	Active            bool         `json:"active"`
+	StartsAt          string       `json:"starts_at,omitempty"`
+	EndsAt            string       `json:"ends_at,omitempty"`
+	CreatedAt         string       `json:"created_at"`
+	UpdatedAt         string       `json:"updated_at"`
+}
+
+// DiscountModel is a discount line of a cart, or the snapshot of one on an order.
+type DiscountModel struct {
+	PromotionId *uint       `json:"promotion_id,omitempty"`
+	Code        string      `json:"code,omitempty"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Amount      money.Money `json:"amount"`
+}
+
+// CartCouponModel is a coupon applied to a cart; Reason tells why a coupon is not discounting the cart.
+type CartCouponModel struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type ApplyCouponModel struct {
+	Code string `json:"code" validate:"required,max=50"`
+}
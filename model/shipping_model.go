@@ -0,0 +1,91 @@
+package model
+
+import "github.com/tech-hive/ecommerce/money"
+
+type ShippingZoneCreateOrUpdateModel struct {
+	Name      string                  `json:"name" validate:"required,max=100"`
+	Locations []ShippingLocationModel `json:"locations" validate:"required,min=1,dive"`
+}
+
+type ShippingLocationModel struct {
+	County string `json:"county" validate:"required,max=100"`
+	Town   string `json:"town,omitempty" validate:"max=100"` // the rest of the county when empty
+}
+
+type ShippingZoneModel struct {
+	Id        uint                    `json:"id"`
+	Name      string                  `json:"name"`
+	Locations []ShippingLocationModel `json:"locations"`
+	Methods   []ShippingMethodModel   `json:"methods"`
+	CreatedAt string                  `json:"created_at"`
+	UpdatedAt string                  `json:"updated_at"`
+}
+
+type ShippingMethodCreateOrUpdateModel struct {
+	Name     string              `json:"name" validate:"required,max=100"`
+	Type     string              `json:"type" validate:"required,oneof=standard express pickup_station"`
+	RateType string              `json:"rate_type" validate:"required,oneof=flat weight order_value"`
+	FlatFee  money.Money         `json:"flat_fee" validate:"min=0"`                           // flat rate only
+	Rates    []ShippingRateModel `json:"rates" validate:"required_unless=RateType flat,dive"` // weight (kg) or order value brackets
+	MinDays  int32               `json:"min_days" validate:"min=0"`
+	MaxDays  int32               `json:"max_days" validate:"min=0,gtefield=MinDays"`
+	Active   *bool               `json:"active"`
+}
+
+// ShippingRateModel is a bracket of a weight method, with weight bounds in kg, or of an order value
+// method, with order value bounds. The max is excluded; there is no upper bound when it is omitted.
+type ShippingRateModel struct {
+	MinWeight     float64      `json:"min_weight,omitempty" validate:"min=0"`
+	MaxWeight     *float64     `json:"max_weight,omitempty"`
+	MinOrderValue *money.Money `json:"min_order_value,omitempty" validate:"omitempty,min=0"`
+	MaxOrderValue *money.Money `json:"max_order_value,omitempty"`
+	Fee           money.Money  `json:"fee" validate:"min=0"`
+}
+
+type ShippingMethodModel struct {
+	Id        uint                `json:"id"`
+	ZoneId    uint                `json:"zone_id"`
+	Name      string              `json:"name"`
+	Type      string              `json:"type"`
+	RateType  string              `json:"rate_type"`
+	FlatFee   money.Money         `json:"flat_fee"`
+	Rates     []ShippingRateModel `json:"rates"`
+	MinDays   int32               `json:"min_days"`
+	MaxDays   int32               `json:"max_days"`
+	Active    bool                `json:"active"`
+	CreatedAt string              `json:"created_at"`
+	UpdatedAt string              `json:"updated_at"`
+}
+
+// ShippingQuoteModel lists what each shipping method of the zone of an address costs for a cart.
+type ShippingQuoteModel struct {
+	Zone       string                `json:"zone"`
+	County     string                `json:"county"`
+	Town       string                `json:"town,omitempty"`
+	Weight     float64               `json:"weight"`      // kg
+	OrderValue money.Money           `json:"order_value"` // subtotal after discounts
+	Options    []ShippingOptionModel `json:"options"`
+	Currency   string                `json:"currency"`
+}
+
+type ShippingOptionModel struct {
+	MethodId     uint         `json:"method_id"`
+	Name         string       `json:"name"`
+	Type         string       `json:"type"`
+	Cost         money.Money  `json:"cost"`
+	OriginalCost *money.Money `json:"original_cost,omitempty"` // before a free shipping promotion
+	FreeShipping bool         `json:"free_shipping"`
+	MinDays      int32        `json:"min_days"`
+	MaxDays      int32        `json:"max_days"`
+}
+
+// OrderShippingModel is the shipping chosen at checkout, as it was charged.
+type OrderShippingModel struct {
+	MethodId *uint       `json:"method_id,omitempty"` // omitted once the method is deleted
+	Method   string      `json:"method"`
+	Type     string      `json:"type"`
+	Cost     money.Money `json:"cost"`
+	Address  string      `json:"address"`
+	County   string      `json:"county"`
+	Town     string      `json:"town,omitempty"`
+}
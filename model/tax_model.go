@@ -0,0 +1,28 @@
+package model
+
+import "github.com/tech-hive/ecommerce/money"
+
+type TaxClassModel struct {
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Rate      float64 `json:"rate"`
+	Exempt    bool    `json:"exempt"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type TaxClassCreateOrUpdateModel struct {
+	Code   string  `json:"code" validate:"omitempty,max=20"` // on create only
+	Name   string  `json:"name" validate:"required,max=100"`
+	Rate   float64 `json:"rate" validate:"min=0,max=100"`
+	Exempt bool    `json:"exempt"`
+}
+
+// TaxBreakdownModel sums the lines of one tax class of a cart or order.
+type TaxBreakdownModel struct {
+	TaxClass  string      `json:"tax_class"`
+	Name      string      `json:"name"`
+	Rate      float64     `json:"rate"`
+	Exempt    bool        `json:"exempt"`
+	NetAmount money.Money `json:"net_amount"` // after discounts, excluding tax
+	TaxAmount money.Money `json:"tax_amount"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "github.com/tech-hive/ecommerce/money"
+
+type WishlistItemModel struct {
+	Id                uint         `json:"id"`
+	ProductId         string       `json:"product_id"`
+	Product           ProductModel `json:"product"`
+	NotifyPriceDrop   bool         `json:"notify_price_drop"`
+	NotifyBackInStock bool         `json:"notify_back_in_stock"`
+	CreatedAt         string       `json:"created_at"`
+}
+
+// WishlistItemCreateModel adds a product to the wishlist, or changes its alerts if it is already
+// there. Alerts default to on.
+type WishlistItemCreateModel struct {
+	ProductId         string `json:"product_id" validate:"required"`
+	NotifyPriceDrop   *bool  `json:"notify_price_drop"`
+	NotifyBackInStock *bool  `json:"notify_back_in_stock"`
+}
+
+// ProductAlertModel is what notifiers receive for a price drop or back-in-stock alert.
+type ProductAlertModel struct {
+	Id          uint         `json:"id"`
+	Type        string       `json:"type"`
+	UserId      uint         `json:"user_id"`
+	Email       string       `json:"email"`
+	Name        string       `json:"name"`
+	ProductId   string       `json:"product_id"`
+	ProductName string       `json:"product_name"`
+	OldPrice    *money.Money `json:"old_price,omitempty"`
+	NewPrice    money.Money  `json:"new_price"`
+	Stock       int32        `json:"stock"`
+	CreatedAt   string       `json:"created_at"`
+}
@@ -0,0 +1,318 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BaseCurrency is the currency prices, carts and orders are kept in, and the one M-Pesa pays in.
+const BaseCurrency = "KES"
+
+// Money is an amount of a currency in its minor units, cents, the hundredths of the currency; whole
+// unit currencies such as UGX have their cents rounded off. Amounts are added and multiplied
+// exactly; dividing them, for percentages and shares, rounds half away from zero to the cent.
+//
+// Amounts of different currencies do not mix: adding, subtracting or comparing them panics, and an
+// amount only changes currency through Convert. The zero Money is nothing in BaseCurrency.
+//
+// Money reads and writes DECIMAL(…,2) columns, which hold BaseCurrency amounts only, and JSON numbers
+// with two decimals, which models send next to the code of their currency.
+type Money struct {
+	cents    int64
+	currency string // empty for BaseCurrency, so that equal amounts in it compare equal
+}
+
+// New is units and cents of BaseCurrency, e.g. New(12, 50) for KES 12.50.
+func New(units int64, cents int64) Money {
+	return Money{cents: units*100 + cents}
+}
+
+// Parse reads a decimal amount of BaseCurrency such as "1250", "12.5" or "-0.75". Digits after the
+// cents round half away from zero.
+func Parse(text string) (Money, error) {
+	text = strings.TrimSpace(text)
+	negative := strings.HasPrefix(text, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+
+	units, fraction, _ := strings.Cut(digits, ".")
+	if units == "" && fraction == "" || !isDigits(units) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("invalid amount %q", text)
+	}
+	fraction += "000"
+	var cents int64
+	if units != "" {
+		value, err := strconv.ParseInt(units, 10, 64)
+		if err != nil || value > math.MaxInt64/100-1 {
+			return Money{}, fmt.Errorf("invalid amount %q", text)
+		}
+		cents = value * 100
+	}
+	cents += int64(fraction[0]-'0')*10 + int64(fraction[1]-'0')
+	if fraction[2] >= '5' {
+		cents++
+	}
+	if negative {
+		cents = -cents
+	}
+	return Money{cents: cents}, nil
+}
+
+func isDigits(text string) bool {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Currency is the ISO 4217 code of the currency of the amount.
+func (amount Money) Currency() string {
+	if amount.currency == "" {
+		return BaseCurrency
+	}
+	return amount.currency
+}
+
+// Cents is the amount in the minor units of its currency.
+func (amount Money) Cents() int64 {
+	return amount.cents
+}
+
+// Units is the amount in whole currency units, rounding any cents up so the amount is covered.
+func (amount Money) Units() int64 {
+	units := amount.cents / 100
+	if amount.cents%100 > 0 {
+		units++
+	}
+	return units
+}
+
+func (amount Money) IsZero() bool {
+	return amount.cents == 0
+}
+
+func (amount Money) IsPositive() bool {
+	return amount.cents > 0
+}
+
+func (amount Money) IsNegative() bool {
+	return amount.cents < 0
+}
+
+func (amount Money) Add(other Money) Money {
+	amount.mustMatch(other)
+	return Money{cents: amount.cents + other.cents, currency: amount.currency}
+}
+
+func (amount Money) Sub(other Money) Money {
+	amount.mustMatch(other)
+	return Money{cents: amount.cents - other.cents, currency: amount.currency}
+}
+
+// Cmp compares the amount with another of its currency: -1 when it is less, 0 when they are equal
+// and +1 when it is more.
+func (amount Money) Cmp(other Money) int {
+	amount.mustMatch(other)
+	switch {
+	case amount.cents < other.cents:
+		return -1
+	case amount.cents > other.cents:
+		return 1
+	}
+	return 0
+}
+
+func (amount Money) LessThan(other Money) bool {
+	return amount.Cmp(other) < 0
+}
+
+func (amount Money) GreaterThan(other Money) bool {
+	return amount.Cmp(other) > 0
+}
+
+func (amount Money) Mul(quantity int32) Money {
+	return Money{cents: amount.cents * int64(quantity), currency: amount.currency}
+}
+
+// Percent is rate percent of the amount, e.g. the 16% VAT charged on a net amount. Rates have up to
+// two decimals.
+func (amount Money) Percent(rate float64) Money {
+	return Money{cents: divRound(amount.cents*basisPoints(rate), 10000), currency: amount.currency}
+}
+
+// PercentIncluded is the part of a gross amount that is rate percent on top of its net amount, e.g.
+// the VAT included in a VAT-inclusive price.
+func (amount Money) PercentIncluded(rate float64) Money {
+	points := basisPoints(rate)
+	return Money{cents: divRound(amount.cents*points, 10000+points), currency: amount.currency}
+}
+
+// Share is the part of the amount that weight is of total, e.g. the share of a discount that falls on
+// one line of a cart.
+func (amount Money) Share(weight Money, total Money) Money {
+	weight.mustMatch(total)
+	if total.cents == 0 {
+		return Money{currency: amount.currency}
+	}
+	return Money{cents: divRound(amount.cents*weight.cents, total.cents), currency: amount.currency}
+}
+
+// Allocate splits the amount over weights in proportion to them. The shares add up to the amount
+// exactly: the last non-zero weight takes what rounding leaves.
+func (amount Money) Allocate(weights []Money) []Money {
+	shares := make([]Money, len(weights))
+	var total Money
+	if len(weights) > 0 {
+		total.currency = weights[0].currency
+	}
+	last := -1
+	for i, weight := range weights {
+		total = total.Add(weight)
+		if !weight.IsZero() {
+			last = i
+		}
+	}
+	for i := range shares {
+		shares[i] = Money{currency: amount.currency}
+	}
+	if last < 0 {
+		return shares
+	}
+	remaining := amount
+	for i, weight := range weights {
+		if i == last {
+			shares[i] = remaining
+			break
+		}
+		shares[i] = amount.Share(weight, total)
+		remaining = remaining.Sub(shares[i])
+	}
+	return shares
+}
+
+// Convert is the amount in another currency, at rate units of that currency per unit of this one,
+// rounded to the decimals the other currency is quoted in, e.g. 0 for whole Uganda shillings. Rates
+// have up to six decimals.
+func (amount Money) Convert(currency string, rate float64, decimals int) Money {
+	converted := Money{cents: divRound(amount.cents*int64(math.Round(rate*1000000)), 1000000)}
+	if currency != BaseCurrency {
+		converted.currency = currency
+	}
+	if decimals < 2 {
+		step := int64(math.Pow10(2 - decimals))
+		converted.cents = divRound(converted.cents, step) * step
+	}
+	return converted
+}
+
+func Min(a Money, b Money) Money {
+	if a.LessThan(b) {
+		return a
+	}
+	return b
+}
+
+func Max(a Money, b Money) Money {
+	if a.GreaterThan(b) {
+		return a
+	}
+	return b
+}
+
+func (amount Money) mustMatch(other Money) {
+	if amount.currency != other.currency {
+		panic(fmt.Sprintf("money: %s and %s amounts do not mix", amount.Currency(), other.Currency()))
+	}
+}
+
+// String formats the amount with two decimals, e.g. "1250.00".
+func (amount Money) String() string {
+	cents := amount.cents
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func (amount Money) MarshalJSON() ([]byte, error) {
+	return []byte(amount.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number, or a string holding one, as an amount of BaseCurrency.
+func (amount *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	parsed, err := Parse(strings.Trim(text, `"`))
+	if err != nil {
+		return err
+	}
+	*amount = parsed
+	return nil
+}
+
+func (amount Money) MarshalText() ([]byte, error) {
+	return []byte(amount.String()), nil
+}
+
+// UnmarshalText reads amounts of BaseCurrency of query strings and forms.
+func (amount *Money) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*amount = parsed
+	return nil
+}
+
+// Value writes the amount as a decimal, for DECIMAL columns. Only BaseCurrency amounts are stored.
+func (amount Money) Value() (driver.Value, error) {
+	if amount.currency != "" {
+		return nil, errors.New("cannot store an amount of " + amount.currency + ", amounts are stored in " + BaseCurrency)
+	}
+	return amount.String(), nil
+}
+
+// Scan reads DECIMAL columns, which the MySQL driver returns as text, and numeric expressions, as
+// amounts of BaseCurrency.
+func (amount *Money) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*amount = Money{}
+	case []byte:
+		return amount.UnmarshalText(value)
+	case string:
+		return amount.UnmarshalText([]byte(value))
+	case int64:
+		*amount = Money{cents: value * 100}
+	case float64:
+		*amount = Money{cents: int64(math.Round(value * 100))}
+	default:
+		return errors.New(fmt.Sprint("cannot scan money from ", value))
+	}
+	return nil
+}
+
+// basisPoints is a percentage rate in hundredths of a percent.
+func basisPoints(rate float64) int64 {
+	return int64(math.Round(rate * 100))
+}
+
+// divRound divides rounding half away from zero.
+func divRound(numerator int64, denominator int64) int64 {
+	if denominator < 0 {
+		numerator, denominator = -numerator, -denominator
+	}
+	if numerator < 0 {
+		return -((-numerator + denominator/2) / denominator)
+	}
+	return (numerator + denominator/2) / denominator
+}
@@ -0,0 +1,103 @@
+package money
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]Money{
+		"1250":     New(1250, 0),
+		"12.5":     New(12, 50),
+		"0.07":     New(0, 7),
+		".5":       New(0, 50),
+		"-0.75":    New(0, -75),
+		"19.999":   New(20, 0),
+		"0.125":    New(0, 13),
+		"0.124999": New(0, 12),
+	}
+	for text, want := range cases {
+		got, err := Parse(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, want, got, text)
+	}
+
+	for _, text := range []string{"", ".", "12,50", "1e3", "abc", "1.2.3"} {
+		_, err := Parse(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var payload struct {
+		Price Money  `json:"price"`
+		Total *Money `json:"total,omitempty"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"price": 0.1}`), &payload))
+	assert.Equal(t, New(0, 10), payload.Price)
+	assert.Equal(t, BaseCurrency, payload.Price.Currency())
+
+	payload.Price = payload.Price.Mul(3)
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"price":0.30}`, string(data))
+}
+
+func TestMoney_Percent(t *testing.T) {
+	assert.Equal(t, New(16, 0), New(100, 0).Percent(16))
+	assert.Equal(t, New(0, 2), New(0, 13).Percent(16))          // 2.08 cents
+	assert.Equal(t, New(1, 38), New(10, 0).PercentIncluded(16)) // 10 * 16/116 = 1.379
+	assert.Equal(t, Money{}, New(10, 0).PercentIncluded(0))
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	shares := New(10, 0).Allocate([]Money{New(1, 0), New(1, 0), New(1, 0)})
+	assert.Equal(t, []Money{New(3, 33), New(3, 33), New(3, 34)}, shares)
+
+	shares = New(5, 0).Allocate([]Money{New(3, 0), {}})
+	assert.Equal(t, []Money{New(5, 0), {}}, shares)
+
+	assert.Equal(t, []Money{{}, {}}, New(5, 0).Allocate([]Money{{}, {}}))
+}
+
+func TestMoney_Convert(t *testing.T) {
+	ugx := New(1000, 0).Convert("UGX", 28.75, 0)
+	assert.Equal(t, "UGX", ugx.Currency())
+	assert.Equal(t, int64(2875000), ugx.Cents())
+	assert.Equal(t, int64(290100), New(99, 99).Convert("UGX", 29.015, 0).Cents()) // 2901.21
+
+	usd := New(99, 99).Convert("USD", 0.0123, 2)
+	assert.Equal(t, "USD", usd.Currency())
+	assert.Equal(t, int64(123), usd.Cents())
+
+	assert.Equal(t, New(99, 99), New(99, 99).Convert(BaseCurrency, 1, 2))
+}
+
+func TestMoney_CurrenciesDoNotMix(t *testing.T) {
+	usd := New(10, 0).Convert("USD", 0.0077, 2)
+	assert.Equal(t, int64(16), usd.Add(usd).Cents())
+	assert.PanicsWithValue(t, "money: USD and KES amounts do not mix", func() { usd.Add(New(10, 0)) })
+	assert.Panics(t, func() { New(10, 0).LessThan(usd) })
+}
+
+func TestMoney_Units(t *testing.T) {
+	assert.Equal(t, int64(1250), New(1250, 0).Units())
+	assert.Equal(t, int64(1251), New(1250, 1).Units())
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var amount Money
+	assert.NoError(t, amount.Scan([]byte("1999.99")))
+	assert.Equal(t, New(1999, 99), amount)
+	assert.NoError(t, amount.Scan(int64(3)))
+	assert.Equal(t, New(3, 0), amount)
+
+	value, err := New(1999, 9).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1999.09", value)
+
+	// Columns hold KES only
+	_, err = New(1999, 9).Convert("USD", 0.0077, 2).Value()
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package pdf
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	document := New("Invoice INV-000001")
+	document.AddPage()
+	document.Text(40, 60, Bold, 18, "Invoice (copy)")
+	document.Line(40, 70, 555, 70, 0.5)
+	document.AddPage()
+	document.TextRight(555, 60, Regular, 10, "KES 1,250.00")
+	data := document.Bytes()
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Contains(t, string(data), "/Count 2")
+	assert.Contains(t, string(data), `(Invoice \(copy\)) Tj`)
+	assert.Contains(t, string(data), "40 781.89 Td")
+
+	// startxref points at the cross-reference table, whose entries point at their objects
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	assert.True(t, bytes.HasPrefix(data[offset:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(data, -1)
+	assert.Len(t, entries, 9)
+	for i, entry := range entries {
+		objectOffset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(data[objectOffset:], []byte(strconv.Itoa(i+1)+" 0 obj")), "object %d", i+1)
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.InDelta(t, 9.44, TextWidth("Hi", Regular, 10), 0.001)
+	assert.InDelta(t, 10, TextWidth("Hi", Bold, 10), 0.001)
+	assert.Equal(t, "Wireless headp...", Truncate("Wireless headphones", Regular, 10, 80))
+}
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, []byte("caf\xe9 \x96 ?"), encode("café – 中"))
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+)
+
+type AttributeRepository interface {
+	InsertDefinition(ctx context.Context, definition entity.AttributeDefinition) (entity.AttributeDefinition, error)
+	FindDefinitionById(ctx context.Context, id uint) (entity.AttributeDefinition, error)
+	FindDefinitions(ctx context.Context) ([]entity.AttributeDefinition, error)
+	FindCategoryAttributes(ctx context.Context, category string) ([]entity.CategoryAttribute, error)
+	SaveCategoryAttribute(ctx context.Context, categoryAttribute entity.CategoryAttribute) error
+	DeleteCategoryAttribute(ctx context.Context, category string, attributeId uint) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"time"
+)
+
+type CartReminderRepository interface {
+	FindAbandonedCarts(ctx context.Context, idleSince time.Time, afterId uint, limit int) ([]entity.Cart, error)
+	FindLatestByCartIds(ctx context.Context, cartIds []uint) (map[uint]entity.CartReminder, error)
+	Insert(ctx context.Context, reminder entity.CartReminder, coupon *entity.Promotion) (entity.CartReminder, error)
+	FindPending(ctx context.Context, afterId uint, limit int) ([]entity.CartReminder, error)
+	MarkSent(ctx context.Context, id uint, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, reason string, maxAttempts int32) error
+	MarkCancelled(ctx context.Context, id uint, reason string) error
+	MarkRecovered(ctx context.Context, cartId uint, orderId uint, sentSince time.Time, recoveredAt time.Time) error
+	Report(ctx context.Context, from time.Time, to time.Time, idleSince time.Time) (model.CartRecoveryReportModel, error)
+}
@@ -3,14 +3,27 @@ package repository
 import (
 	"context"
 	"github.com/tech-hive/ecommerce/entity"
+	"time"
 )
 
 type CartRepository interface {
 	GetCartByUserId(ctx context.Context, userId uint) (entity.Cart, error)
+	GetCartById(ctx context.Context, cartId uint) (entity.Cart, error)
+	FindCartsByUserId(ctx context.Context, userId uint) ([]entity.Cart, error)
+	GetOrCreateSavedList(ctx context.Context, userId uint) (entity.Cart, error)
+	UpdateCartName(ctx context.Context, cartId uint, name string) error
+	DeleteCart(ctx context.Context, cartId uint) error
+	MoveCartItem(ctx context.Context, cartItem entity.CartItem, cartId uint, stock int32) (int32, bool, error)
 	CreateCart(ctx context.Context, cart entity.Cart) (entity.Cart, error)
 	GetOrCreateCart(ctx context.Context, userId uint) (entity.Cart, error)
+	GetCartByGuestId(ctx context.Context, guestId string) (entity.Cart, error)
+	GetOrCreateGuestCart(ctx context.Context, guestId string) (entity.Cart, error)
+	MergeCart(ctx context.Context, guestCartId uint, items []entity.CartItem) error
+	SaveCartItems(ctx context.Context, items []entity.CartItem) error
+	ReviseCart(ctx context.Context, items []entity.CartItem, removedItemIds []uint) error
 	AddItemToCart(ctx context.Context, cartItem entity.CartItem) (entity.CartItem, error)
 	UpdateCartItem(ctx context.Context, cartItemId uint, quantity int32) (entity.CartItem, error)
 	RemoveCartItem(ctx context.Context, cartItemId uint) error
 	ClearCart(ctx context.Context, cartId uint) error
+	TouchCart(ctx context.Context, cartId uint, at time.Time) error
 }
\ No newline at end of file
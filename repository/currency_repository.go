@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+)
+
+type CurrencyRepository interface {
+	Insert(ctx context.Context, currency entity.Currency) (entity.Currency, error)
+	Update(ctx context.Context, currency entity.Currency) (entity.Currency, error)
+	FindByCode(ctx context.Context, code string) (entity.Currency, error)
+	FindAll(ctx context.Context) ([]entity.Currency, error)
+}
@@ -0,0 +1,109 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/common"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+)
+
+const maxAttributeFilters = 10
+
+// attributeFilter is one parsed attribute filter: either a set of accepted values or a
+// numeric range with optional bounds.
+type attributeFilter struct {
+	code   string
+	values []string
+	min    *float64
+	max    *float64
+}
+
+// parseAttributeFilter reads code:value, code:value1|value2, or code:min..max where either
+// bound may be left out.
+func parseAttributeFilter(raw string) (attributeFilter, error) {
+	invalid := common.NewValidationError("attr", "this field is code:value, code:value1|value2 or code:min..max, got "+raw)
+
+	code, value, found := strings.Cut(raw, ":")
+	code = strings.TrimSpace(code)
+	value = strings.TrimSpace(value)
+	if !found || code == "" || value == "" {
+		return attributeFilter{}, invalid
+	}
+
+	filter := attributeFilter{code: code}
+	if lower, upper, isRange := strings.Cut(value, ".."); isRange {
+		for _, bound := range []struct {
+			text   string
+			target **float64
+		}{{lower, &filter.min}, {upper, &filter.max}} {
+			text := strings.TrimSpace(bound.text)
+			if text == "" {
+				continue
+			}
+			number, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return attributeFilter{}, invalid
+			}
+			*bound.target = &number
+		}
+		if filter.min == nil && filter.max == nil {
+			return attributeFilter{}, invalid
+		}
+		return filter, nil
+	}
+
+	for _, option := range strings.Split(value, "|") {
+		if option = strings.TrimSpace(option); option != "" {
+			filter.values = append(filter.values, option)
+		}
+	}
+	if len(filter.values) == 0 {
+		return attributeFilter{}, invalid
+	}
+	return filter, nil
+}
+
+// applyAttributeFilters narrows a product query to products whose attribute values match
+// every filter. Values are compared in canonical text form, and numerically when they parse.
+func applyAttributeFilters(query *gorm.DB, rawFilters []string) (*gorm.DB, error) {
+	if len(rawFilters) > maxAttributeFilters {
+		return nil, common.NewValidationError("attr", "this field is max="+strconv.Itoa(maxAttributeFilters))
+	}
+
+	for _, raw := range rawFilters {
+		filter, err := parseAttributeFilter(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		condition := "d.code = ?"
+		args := []interface{}{filter.code}
+		if filter.values != nil {
+			numbers := make([]float64, 0, len(filter.values))
+			for _, value := range filter.values {
+				if number, err := strconv.ParseFloat(value, 64); err == nil {
+					numbers = append(numbers, number)
+				}
+			}
+			if len(numbers) == len(filter.values) {
+				condition += " AND (v.value_text IN ? OR v.value_number IN ?)"
+				args = append(args, filter.values, numbers)
+			} else {
+				condition += " AND v.value_text IN ?"
+				args = append(args, filter.values)
+			}
+		}
+		if filter.min != nil {
+			condition += " AND v.value_number >= ?"
+			args = append(args, *filter.min)
+		}
+		if filter.max != nil {
+			condition += " AND v.value_number <= ?"
+			args = append(args, *filter.max)
+		}
+
+		query = query.Where("product_id IN (SELECT v.product_id FROM tb_product_attribute_value v "+
+			"JOIN tb_attribute_definition d ON d.id = v.attribute_id WHERE "+condition+")", args...)
+	}
+	return query, nil
+}
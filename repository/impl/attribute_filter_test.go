@@ -0,0 +1,34 @@
+package impl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseAttributeFilter_Values(t *testing.T) {
+	filter, err := parseAttributeFilter("color: black | silver ")
+	assert.NoError(t, err)
+	assert.Equal(t, "color", filter.code)
+	assert.Equal(t, []string{"black", "silver"}, filter.values)
+	assert.Nil(t, filter.min)
+	assert.Nil(t, filter.max)
+}
+
+func TestParseAttributeFilter_Range(t *testing.T) {
+	filter, err := parseAttributeFilter("screen_size:13..15.6")
+	assert.NoError(t, err)
+	assert.Equal(t, 13.0, *filter.min)
+	assert.Equal(t, 15.6, *filter.max)
+
+	filter, err = parseAttributeFilter("ram:16..")
+	assert.NoError(t, err)
+	assert.Equal(t, 16.0, *filter.min)
+	assert.Nil(t, filter.max)
+}
+
+func TestParseAttributeFilter_Invalid(t *testing.T) {
+	for _, raw := range []string{"ram", "ram:", ":16", "ram:..", "ram:a..b", "ram:|"} {
+		_, err := parseAttributeFilter(raw)
+		assert.Error(t, err, raw)
+	}
+}
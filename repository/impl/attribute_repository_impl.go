@@ -0,0 +1,74 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewAttributeRepositoryImpl(DB *gorm.DB) repository.AttributeRepository {
+	return &attributeRepositoryImpl{DB: DB}
+}
+
+type attributeRepositoryImpl struct {
+	*gorm.DB
+}
+
+func (attributeRepository *attributeRepositoryImpl) InsertDefinition(ctx context.Context, definition entity.AttributeDefinition) (entity.AttributeDefinition, error) {
+	result := attributeRepository.DB.WithContext(ctx).Create(&definition)
+	if result.Error != nil {
+		return entity.AttributeDefinition{}, result.Error
+	}
+	return definition, nil
+}
+
+func (attributeRepository *attributeRepositoryImpl) FindDefinitionById(ctx context.Context, id uint) (entity.AttributeDefinition, error) {
+	var definition entity.AttributeDefinition
+	result := attributeRepository.DB.WithContext(ctx).Where("id = ?", id).First(&definition)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.AttributeDefinition{}, errors.New("attribute not found")
+		}
+		return entity.AttributeDefinition{}, result.Error
+	}
+	return definition, nil
+}
+
+func (attributeRepository *attributeRepositoryImpl) FindDefinitions(ctx context.Context) ([]entity.AttributeDefinition, error) {
+	var definitions []entity.AttributeDefinition
+	err := attributeRepository.DB.WithContext(ctx).Order("name").Find(&definitions).Error
+	return definitions, err
+}
+
+func (attributeRepository *attributeRepositoryImpl) FindCategoryAttributes(ctx context.Context, category string) ([]entity.CategoryAttribute, error) {
+	var categoryAttributes []entity.CategoryAttribute
+	err := attributeRepository.DB.WithContext(ctx).
+		Preload("Attribute").
+		Where("category = ?", category).
+		Find(&categoryAttributes).Error
+	return categoryAttributes, err
+}
+
+// SaveCategoryAttribute attaches the attribute to the category, or updates whether it is required.
+func (attributeRepository *attributeRepositoryImpl) SaveCategoryAttribute(ctx context.Context, categoryAttribute entity.CategoryAttribute) error {
+	return attributeRepository.DB.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"required"})}).
+		Create(&categoryAttribute).Error
+}
+
+func (attributeRepository *attributeRepositoryImpl) DeleteCategoryAttribute(ctx context.Context, category string, attributeId uint) error {
+	result := attributeRepository.DB.WithContext(ctx).
+		Where("category = ? AND attribute_id = ?", category, attributeId).
+		Delete(&entity.CategoryAttribute{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("attribute is not attached to category " + category)
+	}
+	return nil
+}
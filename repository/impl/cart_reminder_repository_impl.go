@@ -0,0 +1,236 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func NewCartReminderRepositoryImpl(DB *gorm.DB) repository.CartReminderRepository {
+	return &cartReminderRepositoryImpl{DB: DB}
+}
+
+type cartReminderRepositoryImpl struct {
+	*gorm.DB
+}
+
+// FindAbandonedCarts returns the customer carts with items that have not changed since idleSince.
+// Guest carts are left out, as there is no one to remind.
+func (reminderRepository *cartReminderRepositoryImpl) FindAbandonedCarts(ctx context.Context, idleSince time.Time, afterId uint, limit int) ([]entity.Cart, error) {
+	var carts []entity.Cart
+	err := reminderRepository.DB.WithContext(ctx).
+		Preload("CartItems").
+		Where("user_id IS NOT NULL AND type = ? AND last_activity_at <= ? AND id > ?", "cart", idleSince, afterId).
+		Where("EXISTS (SELECT 1 FROM tb_cart_item WHERE tb_cart_item.cart_id = tb_cart.id)").
+		Order("id").
+		Limit(limit).
+		Find(&carts).Error
+	return carts, err
+}
+
+// FindLatestByCartIds returns the last reminder created for each of the carts that had one.
+func (reminderRepository *cartReminderRepositoryImpl) FindLatestByCartIds(ctx context.Context, cartIds []uint) (map[uint]entity.CartReminder, error) {
+	latest := map[uint]entity.CartReminder{}
+	if len(cartIds) == 0 {
+		return latest, nil
+	}
+
+	var reminders []entity.CartReminder
+	err := reminderRepository.DB.WithContext(ctx).
+		Where("cart_id IN ?", cartIds).
+		Order("id").
+		Find(&reminders).Error
+	for _, reminder := range reminders {
+		latest[*reminder.CartId] = reminder
+	}
+	return latest, err
+}
+
+// Insert queues a reminder, with the coupon it offers if coupon is not nil, in one transaction. A
+// reminder the cart already has for the same stage and activity is left as it is, so overlapping
+// runs queue it once and do not leave an unsent coupon behind.
+func (reminderRepository *cartReminderRepositoryImpl) Insert(ctx context.Context, reminder entity.CartReminder, coupon *entity.Promotion) (entity.CartReminder, error) {
+	err := reminderRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if coupon != nil {
+			if err := tx.Create(coupon).Error; err != nil {
+				return err
+			}
+			reminder.PromotionId = &coupon.Id
+			reminder.CouponCode = coupon.Code
+		}
+
+		result := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&reminder)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 && coupon != nil {
+			return errReminderQueued
+		}
+		return nil
+	})
+	if err == errReminderQueued {
+		return reminder, nil
+	}
+	return reminder, err
+}
+
+// errReminderQueued rolls back the coupon of a reminder another run already queued.
+var errReminderQueued = errors.New("cart reminder already queued")
+
+func (reminderRepository *cartReminderRepositoryImpl) FindPending(ctx context.Context, afterId uint, limit int) ([]entity.CartReminder, error) {
+	var reminders []entity.CartReminder
+	err := reminderRepository.DB.WithContext(ctx).
+		Preload("User").
+		Preload("Cart").
+		Preload("Cart.CartItems").
+		Preload("Cart.CartItems.Product", withArchivedProducts).
+		Preload("Promotion").
+		Where("status = ? AND id > ?", "pending", afterId).
+		Order("id").
+		Limit(limit).
+		Find(&reminders).Error
+	return reminders, err
+}
+
+func (reminderRepository *cartReminderRepositoryImpl) MarkSent(ctx context.Context, id uint, sentAt time.Time) error {
+	return reminderRepository.DB.WithContext(ctx).Model(&entity.CartReminder{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":   "sent",
+		"attempts": gorm.Expr("attempts + 1"),
+		"sent_at":  sentAt,
+	}).Error
+}
+
+// MarkFailed records a failed delivery; the reminder is retried until it has failed maxAttempts times.
+// The coupon of a reminder that gives up is switched off, as the customer never received it.
+func (reminderRepository *cartReminderRepositoryImpl) MarkFailed(ctx context.Context, id uint, reason string, maxAttempts int32) error {
+	return reminderRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.CartReminder{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":     gorm.Expr("CASE WHEN attempts + 1 >= ? THEN 'failed' ELSE 'pending' END", maxAttempts),
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": reason,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return deactivateReminderCoupon(tx, id, "failed")
+	})
+}
+
+// MarkCancelled drops a reminder that no longer applies, e.g. because the cart changed before it was
+// sent, and switches off the coupon it would have offered.
+func (reminderRepository *cartReminderRepositoryImpl) MarkCancelled(ctx context.Context, id uint, reason string) error {
+	return reminderRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.CartReminder{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":     "cancelled",
+			"last_error": reason,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return deactivateReminderCoupon(tx, id, "cancelled")
+	})
+}
+
+// deactivateReminderCoupon switches off the coupon of a reminder that ended in status unsent.
+func deactivateReminderCoupon(tx *gorm.DB, id uint, status string) error {
+	return tx.Model(&entity.Promotion{}).
+		Where("id IN (SELECT promotion_id FROM tb_cart_reminder WHERE id = ? AND status = ?)", id, status).
+		Update("active", false).Error
+}
+
+// MarkRecovered credits an order to the last reminder sent for the cart since sentSince. Carts that
+// were not reminded of in that time are left alone.
+func (reminderRepository *cartReminderRepositoryImpl) MarkRecovered(ctx context.Context, cartId uint, orderId uint, sentSince time.Time, recoveredAt time.Time) error {
+	var reminder entity.CartReminder
+	result := reminderRepository.DB.WithContext(ctx).
+		Where("cart_id = ? AND status = ? AND sent_at >= ? AND order_id IS NULL", cartId, "sent", sentSince).
+		Order("sent_at DESC, id DESC").
+		First(&reminder)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return reminderRepository.DB.WithContext(ctx).Model(&reminder).Updates(map[string]interface{}{
+		"order_id":     orderId,
+		"recovered_at": recoveredAt,
+	}).Error
+}
+
+// Report counts the reminders sent from from until to and the orders they recovered, leaving out
+// cancelled orders, and the customer carts abandoned since idleSince.
+func (reminderRepository *cartReminderRepositoryImpl) Report(ctx context.Context, from time.Time, to time.Time, idleSince time.Time) (model.CartRecoveryReportModel, error) {
+	db := reminderRepository.DB.WithContext(ctx)
+
+	var totals struct {
+		RemindersSent    int64
+		CartsReminded    int64
+		CartsRecovered   int64
+		RecoveredRevenue money.Money
+		CouponsSent      int64
+		CouponsRedeemed  int64
+	}
+	err := db.Raw(`SELECT COUNT(*) AS reminders_sent,
+		COUNT(DISTINCT r.user_id, COALESCE(r.cart_id, 0), r.cart_activity_at) AS carts_reminded,
+		COUNT(o.id) AS carts_recovered,
+		COALESCE(SUM(o.total), 0) AS recovered_revenue,
+		COUNT(r.coupon_code) AS coupons_sent,
+		COUNT(CASE WHEN p.usage_count > 0 THEN 1 END) AS coupons_redeemed
+		FROM tb_cart_reminder r
+		LEFT JOIN tb_order o ON o.id = r.order_id AND o.status <> 'cancelled'
+		LEFT JOIN tb_promotion p ON p.id = r.promotion_id
+		WHERE r.status = 'sent' AND r.sent_at >= ? AND r.sent_at < ?`, from, to).
+		Scan(&totals).Error
+	if err != nil {
+		return model.CartRecoveryReportModel{}, err
+	}
+
+	var stages []model.CartRecoveryStageModel
+	err = db.Raw(`SELECT r.stage AS stage, COUNT(*) AS sent, COUNT(o.id) AS recovered
+		FROM tb_cart_reminder r
+		LEFT JOIN tb_order o ON o.id = r.order_id AND o.status <> 'cancelled'
+		WHERE r.status = 'sent' AND r.sent_at >= ? AND r.sent_at < ?
+		GROUP BY r.stage
+		ORDER BY r.stage`, from, to).
+		Scan(&stages).Error
+	if err != nil {
+		return model.CartRecoveryReportModel{}, err
+	}
+
+	var abandoned struct {
+		AbandonedCarts int64
+		AbandonedValue money.Money
+	}
+	err = db.Raw(`SELECT COUNT(*) AS abandoned_carts, COALESCE(SUM(value), 0) AS abandoned_value
+		FROM (SELECT SUM(i.price * i.quantity) AS value
+			FROM tb_cart c
+			JOIN tb_cart_item i ON i.cart_id = c.id
+			WHERE c.user_id IS NOT NULL AND c.type = 'cart' AND c.last_activity_at <= ?
+			GROUP BY c.id) abandoned`, idleSince).
+		Scan(&abandoned).Error
+	if err != nil {
+		return model.CartRecoveryReportModel{}, err
+	}
+
+	return model.CartRecoveryReportModel{
+		AbandonedCarts:   abandoned.AbandonedCarts,
+		AbandonedValue:   abandoned.AbandonedValue,
+		RemindersSent:    totals.RemindersSent,
+		CartsReminded:    totals.CartsReminded,
+		CartsRecovered:   totals.CartsRecovered,
+		RecoveredRevenue: totals.RecoveredRevenue,
+		CouponsSent:      totals.CouponsSent,
+		CouponsRedeemed:  totals.CouponsRedeemed,
+		Stages:           stages,
+	}, nil
+}
@@ -6,6 +6,8 @@ import (
  	"github.com/tech-hive/ecommerce/entity"
  	"github.com/tech-hive/ecommerce/repository"
  	"gorm.io/gorm"
+ 	"gorm.io/gorm/clause"
+ 	"time"
  )
 
 func NewCartRepositoryImpl(DB *gorm.DB) repository.CartRepository {
@@ -16,12 +18,31 @@ type cartRepositoryImpl struct {
 	*gorm.DB
 }
 
+// GetCartByUserId returns the default cart of a customer, the first one they had.
 func (cartRepository *cartRepositoryImpl) GetCartByUserId(ctx context.Context, userId uint) (entity.Cart, error) {
 	var cart entity.Cart
 	result := cartRepository.DB.WithContext(ctx).
 		Preload("CartItems").
 		Preload("CartItems.Product").
-		Where("user_id = ?", userId).
+		Where("user_id = ? AND type = ?", userId, "cart").
+		Order("id").
+		First(&cart)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.Cart{}, errors.New("cart not found")
+		}
+		return entity.Cart{}, result.Error
+	}
+	return cart, nil
+}
+
+func (cartRepository *cartRepositoryImpl) GetCartById(ctx context.Context, cartId uint) (entity.Cart, error) {
+	var cart entity.Cart
+	result := cartRepository.DB.WithContext(ctx).
+		Preload("CartItems").
+		Preload("CartItems.Product").
+		Where("id = ?", cartId).
 		First(&cart)
 
 	if result.Error != nil {
@@ -33,6 +54,17 @@ func (cartRepository *cartRepositoryImpl) GetCartByUserId(ctx context.Context, u
 	return cart, nil
 }
 
+// FindCartsByUserId returns the carts and the save-for-later list of a customer, default cart first.
+func (cartRepository *cartRepositoryImpl) FindCartsByUserId(ctx context.Context, userId uint) ([]entity.Cart, error) {
+	var carts []entity.Cart
+	err := cartRepository.DB.WithContext(ctx).
+		Preload("CartItems").
+		Where("user_id = ?", userId).
+		Order("type, id").
+		Find(&carts).Error
+	return carts, err
+}
+
 func (cartRepository *cartRepositoryImpl) CreateCart(ctx context.Context, cart entity.Cart) (entity.Cart, error) {
 	result := cartRepository.DB.WithContext(ctx).Create(&cart)
 	if result.Error != nil {
@@ -50,11 +82,163 @@ func (cartRepository *cartRepositoryImpl) GetOrCreateCart(ctx context.Context, u
 
 	// Create new cart if not found
 	newCart := entity.Cart{
-		UserId: userId,
+		UserId: &userId,
 	}
 	return cartRepository.CreateCart(ctx, newCart)
 }
 
+func (cartRepository *cartRepositoryImpl) GetOrCreateSavedList(ctx context.Context, userId uint) (entity.Cart, error) {
+	var cart entity.Cart
+	result := cartRepository.DB.WithContext(ctx).
+		Preload("CartItems").
+		Preload("CartItems.Product").
+		Where("user_id = ? AND type = ?", userId, "saved").
+		First(&cart)
+	if result.Error == nil {
+		return cart, nil
+	}
+	if result.Error != gorm.ErrRecordNotFound {
+		return entity.Cart{}, result.Error
+	}
+
+	return cartRepository.CreateCart(ctx, entity.Cart{
+		UserId: &userId,
+		Name:   "Saved for later",
+		Type:   "saved",
+	})
+}
+
+func (cartRepository *cartRepositoryImpl) UpdateCartName(ctx context.Context, cartId uint, name string) error {
+	return cartRepository.DB.WithContext(ctx).Model(&entity.Cart{}).Where("id = ?", cartId).Update("name", name).Error
+}
+
+// DeleteCart deletes a cart with its items and coupons.
+func (cartRepository *cartRepositoryImpl) DeleteCart(ctx context.Context, cartId uint) error {
+	return cartRepository.DB.WithContext(ctx).Delete(&entity.Cart{}, cartId).Error
+}
+
+// MoveCartItem moves an item into another cart and returns the quantity of the product there. When
+// that cart already has the product, the quantities are added up on its line, capped at stock, and
+// the moved item is removed; the bool reports that the cap took units off.
+func (cartRepository *cartRepositoryImpl) MoveCartItem(ctx context.Context, cartItem entity.CartItem, cartId uint, stock int32) (int32, bool, error) {
+	quantity, limited := cartItem.Quantity, false
+	err := cartRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing entity.CartItem
+		result := tx.Where("cart_id = ? AND product_id = ?", cartId, cartItem.ProductId).First(&existing)
+		if result.Error == gorm.ErrRecordNotFound {
+			return tx.Model(&entity.CartItem{}).Where("id = ?", cartItem.Id).Updates(map[string]interface{}{
+				"cart_id": cartId,
+				"price":   cartItem.Price,
+			}).Error
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		quantity, limited = mergedQuantity(existing.Quantity, cartItem.Quantity, stock)
+		if err := tx.Model(&existing).Update("quantity", quantity).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.CartItem{}, cartItem.Id).Error
+	})
+	return quantity, limited, err
+}
+
+// mergedQuantity adds the units of a line to the line of the same product in another cart, capped
+// at the stock available. Units already on that line are kept even when stock has fallen below them.
+func mergedQuantity(existing int32, added int32, stock int32) (int32, bool) {
+	quantity := existing + added
+	if quantity <= stock {
+		return quantity, false
+	}
+	if existing > stock {
+		return existing, true
+	}
+	return stock, true
+}
+
+func (cartRepository *cartRepositoryImpl) GetCartByGuestId(ctx context.Context, guestId string) (entity.Cart, error) {
+	var cart entity.Cart
+	result := cartRepository.DB.WithContext(ctx).
+		Preload("CartItems").
+		Preload("CartItems.Product").
+		Where("guest_id = ?", guestId).
+		First(&cart)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.Cart{}, errors.New("cart not found")
+		}
+		return entity.Cart{}, result.Error
+	}
+	return cart, nil
+}
+
+func (cartRepository *cartRepositoryImpl) GetOrCreateGuestCart(ctx context.Context, guestId string) (entity.Cart, error) {
+	cart, err := cartRepository.GetCartByGuestId(ctx, guestId)
+	if err == nil {
+		return cart, nil
+	}
+
+	return cartRepository.CreateCart(ctx, entity.Cart{
+		GuestId: &guestId,
+	})
+}
+
+// MergeCart saves the items merged into a customer's cart, updating the quantity of items that
+// already exist and creating the others, then deletes the guest cart they came from.
+func (cartRepository *cartRepositoryImpl) MergeCart(ctx context.Context, guestCartId uint, items []entity.CartItem) error {
+	return cartRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveCartItems(tx, items); err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Cart{}, guestCartId).Error
+	})
+}
+
+// SaveCartItems sets the quantity of the items already in a cart and adds the new ones, in one
+// transaction.
+func (cartRepository *cartRepositoryImpl) SaveCartItems(ctx context.Context, items []entity.CartItem) error {
+	return cartRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveCartItems(tx, items)
+	})
+}
+
+func saveCartItems(tx *gorm.DB, items []entity.CartItem) error {
+	for _, item := range items {
+		if item.Id != 0 {
+			if err := tx.Model(&entity.CartItem{}).Where("id = ?", item.Id).Update("quantity", item.Quantity).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tx.Omit(clause.Associations).Create(&item).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReviseCart saves the price and quantity of revised cart items and removes the items that can no
+// longer be bought, in one transaction.
+func (cartRepository *cartRepositoryImpl) ReviseCart(ctx context.Context, items []entity.CartItem, removedItemIds []uint) error {
+	return cartRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			err := tx.Model(&entity.CartItem{}).Where("id = ?", item.Id).Updates(map[string]interface{}{
+				"price":    item.Price,
+				"quantity": item.Quantity,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(removedItemIds) == 0 {
+			return nil
+		}
+		return tx.Delete(&entity.CartItem{}, removedItemIds).Error
+	})
+}
+
 func (cartRepository *cartRepositoryImpl) AddItemToCart(ctx context.Context, cartItem entity.CartItem) (entity.CartItem, error) {
 	result := cartRepository.DB.WithContext(ctx).Create(&cartItem)
 	if result.Error != nil {
@@ -86,4 +270,9 @@ func (cartRepository *cartRepositoryImpl) ClearCart(ctx context.Context, cartId
 		return result.Error
 	}
 	return nil
+}
+
+// TouchCart records that the items or coupons of a cart changed, which restarts its abandonment.
+func (cartRepository *cartRepositoryImpl) TouchCart(ctx context.Context, cartId uint, at time.Time) error {
+	return cartRepository.DB.WithContext(ctx).Model(&entity.Cart{}).Where("id = ?", cartId).Update("last_activity_at", at).Error
 }
\ No newline at end of file
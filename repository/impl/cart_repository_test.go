@@ -0,0 +1,25 @@
+package impl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMergedQuantity(t *testing.T) {
+	cases := map[string]struct {
+		existing, added, stock int32
+		quantity               int32
+		limited                bool
+	}{
+		"within stock":            {existing: 2, added: 3, stock: 10, quantity: 5},
+		"exactly the stock":       {existing: 2, added: 3, stock: 5, quantity: 5},
+		"capped at stock":         {existing: 2, added: 3, stock: 4, quantity: 4, limited: true},
+		"stock below the line":    {existing: 6, added: 3, stock: 4, quantity: 6, limited: true},
+		"out of stock keeps line": {existing: 1, added: 1, stock: 0, quantity: 1, limited: true},
+	}
+	for name, c := range cases {
+		quantity, limited := mergedQuantity(c.existing, c.added, c.stock)
+		assert.Equal(t, c.quantity, quantity, name)
+		assert.Equal(t, c.limited, limited, name)
+	}
+}
@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+)
+
+func NewCurrencyRepositoryImpl(DB *gorm.DB) repository.CurrencyRepository {
+	return &currencyRepositoryImpl{DB: DB}
+}
+
+type currencyRepositoryImpl struct {
+	*gorm.DB
+}
+
+func (currencyRepository *currencyRepositoryImpl) Insert(ctx context.Context, currency entity.Currency) (entity.Currency, error) {
+	result := currencyRepository.DB.WithContext(ctx).Create(&currency)
+	if result.Error != nil {
+		return entity.Currency{}, result.Error
+	}
+	return currencyRepository.FindByCode(ctx, currency.Code)
+}
+
+// Update changes the name, rate and rounding of a currency. Placed orders keep the rate they were placed at.
+func (currencyRepository *currencyRepositoryImpl) Update(ctx context.Context, currency entity.Currency) (entity.Currency, error) {
+	result := currencyRepository.DB.WithContext(ctx).
+		Model(&currency).
+		Select("name", "rate", "decimals", "active", "updated_at").
+		Updates(&currency)
+	if result.Error != nil {
+		return entity.Currency{}, result.Error
+	}
+	return currencyRepository.FindByCode(ctx, currency.Code)
+}
+
+func (currencyRepository *currencyRepositoryImpl) FindByCode(ctx context.Context, code string) (entity.Currency, error) {
+	var currency entity.Currency
+	result := currencyRepository.DB.WithContext(ctx).Where("code = ?", code).First(&currency)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.Currency{}, errors.New("currency not found")
+		}
+		return entity.Currency{}, result.Error
+	}
+	return currency, nil
+}
+
+func (currencyRepository *currencyRepositoryImpl) FindAll(ctx context.Context) ([]entity.Currency, error) {
+	var currencies []entity.Currency
+	err := currencyRepository.DB.WithContext(ctx).Order("code").Find(&currencies).Error
+	return currencies, err
+}
@@ -0,0 +1,169 @@
+package impl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/model"
+	"gorm.io/gorm"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultListLimit = 10
+	maxListLimit     = 100
+	// cursorTimeLayout is how timestamps are written into cursors so MySQL can compare them.
+	cursorTimeLayout = "2006-01-02 15:04:05.999999"
+)
+
+// listQuerySpec whitelists the sort and filter keys a listing accepts and maps
+// them to columns, so client input never reaches the SQL text directly.
+type listQuerySpec[T any] struct {
+	sortColumns   map[string]string
+	filterColumns map[string]string
+	defaultSort   string
+	preloads      []string
+	// preloadScopes optionally narrows or widens a preload, e.g. to include archived products.
+	preloadScopes map[string]func(*gorm.DB) *gorm.DB
+	// keyColumn is a unique column used as the keyset tie-breaker.
+	keyColumn string
+	// cursorValues returns the sort value and key value of a row for the next cursor.
+	cursorValues func(row T, sortBy string) (interface{}, interface{})
+}
+
+type listCursor struct {
+	SortBy    string      `json:"s"`
+	SortOrder string      `json:"o"`
+	Value     interface{} `json:"v"`
+	Key       interface{} `json:"k"`
+}
+
+func encodeListCursor(cursor listCursor) string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(value string) (listCursor, error) {
+	var cursor listCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return listCursor{}, err
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return listCursor{}, err
+	}
+	return cursor, nil
+}
+
+func whitelistKeys(columns map[string]string) string {
+	keys := make([]string, 0, len(columns))
+	for key := range columns {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, " ")
+}
+
+// findPage applies whitelisted filters, sorting and either offset or keyset
+// pagination to query and loads one page of rows. A next cursor is returned
+// whenever more rows follow, so clients can switch to keyset paging at any point.
+func findPage[T any](query *gorm.DB, spec listQuerySpec[T], listQuery model.ListQueryModel) ([]T, model.PageInfoModel, error) {
+	sortBy := listQuery.SortBy
+	if sortBy == "" {
+		sortBy = spec.defaultSort
+	}
+	sortColumn, ok := spec.sortColumns[sortBy]
+	if !ok {
+		return nil, model.PageInfoModel{}, common.NewValidationError("sort_by", "this field is oneof "+whitelistKeys(spec.sortColumns))
+	}
+
+	sortOrder := strings.ToLower(listQuery.SortOrder)
+	if sortOrder == "" {
+		sortOrder = "desc"
+	}
+	if sortOrder != "asc" && sortOrder != "desc" {
+		return nil, model.PageInfoModel{}, common.NewValidationError("sort_order", "this field is oneof asc desc")
+	}
+
+	for key, value := range listQuery.Filters {
+		column, ok := spec.filterColumns[key]
+		if !ok {
+			return nil, model.PageInfoModel{}, common.NewValidationError("filters."+key, "this field is oneof "+whitelistKeys(spec.filterColumns))
+		}
+		query = query.Where(column+" = ?", value)
+	}
+
+	limit := listQuery.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	var totalCount int64
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, model.PageInfoModel{}, err
+	}
+
+	pageInfo := model.PageInfoModel{
+		Limit:      limit,
+		TotalCount: totalCount,
+	}
+
+	query = query.Session(&gorm.Session{})
+	if listQuery.Cursor != "" {
+		cursor, err := decodeListCursor(listQuery.Cursor)
+		if err != nil || cursor.SortBy != sortBy || cursor.SortOrder != sortOrder {
+			return nil, model.PageInfoModel{}, common.NewValidationError("cursor", "this field is invalid for the requested sort")
+		}
+		operator := ">"
+		if sortOrder == "desc" {
+			operator = "<"
+		}
+		query = query.Where("("+sortColumn+" "+operator+" ? OR ("+sortColumn+" = ? AND "+spec.keyColumn+" "+operator+" ?))",
+			cursor.Value, cursor.Value, cursor.Key)
+	} else {
+		page := listQuery.Page
+		if page <= 0 {
+			page = 1
+		}
+		pageInfo.Page = page
+		query = query.Offset((page - 1) * limit)
+	}
+
+	for _, preload := range spec.preloads {
+		if scope, ok := spec.preloadScopes[preload]; ok {
+			query = query.Preload(preload, scope)
+		} else {
+			query = query.Preload(preload)
+		}
+	}
+
+	var rows []T
+	err := query.
+		Order(sortColumn + " " + sortOrder).
+		Order(spec.keyColumn + " " + sortOrder).
+		Limit(limit + 1).
+		Find(&rows).Error
+	if err != nil {
+		return nil, model.PageInfoModel{}, err
+	}
+
+	if len(rows) > limit {
+		rows = rows[:limit]
+		value, key := spec.cursorValues(rows[limit-1], sortBy)
+		pageInfo.NextCursor = encodeListCursor(listCursor{
+			SortBy:    sortBy,
+			SortOrder: sortOrder,
+			Value:     value,
+			Key:       key,
+		})
+	}
+
+	return rows, pageInfo, nil
+}
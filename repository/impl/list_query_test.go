@@ -0,0 +1,32 @@
+package impl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListCursor_RoundTrip(t *testing.T) {
+	cursor := listCursor{
+		SortBy:    "price",
+		SortOrder: "asc",
+		Value:     99.5,
+		Key:       float64(42),
+	}
+
+	decoded, err := decodeListCursor(encodeListCursor(cursor))
+
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestListCursor_RejectsGarbage(t *testing.T) {
+	_, err := decodeListCursor("not-a-cursor!")
+
+	assert.Error(t, err)
+}
+
+func TestWhitelistKeys_Sorted(t *testing.T) {
+	keys := whitelistKeys(map[string]string{"price": "price", "name": "name", "stock": "quantity"})
+
+	assert.Equal(t, "name price stock", keys)
+}
@@ -0,0 +1,70 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+)
+
+// documentPrefixes are the number prefixes of each document type.
+var documentPrefixes = map[string]string{
+	"invoice": "INV",
+	"receipt": "RCT",
+}
+
+func NewOrderDocumentRepositoryImpl(DB *gorm.DB) repository.OrderDocumentRepository {
+	return &orderDocumentRepositoryImpl{DB: DB}
+}
+
+type orderDocumentRepositoryImpl struct {
+	*gorm.DB
+}
+
+func (documentRepository *orderDocumentRepositoryImpl) FindByOrderId(ctx context.Context, orderId uint, documentType string) (entity.OrderDocument, error) {
+	var document entity.OrderDocument
+	result := documentRepository.DB.WithContext(ctx).
+		Where("order_id = ? AND type = ?", orderId, documentType).
+		First(&document)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.OrderDocument{}, errors.New("document not found")
+		}
+		return entity.OrderDocument{}, result.Error
+	}
+	return document, nil
+}
+
+// Issue takes the next number of the document's type, renders the document with it and stores it,
+// in one transaction: a document that fails to render or store gives its number back, so numbers
+// have no gaps. Concurrent documents of a type wait for each other's number.
+func (documentRepository *orderDocumentRepositoryImpl) Issue(ctx context.Context, document entity.OrderDocument, render func(number string) ([]byte, error)) (entity.OrderDocument, error) {
+	prefix, found := documentPrefixes[document.Type]
+	if !found {
+		return entity.OrderDocument{}, errors.New("unknown document type " + document.Type)
+	}
+
+	err := documentRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE tb_document_sequence SET last_number = last_number + 1 WHERE type = ?", document.Type).Error; err != nil {
+			return err
+		}
+		var lastNumber int
+		if err := tx.Raw("SELECT last_number FROM tb_document_sequence WHERE type = ?", document.Type).Scan(&lastNumber).Error; err != nil {
+			return err
+		}
+
+		document.Number = fmt.Sprintf("%s-%06d", prefix, lastNumber)
+		content, err := render(document.Number)
+		if err != nil {
+			return err
+		}
+		document.Content = content
+		return tx.Create(&document).Error
+	})
+	if err != nil {
+		return entity.OrderDocument{}, err
+	}
+	return document, nil
+}
@@ -0,0 +1,35 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+)
+
+func NewOrderNoteRepositoryImpl(DB *gorm.DB) repository.OrderNoteRepository {
+	return &orderNoteRepositoryImpl{DB: DB}
+}
+
+type orderNoteRepositoryImpl struct {
+	*gorm.DB
+}
+
+func (noteRepository *orderNoteRepositoryImpl) Insert(ctx context.Context, note entity.OrderNote) (entity.OrderNote, error) {
+	if err := noteRepository.DB.WithContext(ctx).Create(&note).Error; err != nil {
+		return entity.OrderNote{}, err
+	}
+	err := noteRepository.DB.WithContext(ctx).Preload("Author").Where("id = ?", note.Id).First(&note).Error
+	return note, err
+}
+
+// FindByOrderId returns the notes of an order, oldest first.
+func (noteRepository *orderNoteRepositoryImpl) FindByOrderId(ctx context.Context, orderId uint) ([]entity.OrderNote, error) {
+	notes := []entity.OrderNote{}
+	err := noteRepository.DB.WithContext(ctx).
+		Preload("Author").
+		Where("order_id = ?", orderId).
+		Order("created_at, id").
+		Find(&notes).Error
+	return notes, err
+}
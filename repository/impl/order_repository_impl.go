@@ -4,8 +4,11 @@ import (
  	"context"
  	"errors"
  	"github.com/tech-hive/ecommerce/entity"
+ 	"github.com/tech-hive/ecommerce/model"
  	"github.com/tech-hive/ecommerce/repository"
  	"gorm.io/gorm"
+ 	"gorm.io/gorm/clause"
+ 	"time"
  )
 
 func NewOrderRepositoryImpl(DB *gorm.DB) repository.OrderRepository {
@@ -25,12 +28,24 @@ func (orderRepository *orderRepositoryImpl) CreateOrder(ctx context.Context, ord
 }
 
 func (orderRepository *orderRepositoryImpl) GetOrderById(ctx context.Context, orderId uint) (entity.Order, error) {
+	return orderRepository.findOrder(ctx, "id = ?", orderId)
+}
+
+func (orderRepository *orderRepositoryImpl) GetOrderByNumber(ctx context.Context, number string) (entity.Order, error) {
+	return orderRepository.findOrder(ctx, "number = ?", number)
+}
+
+// findOrder loads the order matching condition with everything shown on it.
+func (orderRepository *orderRepositoryImpl) findOrder(ctx context.Context, condition string, value interface{}) (entity.Order, error) {
 	var order entity.Order
 	result := orderRepository.DB.WithContext(ctx).
+		Preload("User").
 		Preload("OrderItems").
-		Preload("OrderItems.Product").
+		Preload("OrderItems.Product", withArchivedProducts).
 		Preload("Payments").
-		Where("id = ?", orderId).
+		Preload("Discounts").
+		Preload("Taxes").
+		Where(condition, value).
 		First(&order)
 
 	if result.Error != nil {
@@ -42,20 +57,44 @@ func (orderRepository *orderRepositoryImpl) GetOrderById(ctx context.Context, or
 	return order, nil
 }
 
-func (orderRepository *orderRepositoryImpl) GetOrdersByUserId(ctx context.Context, userId uint) ([]entity.Order, error) {
-	var orders []entity.Order
-	result := orderRepository.DB.WithContext(ctx).
-		Preload("OrderItems").
-		Preload("OrderItems.Product").
-		Preload("Payments").
-		Where("user_id = ?", userId).
-		Order("created_at DESC").
-		Find(&orders)
+var orderListSpec = listQuerySpec[entity.Order]{
+	sortColumns: map[string]string{
+		"created_at": "created_at",
+		"total":      "total",
+		"status":     "status",
+	},
+	filterColumns: map[string]string{
+		"status": "status",
+		"number": "number",
+	},
+	defaultSort: "created_at",
+	keyColumn:   "id",
+	preloads:    []string{"OrderItems", "OrderItems.Product", "Payments", "Discounts", "Taxes"},
+	preloadScopes: map[string]func(*gorm.DB) *gorm.DB{
+		"OrderItems.Product": withArchivedProducts,
+	},
+	cursorValues: func(order entity.Order, sortBy string) (interface{}, interface{}) {
+		switch sortBy {
+		case "total":
+			return order.Total, order.Id
+		case "status":
+			return order.Status, order.Id
+		default:
+			return order.CreatedAt.Format(cursorTimeLayout), order.Id
+		}
+	},
+}
 
-	if result.Error != nil {
-		return []entity.Order{}, result.Error
+func (orderRepository *orderRepositoryImpl) GetOrdersByUserId(ctx context.Context, userId uint, listQuery model.ListQueryModel) ([]entity.Order, model.PageInfoModel, error) {
+	query := orderRepository.DB.WithContext(ctx).
+		Model(&entity.Order{}).
+		Where("user_id = ?", userId)
+
+	orders, pageInfo, err := findPage(query, orderListSpec, listQuery)
+	if err != nil {
+		return []entity.Order{}, model.PageInfoModel{}, err
 	}
-	return orders, nil
+	return orders, pageInfo, nil
 }
 
 func (orderRepository *orderRepositoryImpl) UpdateOrderStatus(ctx context.Context, orderId uint, status string) (entity.Order, error) {
@@ -75,4 +114,228 @@ func (orderRepository *orderRepositoryImpl) DeleteOrder(ctx context.Context, ord
 		return result.Error
 	}
 	return nil
+}
+
+const (
+	// paidOrder matches orders with a successful payment.
+	paidOrder = "EXISTS (SELECT 1 FROM tb_payment WHERE tb_payment.order_id = tb_order.id AND tb_payment.status = 'success')"
+	// unpaidOrder matches orders without a successful payment.
+	unpaidOrder = "NOT " + paidOrder
+)
+
+// orderPaymentStatuses match orders by how far their payment got, as admins filter them.
+var orderPaymentStatuses = map[string]string{
+	"paid":    paidOrder,
+	"unpaid":  unpaidOrder,
+	"pending": unpaidOrder + " AND EXISTS (SELECT 1 FROM tb_payment WHERE tb_payment.order_id = tb_order.id AND tb_payment.status = 'pending')",
+	"failed": unpaidOrder + " AND NOT EXISTS (SELECT 1 FROM tb_payment WHERE tb_payment.order_id = tb_order.id AND tb_payment.status = 'pending')" +
+		" AND EXISTS (SELECT 1 FROM tb_payment WHERE tb_payment.order_id = tb_order.id)",
+	"none": "NOT EXISTS (SELECT 1 FROM tb_payment WHERE tb_payment.order_id = tb_order.id)",
+}
+
+// adminOrderListSpec lists the orders of all customers, with who placed them.
+var adminOrderListSpec = func() listQuerySpec[entity.Order] {
+	spec := orderListSpec
+	spec.preloads = append([]string{"User"}, orderListSpec.preloads...)
+	return spec
+}()
+
+// FindAll lists the orders of all customers for admins. The search is validated by the caller;
+// its order number is as stored.
+func (orderRepository *orderRepositoryImpl) FindAll(ctx context.Context, search model.OrderSearchModel) ([]entity.Order, model.PageInfoModel, error) {
+	query := orderRepository.DB.WithContext(ctx).Model(&entity.Order{})
+	if search.Status != "" {
+		query = query.Where("status = ?", search.Status)
+	}
+	if search.PaymentStatus != "" {
+		query = query.Where(orderPaymentStatuses[search.PaymentStatus])
+	}
+	if search.UserId != 0 {
+		query = query.Where("user_id = ?", search.UserId)
+	}
+	if search.Customer != "" {
+		pattern := "%" + search.Customer + "%"
+		query = query.Where("user_id IN (SELECT id FROM tb_user WHERE name LIKE ? OR email LIKE ?)", pattern, pattern)
+	}
+	if search.Number != "" {
+		query = query.Where("number = ?", search.Number)
+	}
+	if search.From != "" {
+		from, _ := time.ParseInLocation("2006-01-02", search.From, time.Local)
+		query = query.Where("created_at >= ?", from)
+	}
+	if search.To != "" {
+		to, _ := time.ParseInLocation("2006-01-02", search.To, time.Local)
+		query = query.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+	if search.MinTotal.IsPositive() {
+		query = query.Where("total >= ?", search.MinTotal)
+	}
+	if search.MaxTotal.IsPositive() {
+		query = query.Where("total <= ?", search.MaxTotal)
+	}
+
+	listQuery := search.ListQueryModel
+	listQuery.Filters = nil
+	orders, pageInfo, err := findPage(query, adminOrderListSpec, listQuery)
+	if err != nil {
+		return []entity.Order{}, model.PageInfoModel{}, err
+	}
+	return orders, pageInfo, nil
+}
+
+// orderStatusTransitions are the statuses each order status may move to: one step along fulfilment,
+// or cancelled before the order ships. Delivered and cancelled orders stay as they are.
+var orderStatusTransitions = map[string][]string{
+	"pending":    {"confirmed", "cancelled"},
+	"confirmed":  {"processing", "cancelled"},
+	"processing": {"shipped", "cancelled"},
+	"shipped":    {"delivered"},
+}
+
+// canMoveOrder tells whether an order in status from may be moved to status to.
+func canMoveOrder(from string, to string) bool {
+	for _, status := range orderStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateOrderStatuses sets the status of several orders at once, refusing the orders the status
+// cannot follow. Cancelled orders put back the stock they took and their open payment attempts are
+// cancelled, in the same transaction. It returns the orders updated, unchanged and refused, leaving
+// ids of missing orders out, and the products and bundles whose stock changed.
+func (orderRepository *orderRepositoryImpl) UpdateOrderStatuses(ctx context.Context, orderIds []uint, status string) (model.OrderBulkStatusResultModel, []string, error) {
+	result := model.OrderBulkStatusResultModel{
+		Status:    status,
+		Updated:   []uint{},
+		Unchanged: []uint{},
+		Refused:   []model.OrderStatusRefusalModel{},
+	}
+	var restocked []string
+	err := orderRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var orders []entity.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status").
+			Where("id IN ?", orderIds).
+			Order("id").
+			Find(&orders).Error
+		if err != nil {
+			return err
+		}
+		for _, order := range orders {
+			switch {
+			case order.Status == status:
+				result.Unchanged = append(result.Unchanged, order.Id)
+			case canMoveOrder(order.Status, status):
+				result.Updated = append(result.Updated, order.Id)
+			default:
+				result.Refused = append(result.Refused, model.OrderStatusRefusalModel{OrderId: order.Id, Status: order.Status})
+			}
+		}
+		if len(result.Updated) == 0 {
+			return nil
+		}
+		if err := tx.Model(&entity.Order{}).Where("id IN ?", result.Updated).Update("status", status).Error; err != nil {
+			return err
+		}
+		if status != "cancelled" {
+			return nil
+		}
+
+		if restocked, err = restockOrders(tx, result.Updated...); err != nil {
+			return err
+		}
+		return tx.Model(&entity.Payment{}).Where("order_id IN ? AND status = ?", result.Updated, "pending").Update("status", "cancelled").Error
+	})
+	if err != nil {
+		return model.OrderBulkStatusResultModel{}, nil, err
+	}
+	return result, restocked, nil
+}
+
+// FindUnpaidPending returns the pending orders placed before createdBefore that have not been paid.
+func (orderRepository *orderRepositoryImpl) FindUnpaidPending(ctx context.Context, createdBefore time.Time, afterId uint, limit int) ([]entity.Order, error) {
+	var orders []entity.Order
+	err := orderRepository.DB.WithContext(ctx).
+		Preload("User").
+		Where("status = ? AND created_at <= ? AND id > ?", "pending", createdBefore, afterId).
+		Where(unpaidOrder).
+		Order("id").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, err
+}
+
+// CancelUnpaidOrder cancels an order that is still pending and unpaid, puts back the stock it took
+// and cancels the payment attempts still open, in one transaction. It returns the products and
+// bundles whose stock changed, and reports false, changing nothing, when the order was paid or
+// moved on meanwhile.
+func (orderRepository *orderRepositoryImpl) CancelUnpaidOrder(ctx context.Context, orderId uint) ([]string, bool, error) {
+	var restocked []string
+	cancelled := false
+	err := orderRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Order{}).
+			Where("id = ? AND status = ?", orderId, "pending").
+			Where(unpaidOrder).
+			Update("status", "cancelled")
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		cancelled = true
+
+		var err error
+		if restocked, err = restockOrders(tx, orderId); err != nil {
+			return err
+		}
+		return tx.Model(&entity.Payment{}).Where("order_id = ? AND status = ?", orderId, "pending").Update("status", "cancelled").Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return restocked, cancelled, nil
+}
+
+// restockOrders puts back the units the orders took at checkout and refreshes the bundles they make
+// up. It returns the products and bundles whose stock changed.
+func restockOrders(tx *gorm.DB, orderIds ...uint) ([]string, error) {
+	// Ordered by product, so concurrent restocks lock the products in one order and cannot deadlock
+	var stock []entity.OrderStock
+	err := tx.Model(&entity.OrderStock{}).
+		Select("product_id, SUM(quantity) AS quantity").
+		Where("order_id IN ?", orderIds).
+		Group("product_id").
+		Order("product_id").
+		Find(&stock).Error
+	if err != nil {
+		return nil, err
+	}
+
+	productIds := make([]string, 0, len(stock))
+	for _, taken := range stock {
+		var product entity.Product
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ?", taken.ProductId).First(&product).Error
+		if err == gorm.ErrRecordNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := enqueueProductAlerts(tx, product, product.Price, product.Stock+taken.Quantity); err != nil {
+			return nil, err
+		}
+		err = tx.Unscoped().Model(&entity.Product{}).Where("id = ?", product.Id).Update("quantity", gorm.Expr("quantity + ?", taken.Quantity)).Error
+		if err != nil {
+			return nil, err
+		}
+		productIds = append(productIds, taken.ProductId)
+	}
+
+	bundleIds, err := refreshBundles(tx, productIds)
+	if err != nil {
+		return nil, err
+	}
+	return append(productIds, bundleIds...), nil
 }
\ No newline at end of file
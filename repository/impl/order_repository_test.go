@@ -0,0 +1,49 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOrderPaymentStatuses_CoverSearch(t *testing.T) {
+	// Every payment status the search accepts has a filter
+	field, _ := reflect.TypeOf(model.OrderSearchModel{}).FieldByName("PaymentStatus")
+	accepted := strings.TrimPrefix(field.Tag.Get("validate"), "omitempty,oneof=")
+
+	assert.Equal(t, "failed none paid pending unpaid", whitelistKeys(orderPaymentStatuses))
+	assert.Equal(t, len(strings.Fields(accepted)), len(orderPaymentStatuses))
+	for _, status := range strings.Fields(accepted) {
+		assert.NotEmpty(t, orderPaymentStatuses[status], status)
+	}
+}
+
+func TestOrderPaymentStatuses_ExcludePaidOrders(t *testing.T) {
+	// An order with a successful payment is paid, whatever its other attempts
+	for _, status := range []string{"unpaid", "pending", "failed"} {
+		assert.True(t, strings.HasPrefix(orderPaymentStatuses[status], unpaidOrder), status)
+	}
+	assert.Equal(t, paidOrder, orderPaymentStatuses["paid"])
+	assert.Equal(t, "NOT "+paidOrder, unpaidOrder)
+	assert.True(t, strings.HasPrefix(orderPaymentStatuses["none"], "NOT EXISTS"))
+}
+
+func TestCanMoveOrder(t *testing.T) {
+	// Orders move one step along fulfilment, or to cancelled before they ship
+	for _, move := range [][2]string{
+		{"pending", "confirmed"}, {"confirmed", "processing"}, {"processing", "shipped"}, {"shipped", "delivered"},
+		{"pending", "cancelled"}, {"confirmed", "cancelled"}, {"processing", "cancelled"},
+	} {
+		assert.True(t, canMoveOrder(move[0], move[1]), move[0]+" to "+move[1])
+	}
+
+	// Delivered and cancelled orders, expired ones among them, stay put, and shipped ones cannot be cancelled
+	for _, move := range [][2]string{
+		{"delivered", "pending"}, {"delivered", "cancelled"}, {"cancelled", "shipped"}, {"cancelled", "pending"},
+		{"shipped", "cancelled"}, {"pending", "shipped"}, {"shipped", "processing"}, {"pending", "pending"},
+	} {
+		assert.False(t, canMoveOrder(move[0], move[1]), move[0]+" to "+move[1])
+	}
+}
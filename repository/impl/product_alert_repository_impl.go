@@ -0,0 +1,75 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+	"time"
+)
+
+func NewProductAlertRepositoryImpl(DB *gorm.DB) repository.ProductAlertRepository {
+	return &productAlertRepositoryImpl{DB: DB}
+}
+
+type productAlertRepositoryImpl struct {
+	*gorm.DB
+}
+
+func (alertRepository *productAlertRepositoryImpl) FindPending(ctx context.Context, afterId uint, limit int) ([]entity.ProductAlert, error) {
+	var alerts []entity.ProductAlert
+	err := alertRepository.DB.WithContext(ctx).
+		Preload("User").
+		Preload("Product", withArchivedProducts).
+		Where("status = ? AND id > ?", "pending", afterId).
+		Order("id").
+		Limit(limit).
+		Find(&alerts).Error
+	return alerts, err
+}
+
+func (alertRepository *productAlertRepositoryImpl) MarkSent(ctx context.Context, id uint, sentAt time.Time) error {
+	return alertRepository.DB.WithContext(ctx).Model(&entity.ProductAlert{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":   "sent",
+		"attempts": gorm.Expr("attempts + 1"),
+		"sent_at":  sentAt,
+	}).Error
+}
+
+// MarkFailed records a failed delivery; the alert is retried until it has failed maxAttempts times.
+func (alertRepository *productAlertRepositoryImpl) MarkFailed(ctx context.Context, id uint, reason string, maxAttempts int32) error {
+	return alertRepository.DB.WithContext(ctx).Model(&entity.ProductAlert{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     gorm.Expr("CASE WHEN attempts + 1 >= ? THEN 'failed' ELSE 'pending' END", maxAttempts),
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": reason,
+	}).Error
+}
+
+// enqueueProductAlerts queues alerts for everyone watching the product when its price drops or it
+// comes back in stock. It runs in the transaction that changes the product, so alerts are queued
+// exactly when the change is committed. Archived products raise no alerts.
+func enqueueProductAlerts(tx *gorm.DB, current entity.Product, newPrice money.Money, newStock int32) error {
+	if current.DeletedAt.Valid {
+		return nil
+	}
+	productId := current.ProductId.String()
+
+	if newPrice.LessThan(current.Price) {
+		err := tx.Exec(`INSERT INTO tb_product_alert (user_id, product_id, type, old_price, new_price, status)
+			SELECT user_id, product_id, 'price_drop', ?, ?, 'pending' FROM tb_wishlist_item
+			WHERE product_id = ? AND notify_price_drop = TRUE`, current.Price, newPrice, productId).Error
+		if err != nil {
+			return err
+		}
+	}
+	if current.Stock <= 0 && newStock > 0 {
+		err := tx.Exec(`INSERT INTO tb_product_alert (user_id, product_id, type, new_price, status)
+			SELECT user_id, product_id, 'back_in_stock', ?, 'pending' FROM tb_wishlist_item
+			WHERE product_id = ? AND notify_back_in_stock = TRUE`, newPrice, productId).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func saveBundleComponents(tx *gorm.DB, product entity.Product) error {
+	if len(product.Components) == 0 {
+		return nil
+	}
+	for i := range product.Components {
+		product.Components[i].BundleProductId = product.ProductId.String()
+	}
+	return tx.Omit(clause.Associations).Create(&product.Components).Error
+}
+
+// refreshBundles refreshes the stock of the bundles containing, or being, one of the given products
+// and returns their ids.
+func refreshBundles(tx *gorm.DB, productIds []string) ([]string, error) {
+	if len(productIds) == 0 {
+		return nil, nil
+	}
+	var bundleIds []string
+	err := tx.Model(&entity.ProductBundleComponent{}).
+		Where("bundle_product_id IN ? OR component_product_id IN ?", productIds, productIds).
+		Distinct().
+		Pluck("bundle_product_id", &bundleIds).Error
+	if err != nil {
+		return nil, err
+	}
+	return bundleIds, refreshBundleStock(tx, productIds)
+}
+
+// refreshBundleStock recomputes the stock of the given bundles and of every bundle containing one
+// of the given products: the number of complete bundles the components' stock makes up. An
+// archived component makes the bundle unavailable.
+func refreshBundleStock(tx *gorm.DB, productIds []string) error {
+	if len(productIds) == 0 {
+		return nil
+	}
+	return tx.Exec(`UPDATE tb_product b
+		JOIN (
+			SELECT c.bundle_product_id,
+				MIN(CASE WHEN p.deleted_at IS NULL THEN FLOOR(p.quantity / c.quantity) ELSE 0 END) AS available
+			FROM tb_product_bundle_component c
+			JOIN tb_product p ON p.product_id = c.component_product_id
+			GROUP BY c.bundle_product_id
+		) s ON s.bundle_product_id = b.product_id
+		SET b.quantity = s.available
+		WHERE b.type = 'bundle' AND b.product_id IN (
+			SELECT bundle_product_id FROM tb_product_bundle_component
+			WHERE bundle_product_id IN ? OR component_product_id IN ?
+		)`, productIds, productIds).Error
+}
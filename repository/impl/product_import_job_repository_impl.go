@@ -0,0 +1,41 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+)
+
+func NewProductImportJobRepositoryImpl(DB *gorm.DB) repository.ProductImportJobRepository {
+	return &productImportJobRepositoryImpl{DB: DB}
+}
+
+type productImportJobRepositoryImpl struct {
+	*gorm.DB
+}
+
+func (jobRepository *productImportJobRepositoryImpl) Insert(ctx context.Context, job entity.ProductImportJob) (entity.ProductImportJob, error) {
+	result := jobRepository.DB.WithContext(ctx).Create(&job)
+	if result.Error != nil {
+		return entity.ProductImportJob{}, result.Error
+	}
+	return job, nil
+}
+
+func (jobRepository *productImportJobRepositoryImpl) Update(ctx context.Context, job entity.ProductImportJob) error {
+	return jobRepository.DB.WithContext(ctx).Save(&job).Error
+}
+
+func (jobRepository *productImportJobRepositoryImpl) FindById(ctx context.Context, id string) (entity.ProductImportJob, error) {
+	var job entity.ProductImportJob
+	result := jobRepository.DB.WithContext(ctx).Where("id = ?", id).First(&job)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ProductImportJob{}, errors.New("import job not found")
+		}
+		return entity.ProductImportJob{}, result.Error
+	}
+	return job, nil
+}
@@ -0,0 +1,265 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func NewProductPriceRepositoryImpl(DB *gorm.DB) repository.ProductPriceRepository {
+	return &productPriceRepositoryImpl{DB: DB}
+}
+
+type productPriceRepositoryImpl struct {
+	*gorm.DB
+}
+
+var productPriceHistoryListSpec = listQuerySpec[entity.ProductPriceHistory]{
+	sortColumns: map[string]string{
+		"created_at": "created_at",
+	},
+	filterColumns: map[string]string{
+		"reason": "reason",
+	},
+	defaultSort: "created_at",
+	keyColumn:   "id",
+	cursorValues: func(history entity.ProductPriceHistory, sortBy string) (interface{}, interface{}) {
+		return history.CreatedAt.Format(cursorTimeLayout), history.Id
+	},
+}
+
+var productPriceScheduleListSpec = listQuerySpec[entity.ProductPriceSchedule]{
+	sortColumns: map[string]string{
+		"starts_at":  "starts_at",
+		"created_at": "created_at",
+	},
+	filterColumns: map[string]string{
+		"type":   "type",
+		"status": "status",
+	},
+	defaultSort: "starts_at",
+	keyColumn:   "id",
+	cursorValues: func(schedule entity.ProductPriceSchedule, sortBy string) (interface{}, interface{}) {
+		if sortBy == "created_at" {
+			return schedule.CreatedAt.Format(cursorTimeLayout), schedule.Id
+		}
+		return schedule.StartsAt.Format(cursorTimeLayout), schedule.Id
+	},
+}
+
+func (priceRepository *productPriceRepositoryImpl) FindHistory(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]entity.ProductPriceHistory, model.PageInfoModel, error) {
+	query := priceRepository.DB.WithContext(ctx).
+		Model(&entity.ProductPriceHistory{}).
+		Where("product_id = ?", productId)
+	return findPage(query, productPriceHistoryListSpec, listQuery)
+}
+
+func (priceRepository *productPriceRepositoryImpl) InsertSchedule(ctx context.Context, schedule entity.ProductPriceSchedule) (entity.ProductPriceSchedule, error) {
+	result := priceRepository.DB.WithContext(ctx).Create(&schedule)
+	if result.Error != nil {
+		return entity.ProductPriceSchedule{}, result.Error
+	}
+	return schedule, nil
+}
+
+func (priceRepository *productPriceRepositoryImpl) FindScheduleById(ctx context.Context, id uint) (entity.ProductPriceSchedule, error) {
+	var schedule entity.ProductPriceSchedule
+	result := priceRepository.DB.WithContext(ctx).Where("id = ?", id).First(&schedule)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ProductPriceSchedule{}, errors.New("price schedule not found")
+		}
+		return entity.ProductPriceSchedule{}, result.Error
+	}
+	return schedule, nil
+}
+
+func (priceRepository *productPriceRepositoryImpl) FindSchedules(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]entity.ProductPriceSchedule, model.PageInfoModel, error) {
+	query := priceRepository.DB.WithContext(ctx).
+		Model(&entity.ProductPriceSchedule{}).
+		Where("product_id = ?", productId)
+	return findPage(query, productPriceScheduleListSpec, listQuery)
+}
+
+func (priceRepository *productPriceRepositoryImpl) HasOverlappingSale(ctx context.Context, productId string, startsAt time.Time, endsAt time.Time) (bool, error) {
+	var count int64
+	err := priceRepository.DB.WithContext(ctx).
+		Model(&entity.ProductPriceSchedule{}).
+		Where("product_id = ? AND type = 'sale' AND status IN ('pending', 'active')", productId).
+		Where("starts_at < ? AND ends_at > ?", endsAt, startsAt).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindDueScheduleIds returns pending schedules that have started and running sales that have
+// ended. Ended sales come first so a sale that ends and a price that starts together apply in order.
+func (priceRepository *productPriceRepositoryImpl) FindDueScheduleIds(ctx context.Context, now time.Time) ([]uint, error) {
+	var ids []uint
+	err := priceRepository.DB.WithContext(ctx).
+		Model(&entity.ProductPriceSchedule{}).
+		Where("(status = 'pending' AND starts_at <= ?) OR (status = 'active' AND ends_at <= ?)", now, now).
+		Order("CASE WHEN status = 'active' THEN ends_at ELSE starts_at END").
+		Order("id").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ApplySchedule applies one due schedule to its product and reports whether anything changed.
+// The schedule is re-read under a row lock, so instances racing on the same schedule apply it once.
+func (priceRepository *productPriceRepositoryImpl) ApplySchedule(ctx context.Context, id uint, now time.Time) (entity.ProductPriceSchedule, bool, error) {
+	var schedule entity.ProductPriceSchedule
+	applied := false
+	err := priceRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&schedule).Error; err != nil {
+			return err
+		}
+
+		switch {
+		case schedule.Status == "active" && !schedule.EndsAt.After(now):
+			schedule.Status = "completed"
+			applied = true
+			if err := endSale(tx, schedule); err != nil {
+				return err
+			}
+		case schedule.Status == "pending" && !schedule.StartsAt.After(now):
+			if schedule.Type == "price" {
+				schedule.Status = "completed"
+				applied = true
+				if err := applyScheduledPrice(tx, schedule); err != nil {
+					return err
+				}
+			} else if schedule.EndsAt.After(now) {
+				schedule.Status = "active"
+				applied = true
+				if err := startSale(tx, schedule); err != nil {
+					return err
+				}
+			} else {
+				// The whole sale window passed while the scheduler was down.
+				schedule.Status = "completed"
+			}
+		default:
+			return nil
+		}
+
+		return tx.Model(&schedule).Update("status", schedule.Status).Error
+	})
+	return schedule, applied, err
+}
+
+// CancelSchedule cancels a pending schedule, or ends a running sale early.
+func (priceRepository *productPriceRepositoryImpl) CancelSchedule(ctx context.Context, id uint, now time.Time) (entity.ProductPriceSchedule, error) {
+	var schedule entity.ProductPriceSchedule
+	err := priceRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&schedule).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("price schedule not found")
+			}
+			return err
+		}
+
+		switch schedule.Status {
+		case "pending":
+		case "active":
+			if err := endSale(tx, schedule); err != nil {
+				return err
+			}
+			schedule.EndsAt = &now
+		default:
+			return errors.New("price schedule is already " + schedule.Status)
+		}
+
+		schedule.Status = "cancelled"
+		return tx.Model(&schedule).Updates(map[string]interface{}{
+			"status":  schedule.Status,
+			"ends_at": schedule.EndsAt,
+		}).Error
+	})
+	if err != nil {
+		return entity.ProductPriceSchedule{}, err
+	}
+	return schedule, nil
+}
+
+func lockScheduleProduct(tx *gorm.DB, productId string) (entity.Product, error) {
+	var product entity.Product
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ?", productId).First(&product).Error
+	return product, err
+}
+
+// applyScheduledPrice changes the regular price, which is the compare-at price during a sale.
+func applyScheduledPrice(tx *gorm.DB, schedule entity.ProductPriceSchedule) error {
+	product, err := lockScheduleProduct(tx, schedule.ProductId)
+	if err != nil {
+		return err
+	}
+
+	column := "price"
+	oldPrice := product.Price
+	if product.CompareAtPrice != nil {
+		column = "compare_at_price"
+		oldPrice = *product.CompareAtPrice
+	}
+	if err := tx.Unscoped().Model(&product).Update(column, schedule.Price).Error; err != nil {
+		return err
+	}
+	if column == "price" {
+		if err := enqueueProductAlerts(tx, product, schedule.Price, product.Stock); err != nil {
+			return err
+		}
+	}
+	return recordPriceChange(tx, schedule.ProductId, oldPrice, schedule.Price, "scheduled", &schedule.Id)
+}
+
+func startSale(tx *gorm.DB, schedule entity.ProductPriceSchedule) error {
+	product, err := lockScheduleProduct(tx, schedule.ProductId)
+	if err != nil {
+		return err
+	}
+
+	regularPrice := product.Price
+	if product.CompareAtPrice != nil {
+		regularPrice = *product.CompareAtPrice
+	}
+	err = tx.Unscoped().Model(&product).Updates(map[string]interface{}{
+		"price":            schedule.Price,
+		"compare_at_price": regularPrice,
+		"sale_ends_at":     schedule.EndsAt,
+	}).Error
+	if err != nil {
+		return err
+	}
+	if err := enqueueProductAlerts(tx, product, schedule.Price, product.Stock); err != nil {
+		return err
+	}
+	return recordPriceChange(tx, schedule.ProductId, product.Price, schedule.Price, "sale_started", &schedule.Id)
+}
+
+func endSale(tx *gorm.DB, schedule entity.ProductPriceSchedule) error {
+	product, err := lockScheduleProduct(tx, schedule.ProductId)
+	if err != nil {
+		return err
+	}
+	if product.CompareAtPrice == nil {
+		return nil
+	}
+
+	regularPrice := *product.CompareAtPrice
+	err = tx.Unscoped().Model(&product).Updates(map[string]interface{}{
+		"price":            regularPrice,
+		"compare_at_price": nil,
+		"sale_ends_at":     nil,
+	}).Error
+	if err != nil {
+		return err
+	}
+	if err := enqueueProductAlerts(tx, product, regularPrice, product.Stock); err != nil {
+		return err
+	}
+	return recordPriceChange(tx, schedule.ProductId, product.Price, regularPrice, "sale_ended", &schedule.Id)
+}
@@ -0,0 +1,69 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+)
+
+func NewProductRecommendationRepositoryImpl(DB *gorm.DB) repository.ProductRecommendationRepository {
+	return &productRecommendationRepositoryImpl{DB: DB}
+}
+
+type productRecommendationRepositoryImpl struct {
+	*gorm.DB
+}
+
+// FindByProductId returns the stored recommendations of one kind in rank order, skipping
+// products archived since the last refresh.
+func (recommendationRepository *productRecommendationRepositoryImpl) FindByProductId(ctx context.Context, productId string, kind string, limit int) ([]entity.ProductRecommendation, error) {
+	var recommendations []entity.ProductRecommendation
+	err := recommendationRepository.DB.WithContext(ctx).
+		Joins("JOIN tb_product ON tb_product.product_id = tb_product_recommendation.recommended_product_id AND tb_product.deleted_at IS NULL").
+		Preload("RecommendedProduct").
+		Where("tb_product_recommendation.product_id = ? AND tb_product_recommendation.kind = ?", productId, kind).
+		Order("tb_product_recommendation.rank").
+		Limit(limit).
+		Find(&recommendations).Error
+	return recommendations, err
+}
+
+// FindCoPurchases counts, for every pair of products, the orders that contain both. Score holds
+// the order count. Cancelled orders are left out.
+func (recommendationRepository *productRecommendationRepositoryImpl) FindCoPurchases(ctx context.Context) ([]entity.ProductRecommendation, error) {
+	var coPurchases []entity.ProductRecommendation
+	err := recommendationRepository.DB.WithContext(ctx).Raw(`SELECT a.product_id AS product_id,
+		b.product_id AS recommended_product_id,
+		COUNT(DISTINCT a.order_id) AS score
+		FROM tb_order_item a
+		JOIN tb_order_item b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+		JOIN tb_order o ON o.id = a.order_id
+		WHERE o.status <> 'cancelled'
+		GROUP BY a.product_id, b.product_id`).
+		Scan(&coPurchases).Error
+	return coPurchases, err
+}
+
+// FindCandidates returns the catalogue fields recommendations are ranked by, for every product not archived.
+func (recommendationRepository *productRecommendationRepositoryImpl) FindCandidates(ctx context.Context) ([]entity.Product, error) {
+	var products []entity.Product
+	err := recommendationRepository.DB.WithContext(ctx).
+		Select("id", "product_id", "category", "quantity", "rating_average", "rating_count").
+		Find(&products).Error
+	return products, err
+}
+
+// ReplaceAll swaps the whole recommendation table in one transaction, so readers never see a
+// half-built set.
+func (recommendationRepository *productRecommendationRepositoryImpl) ReplaceAll(ctx context.Context, recommendations []entity.ProductRecommendation) error {
+	return recommendationRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&entity.ProductRecommendation{}).Error; err != nil {
+			return err
+		}
+		if len(recommendations) == 0 {
+			return nil
+		}
+		return tx.Omit("RecommendedProduct").CreateInBatches(&recommendations, 500).Error
+	})
+}
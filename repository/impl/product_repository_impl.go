@@ -6,9 +6,11 @@ import (
  	"github.com/tech-hive/ecommerce/entity"
  	"github.com/tech-hive/ecommerce/exception"
  	"github.com/tech-hive/ecommerce/model"
+ 	"github.com/tech-hive/ecommerce/money"
  	"github.com/tech-hive/ecommerce/repository"
  	"github.com/google/uuid"
  	"gorm.io/gorm"
+ 	"gorm.io/gorm/clause"
  )
 
 func NewProductRepositoryImpl(DB *gorm.DB) repository.ProductRepository {
@@ -21,28 +23,150 @@ type productRepositoryImpl struct {
 
 func (repository *productRepositoryImpl) Insert(ctx context.Context, product entity.Product) entity.Product {
  	product.ProductId = uuid.New()
- 	err := repository.DB.WithContext(ctx).Create(&product).Error
+ 	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+ 		if err := tx.Omit(clause.Associations).Create(&product).Error; err != nil {
+ 			return err
+ 		}
+ 		if err := saveProductAttributes(tx, product); err != nil {
+ 			return err
+ 		}
+ 		if err := saveBundleComponents(tx, product); err != nil {
+ 			return err
+ 		}
+ 		return refreshBundleStock(tx, []string{product.ProductId.String()})
+ 	})
  	exception.PanicLogging(err)
  	return product
  }
 
+// Update writes the product and records its price change. While a sale runs the new price
+// replaces the compare-at price instead, so the sale keeps going and ends at the new price.
 func (repository *productRepositoryImpl) Update(ctx context.Context, product entity.Product) entity.Product {
-	err := repository.DB.WithContext(ctx).Where("product_id = ?", product.Id).Updates(&product).Error
+	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.Product
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ?", product.ProductId).First(&current).Error
+		if err != nil {
+			return err
+		}
+
+		regularPrice := product.Price
+		oldRegularPrice := current.Price
+		if current.CompareAtPrice != nil {
+			oldRegularPrice = *current.CompareAtPrice
+			product.CompareAtPrice = &regularPrice
+			product.Price = current.Price
+		}
+		// A bundle's stock is derived from its components and refreshed below.
+		if product.Type == "bundle" {
+			product.Stock = current.Stock
+		}
+
+		// Select every editable column so zero values, such as running out of stock, are saved too.
+		err = tx.Omit(clause.Associations).
+			Select("sku", "type", "name", "description", "category", "tax_class", "price", "weight", "compare_at_price", "quantity", "image_url", "updated_at").
+			Where("product_id = ?", product.ProductId).
+			Updates(&product).Error
+		if err != nil {
+			return err
+		}
+		if err := enqueueProductAlerts(tx, current, product.Price, product.Stock); err != nil {
+			return err
+		}
+		// A nil slice leaves attribute values alone; an empty one clears them.
+		if product.Attributes != nil {
+			if err := tx.Where("product_id = ?", product.ProductId.String()).Delete(&entity.ProductAttributeValue{}).Error; err != nil {
+				return err
+			}
+			if err := saveProductAttributes(tx, product); err != nil {
+				return err
+			}
+		}
+		if product.Components != nil {
+			if err := tx.Where("bundle_product_id = ?", product.ProductId.String()).Delete(&entity.ProductBundleComponent{}).Error; err != nil {
+				return err
+			}
+			if err := saveBundleComponents(tx, product); err != nil {
+				return err
+			}
+		}
+		if err := refreshBundleStock(tx, []string{product.ProductId.String()}); err != nil {
+			return err
+		}
+		return recordPriceChange(tx, product.ProductId.String(), oldRegularPrice, regularPrice, "manual", nil)
+	})
 	exception.PanicLogging(err)
 	return product
 }
 
-func (repository *productRepositoryImpl) Delete(ctx context.Context, product entity.Product) {
-	err := repository.DB.WithContext(ctx).Delete(&product).Error
+// Archive soft-deletes the product, hiding it from the catalogue, and takes it out of every cart.
+// Orders keep resolving it through withArchivedProducts.
+func (repository *productRepositoryImpl) Archive(ctx context.Context, product entity.Product) {
+	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", product.ProductId).Delete(&entity.CartItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&product).Error; err != nil {
+			return err
+		}
+		return refreshBundleStock(tx, []string{product.ProductId.String()})
+	})
 	exception.PanicLogging(err)
 }
 
+func (repository *productRepositoryImpl) Restore(ctx context.Context, product entity.Product) {
+	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&product).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return refreshBundleStock(tx, []string{product.ProductId.String()})
+	})
+	exception.PanicLogging(err)
+}
+
+// Purge permanently deletes the product. The order item foreign key restricts deletes,
+// so a product that was ordered fails here even if CountOrderItems raced with a new order.
+func (repository *productRepositoryImpl) Purge(ctx context.Context, product entity.Product) error {
+	return repository.DB.WithContext(ctx).Unscoped().Delete(&product).Error
+}
+
+func (repository *productRepositoryImpl) CountOrderItems(ctx context.Context, productId string) (int64, error) {
+	var count int64
+	err := repository.DB.WithContext(ctx).Model(&entity.OrderItem{}).Where("product_id = ?", productId).Count(&count).Error
+	return count, err
+}
+
+// FindBundleIds returns the bundles the product is a component of.
+func (repository *productRepositoryImpl) FindBundleIds(ctx context.Context, productId string) ([]string, error) {
+	var bundleIds []string
+	err := repository.DB.WithContext(ctx).Model(&entity.ProductBundleComponent{}).
+		Where("component_product_id = ?", productId).
+		Pluck("bundle_product_id", &bundleIds).Error
+	return bundleIds, err
+}
+
+func (repository *productRepositoryImpl) FindBundleComponents(ctx context.Context, bundleProductId string) ([]entity.ProductBundleComponent, error) {
+	var components []entity.ProductBundleComponent
+	err := repository.DB.WithContext(ctx).Where("bundle_product_id = ?", bundleProductId).Find(&components).Error
+	return components, err
+}
+
+func (repository *productRepositoryImpl) RefreshBundleStock(tx *gorm.DB, productIds []string) ([]string, error) {
+	return refreshBundles(tx, productIds)
+}
+
 func (repository *productRepositoryImpl) FindById(ctx context.Context, id string) (entity.Product, error) {
  	var product entity.Product
- 	result := repository.DB.WithContext(ctx).Unscoped().Where("product_id = ?", id).First(&product)
- 	if result.RowsAffected == 0 {
+ 	result := repository.DB.WithContext(ctx).Unscoped().
+ 		Preload("Attributes.Attribute").
+ 		Preload("Components.Component", withArchivedProducts).
+ 		Where("product_id = ?", id).
+ 		First(&product)
+ 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
  		return entity.Product{}, errors.New("product Not Found")
  	}
+ 	if result.Error != nil {
+ 		return entity.Product{}, result.Error
+ 	}
  	return product, nil
  }
 
@@ -58,20 +182,33 @@ func (repository *productRepositoryImpl) FindByProductId(ctx context.Context, pr
  	return product, nil
  }
 
-func (repository *productRepositoryImpl) FindAl(ctx context.Context) ([]entity.Product, int64) {
-  	var products []entity.Product
-  	var totalCount int64
-
-  	// Get total count
-  	repository.DB.WithContext(ctx).Model(&entity.Product{}).Count(&totalCount)
-
-  	// Get all products
-  	repository.DB.WithContext(ctx).Find(&products)
-
-  	return products, totalCount
-  }
+var productListSpec = listQuerySpec[entity.Product]{
+	sortColumns: map[string]string{
+		"name":       "name",
+		"price":      "price",
+		"stock":      "quantity",
+		"rating":     "rating_average",
+		"created_at": "created_at",
+	},
+	defaultSort: "created_at",
+	keyColumn:   "id",
+	cursorValues: func(product entity.Product, sortBy string) (interface{}, interface{}) {
+		switch sortBy {
+		case "name":
+			return product.Name, product.Id
+		case "price":
+			return product.Price, product.Id
+		case "stock":
+			return product.Stock, product.Id
+		case "rating":
+			return product.RatingAverage, product.Id
+		default:
+			return product.CreatedAt.Format(cursorTimeLayout), product.Id
+		}
+	},
+}
 
-func (repository *productRepositoryImpl) Search(ctx context.Context, searchModel model.ProductSearchModel) ([]entity.Product, int64) {
+func (repository *productRepositoryImpl) Search(ctx context.Context, searchModel model.ProductSearchModel) ([]entity.Product, model.PageInfoModel) {
  	query := repository.DB.WithContext(ctx).Model(&entity.Product{})
 
  	// Add search filters
@@ -79,51 +216,162 @@ func (repository *productRepositoryImpl) Search(ctx context.Context, searchModel
  		query = query.Where("name LIKE ?", "%"+searchModel.Name+"%")
  	}
 
- 	if searchModel.MinPrice > 0 {
+ 	if searchModel.Category != "" {
+ 		query = query.Where("category = ?", searchModel.Category)
+ 	}
+
+ 	if searchModel.MinPrice.IsPositive() {
  		query = query.Where("price >= ?", searchModel.MinPrice)
  	}
 
- 	if searchModel.MaxPrice > 0 {
+ 	if searchModel.MaxPrice.IsPositive() {
  		query = query.Where("price <= ?", searchModel.MaxPrice)
  	}
 
  	if searchModel.InStock != nil && *searchModel.InStock {
- 		query = query.Where("stock > ?", 0)
+ 		query = query.Where("quantity > ?", 0)
  	}
 
- 	// Get total count
- 	var totalCount int64
- 	query.Count(&totalCount)
-
- 	// Set default pagination values
- 	page := searchModel.Page
- 	if page <= 0 {
- 		page = 1
+ 	if searchModel.MinRating > 0 {
+ 		query = query.Where("rating_average >= ?", searchModel.MinRating)
  	}
 
- 	limit := searchModel.Limit
- 	if limit <= 0 {
- 		limit = 10
- 	}
+ 	query, err := applyAttributeFilters(query, searchModel.Attributes)
+ 	exception.PanicLogging(err)
 
- 	offset := (page - 1) * limit
- 	query = query.Offset(offset).Limit(limit)
+ 	products, pageInfo, err := findPage(query, productListSpec, searchModel.ListQueryModel)
+ 	exception.PanicLogging(err)
 
- 	// Add sorting
- 	sortBy := searchModel.SortBy
- 	if sortBy == "" {
- 		sortBy = "created_at"
- 	}
+ 	return products, pageInfo
+ }
 
- 	sortOrder := searchModel.SortOrder
- 	if sortOrder == "" {
- 		sortOrder = "desc"
- 	}
+func (repository *productRepositoryImpl) FindArchived(ctx context.Context, listQuery model.ListQueryModel) ([]entity.Product, model.PageInfoModel) {
+	query := repository.DB.WithContext(ctx).Unscoped().Model(&entity.Product{}).Where("deleted_at IS NOT NULL")
+	products, pageInfo, err := findPage(query, productListSpec, listQuery)
+	exception.PanicLogging(err)
+	return products, pageInfo
+}
 
- 	query = query.Order(sortBy + " " + sortOrder)
+// FindByProductIdsOrSkus includes archived products, so an import updates them instead of
+// colliding with their product id or SKU.
+func (repository *productRepositoryImpl) FindByProductIdsOrSkus(ctx context.Context, productIds []string, skus []string) ([]entity.Product, error) {
+	var products []entity.Product
+	if len(productIds) == 0 && len(skus) == 0 {
+		return products, nil
+	}
 
- 	var products []entity.Product
- 	query.Find(&products)
+	query := repository.DB.WithContext(ctx).Unscoped().Preload("Attributes.Attribute")
+	switch {
+	case len(productIds) == 0:
+		query = query.Where("sku IN ?", skus)
+	case len(skus) == 0:
+		query = query.Where("product_id IN ?", productIds)
+	default:
+		query = query.Where("product_id IN ? OR sku IN ?", productIds, skus)
+	}
+	err := query.Find(&products).Error
+	return products, err
+}
 
- 	return products, totalCount
- }
+// SaveBatch inserts and fully updates products in one transaction, so a failing row
+// rolls back the whole batch. Inserted products keep a preset ProductId. Updates carry
+// their regular price like Update does: during a sale it lands on the compare-at price.
+func (repository *productRepositoryImpl) SaveBatch(ctx context.Context, inserts []entity.Product, updates []entity.Product) error {
+	return repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range inserts {
+			if inserts[i].ProductId == uuid.Nil {
+				inserts[i].ProductId = uuid.New()
+			}
+		}
+		if len(inserts) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(&inserts, len(inserts)).Error; err != nil {
+				return err
+			}
+		}
+		for _, product := range inserts {
+			if err := saveProductAttributes(tx, product); err != nil {
+				return err
+			}
+		}
+		for i := range updates {
+			var current entity.Product
+			err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", updates[i].Id).First(&current).Error
+			if err != nil {
+				return err
+			}
+
+			regularPrice := updates[i].Price
+			oldRegularPrice := current.Price
+			if current.CompareAtPrice != nil {
+				oldRegularPrice = *current.CompareAtPrice
+				updates[i].CompareAtPrice = &regularPrice
+				updates[i].Price = current.Price
+			}
+
+			if err := tx.Unscoped().Omit(clause.Associations).Save(&updates[i]).Error; err != nil {
+				return err
+			}
+			// Attribute values are replaced, dropping those a new category does not have
+			if updates[i].Attributes != nil {
+				if err := tx.Where("product_id = ?", updates[i].ProductId.String()).Delete(&entity.ProductAttributeValue{}).Error; err != nil {
+					return err
+				}
+				if err := saveProductAttributes(tx, updates[i]); err != nil {
+					return err
+				}
+			}
+			if err := enqueueProductAlerts(tx, current, updates[i].Price, updates[i].Stock); err != nil {
+				return err
+			}
+			if err := recordPriceChange(tx, updates[i].ProductId.String(), oldRegularPrice, regularPrice, "import", nil); err != nil {
+				return err
+			}
+		}
+
+		var productIds []string
+		for _, product := range inserts {
+			productIds = append(productIds, product.ProductId.String())
+		}
+		for _, product := range updates {
+			productIds = append(productIds, product.ProductId.String())
+		}
+		return refreshBundleStock(tx, productIds)
+	})
+}
+
+func (repository *productRepositoryImpl) FindAllInBatches(ctx context.Context, batchSize int, process func(products []entity.Product) error) error {
+	var products []entity.Product
+	return repository.DB.WithContext(ctx).Order("id").FindInBatches(&products, batchSize, func(tx *gorm.DB, batch int) error {
+		return process(products)
+	}).Error
+}
+
+// withArchivedProducts is a preload scope for order and transaction history, which must keep
+// showing products after they are archived.
+func withArchivedProducts(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// recordPriceChange appends a price history entry when the price actually moved.
+func recordPriceChange(tx *gorm.DB, productId string, oldPrice money.Money, newPrice money.Money, reason string, scheduleId *uint) error {
+	if oldPrice == newPrice {
+		return nil
+	}
+	return tx.Create(&entity.ProductPriceHistory{
+		ProductId:  productId,
+		OldPrice:   oldPrice,
+		NewPrice:   newPrice,
+		Reason:     reason,
+		ScheduleId: scheduleId,
+	}).Error
+}
+
+func saveProductAttributes(tx *gorm.DB, product entity.Product) error {
+	if len(product.Attributes) == 0 {
+		return nil
+	}
+	for i := range product.Attributes {
+		product.Attributes[i].ProductId = product.ProductId.String()
+	}
+	return tx.Omit(clause.Associations).Create(&product.Attributes).Error
+}
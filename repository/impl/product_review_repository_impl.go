@@ -0,0 +1,127 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+)
+
+func NewProductReviewRepositoryImpl(DB *gorm.DB) repository.ProductReviewRepository {
+	return &productReviewRepositoryImpl{DB: DB}
+}
+
+type productReviewRepositoryImpl struct {
+	*gorm.DB
+}
+
+var productReviewListSpec = listQuerySpec[entity.ProductReview]{
+	sortColumns: map[string]string{
+		"created_at": "created_at",
+		"rating":     "rating",
+	},
+	filterColumns: map[string]string{
+		"status": "status",
+		"rating": "rating",
+	},
+	defaultSort: "created_at",
+	keyColumn:   "id",
+	preloads:    []string{"User"},
+	cursorValues: func(review entity.ProductReview, sortBy string) (interface{}, interface{}) {
+		if sortBy == "rating" {
+			return review.Rating, review.Id
+		}
+		return review.CreatedAt.Format(cursorTimeLayout), review.Id
+	},
+}
+
+func (reviewRepository *productReviewRepositoryImpl) Insert(ctx context.Context, review entity.ProductReview) (entity.ProductReview, error) {
+	result := reviewRepository.DB.WithContext(ctx).Create(&review)
+	if result.Error != nil {
+		return entity.ProductReview{}, result.Error
+	}
+	return review, nil
+}
+
+func (reviewRepository *productReviewRepositoryImpl) FindById(ctx context.Context, id uint) (entity.ProductReview, error) {
+	var review entity.ProductReview
+	result := reviewRepository.DB.WithContext(ctx).
+		Preload("User").
+		Where("id = ?", id).
+		First(&review)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ProductReview{}, errors.New("review not found")
+		}
+		return entity.ProductReview{}, result.Error
+	}
+	return review, nil
+}
+
+func (reviewRepository *productReviewRepositoryImpl) FindByProductAndUser(ctx context.Context, productId string, userId uint) (entity.ProductReview, error) {
+	var review entity.ProductReview
+	result := reviewRepository.DB.WithContext(ctx).
+		Where("product_id = ? AND user_id = ?", productId, userId).
+		First(&review)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ProductReview{}, errors.New("review not found")
+		}
+		return entity.ProductReview{}, result.Error
+	}
+	return review, nil
+}
+
+func (reviewRepository *productReviewRepositoryImpl) FindAll(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]entity.ProductReview, model.PageInfoModel, error) {
+	query := reviewRepository.DB.WithContext(ctx).Model(&entity.ProductReview{})
+	if productId != "" {
+		query = query.Where("product_id = ?", productId)
+	}
+
+	reviews, pageInfo, err := findPage(query, productReviewListSpec, listQuery)
+	if err != nil {
+		return []entity.ProductReview{}, model.PageInfoModel{}, err
+	}
+	return reviews, pageInfo, nil
+}
+
+func (reviewRepository *productReviewRepositoryImpl) UpdateStatus(ctx context.Context, id uint, status string) (entity.ProductReview, error) {
+	result := reviewRepository.DB.WithContext(ctx).Model(&entity.ProductReview{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		return entity.ProductReview{}, result.Error
+	}
+	return reviewRepository.FindById(ctx, id)
+}
+
+// FindDeliveredOrderId returns a delivered order of the user that contains the product,
+// which is what makes the user a verified buyer of it.
+func (reviewRepository *productReviewRepositoryImpl) FindDeliveredOrderId(ctx context.Context, userId uint, productId string) (uint, error) {
+	var orderIds []uint
+	result := reviewRepository.DB.WithContext(ctx).
+		Table("tb_order_item").
+		Joins("join tb_order on tb_order.id = tb_order_item.order_id").
+		Where("tb_order.user_id = ? AND tb_order.status = ? AND tb_order_item.product_id = ?", userId, "delivered", productId).
+		Order("tb_order.created_at DESC").
+		Limit(1).
+		Pluck("tb_order.id", &orderIds)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if len(orderIds) == 0 {
+		return 0, errors.New("product can only be reviewed after a delivered order")
+	}
+	return orderIds[0], nil
+}
+
+// RefreshProductRating recomputes the product's average rating and count from its approved reviews.
+func (reviewRepository *productReviewRepositoryImpl) RefreshProductRating(ctx context.Context, productId string) error {
+	return reviewRepository.DB.WithContext(ctx).Exec(`UPDATE tb_product SET
+		rating_average = (SELECT COALESCE(AVG(rating), 0) FROM tb_product_review WHERE product_id = ? AND status = 'approved'),
+		rating_count = (SELECT COUNT(*) FROM tb_product_review WHERE product_id = ? AND status = 'approved')
+		WHERE product_id = ?`, productId, productId, productId).Error
+}
@@ -0,0 +1,196 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func NewPromotionRepositoryImpl(DB *gorm.DB) repository.PromotionRepository {
+	return &promotionRepositoryImpl{DB: DB}
+}
+
+type promotionRepositoryImpl struct {
+	*gorm.DB
+}
+
+var promotionListSpec = listQuerySpec[entity.Promotion]{
+	sortColumns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+	},
+	filterColumns: map[string]string{
+		"type":  "type",
+		"scope": "scope",
+	},
+	defaultSort: "created_at",
+	keyColumn:   "id",
+	cursorValues: func(promotion entity.Promotion, sortBy string) (interface{}, interface{}) {
+		if sortBy == "name" {
+			return promotion.Name, promotion.Id
+		}
+		return promotion.CreatedAt.Format(cursorTimeLayout), promotion.Id
+	},
+}
+
+func (promotionRepository *promotionRepositoryImpl) Insert(ctx context.Context, promotion entity.Promotion) (entity.Promotion, error) {
+	result := promotionRepository.DB.WithContext(ctx).Create(&promotion)
+	if result.Error != nil {
+		return entity.Promotion{}, result.Error
+	}
+	return promotion, nil
+}
+
+// Update saves every editable column, so a promotion can be switched off or have its limits removed.
+func (promotionRepository *promotionRepositoryImpl) Update(ctx context.Context, promotion entity.Promotion) (entity.Promotion, error) {
+	result := promotionRepository.DB.WithContext(ctx).
+		Model(&promotion).
+		Select("code", "name", "type", "value", "amount", "min_spend", "scope", "scope_product_id", "scope_category",
+			"buy_quantity", "get_quantity", "usage_limit", "usage_limit_per_user", "stackable", "active",
+			"starts_at", "ends_at", "updated_at").
+		Updates(&promotion)
+	if result.Error != nil {
+		return entity.Promotion{}, result.Error
+	}
+	return promotionRepository.FindById(ctx, promotion.Id)
+}
+
+func (promotionRepository *promotionRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	return promotionRepository.DB.WithContext(ctx).Delete(&entity.Promotion{}, id).Error
+}
+
+func (promotionRepository *promotionRepositoryImpl) FindById(ctx context.Context, id uint) (entity.Promotion, error) {
+	var promotion entity.Promotion
+	result := promotionRepository.DB.WithContext(ctx).Where("id = ?", id).First(&promotion)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.Promotion{}, errors.New("promotion not found")
+		}
+		return entity.Promotion{}, result.Error
+	}
+	return promotion, nil
+}
+
+func (promotionRepository *promotionRepositoryImpl) FindByCode(ctx context.Context, code string) (entity.Promotion, error) {
+	var promotion entity.Promotion
+	result := promotionRepository.DB.WithContext(ctx).Where("code = ?", code).First(&promotion)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.Promotion{}, errors.New("coupon not found")
+		}
+		return entity.Promotion{}, result.Error
+	}
+	return promotion, nil
+}
+
+func (promotionRepository *promotionRepositoryImpl) FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]entity.Promotion, model.PageInfoModel, error) {
+	query := promotionRepository.DB.WithContext(ctx).Model(&entity.Promotion{})
+	promotions, pageInfo, err := findPage(query, promotionListSpec, listQuery)
+	if err != nil {
+		return []entity.Promotion{}, model.PageInfoModel{}, err
+	}
+	return promotions, pageInfo, nil
+}
+
+// FindAutomatic returns the active promotions without a coupon code that run at now.
+func (promotionRepository *promotionRepositoryImpl) FindAutomatic(ctx context.Context, now time.Time) ([]entity.Promotion, error) {
+	var promotions []entity.Promotion
+	err := promotionRepository.DB.WithContext(ctx).
+		Where("active = ? AND code IS NULL", true).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("ends_at IS NULL OR ends_at > ?", now).
+		Order("id").
+		Find(&promotions).Error
+	return promotions, err
+}
+
+func (promotionRepository *promotionRepositoryImpl) FindCartCoupons(ctx context.Context, cartId uint) ([]entity.Promotion, error) {
+	var coupons []entity.CartCoupon
+	err := promotionRepository.DB.WithContext(ctx).
+		Preload("Promotion").
+		Where("cart_id = ?", cartId).
+		Order("created_at").
+		Find(&coupons).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// A promotion whose code was removed since is automatic now, not a coupon of the cart
+	promotions := []entity.Promotion{}
+	for _, coupon := range coupons {
+		if coupon.Promotion.Code != nil {
+			promotions = append(promotions, coupon.Promotion)
+		}
+	}
+	return promotions, nil
+}
+
+func (promotionRepository *promotionRepositoryImpl) AddCartCoupon(ctx context.Context, cartId uint, promotionId uint) error {
+	return promotionRepository.DB.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.CartCoupon{
+		CartId:      cartId,
+		PromotionId: promotionId,
+	}).Error
+}
+
+func (promotionRepository *promotionRepositoryImpl) RemoveCartCoupon(ctx context.Context, cartId uint, promotionId uint) error {
+	result := promotionRepository.DB.WithContext(ctx).
+		Where("cart_id = ? AND promotion_id = ?", cartId, promotionId).
+		Delete(&entity.CartCoupon{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("coupon not applied to cart")
+	}
+	return nil
+}
+
+// CountRedemptions returns how many orders of the user redeemed each of the promotions.
+func (promotionRepository *promotionRepositoryImpl) CountRedemptions(ctx context.Context, userId uint, promotionIds []uint) (map[uint]int64, error) {
+	counts := map[uint]int64{}
+	if len(promotionIds) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		PromotionId uint
+		Count       int64
+	}
+	err := promotionRepository.DB.WithContext(ctx).
+		Model(&entity.PromotionRedemption{}).
+		Select("promotion_id, COUNT(*) AS count").
+		Where("user_id = ? AND promotion_id IN ?", userId, promotionIds).
+		Group("promotion_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.PromotionId] = row.Count
+	}
+	return counts, nil
+}
+
+// ReleaseRedemptions gives back the promotion uses of a cancelled order.
+func (promotionRepository *promotionRepositoryImpl) ReleaseRedemptions(ctx context.Context, orderId uint) error {
+	return promotionRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var redemptions []entity.PromotionRedemption
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", orderId).Find(&redemptions).Error; err != nil {
+			return err
+		}
+		for _, redemption := range redemptions {
+			err := tx.Model(&entity.Promotion{}).
+				Where("id = ? AND usage_count > 0", redemption.PromotionId).
+				Update("usage_count", gorm.Expr("usage_count - 1")).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Where("order_id = ?", orderId).Delete(&entity.PromotionRedemption{}).Error
+	})
+}
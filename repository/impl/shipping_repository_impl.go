@@ -0,0 +1,194 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewShippingRepositoryImpl(DB *gorm.DB) repository.ShippingRepository {
+	return &shippingRepositoryImpl{DB: DB}
+}
+
+type shippingRepositoryImpl struct {
+	*gorm.DB
+}
+
+func (shippingRepository *shippingRepositoryImpl) InsertZone(ctx context.Context, zone entity.ShippingZone) (entity.ShippingZone, error) {
+	result := shippingRepository.DB.WithContext(ctx).Omit("Methods").Create(&zone)
+	if result.Error != nil {
+		return entity.ShippingZone{}, result.Error
+	}
+	return shippingRepository.FindZoneById(ctx, zone.Id)
+}
+
+// UpdateZone renames a zone and replaces its locations; its methods are left as they are.
+func (shippingRepository *shippingRepositoryImpl) UpdateZone(ctx context.Context, zone entity.ShippingZone) (entity.ShippingZone, error) {
+	err := shippingRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Model(&zone).Select("name", "updated_at").Updates(&zone).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("zone_id = ?", zone.Id).Delete(&entity.ShippingZoneLocation{}).Error; err != nil {
+			return err
+		}
+		for i := range zone.Locations {
+			zone.Locations[i].Id = 0
+			zone.Locations[i].ZoneId = zone.Id
+		}
+		if len(zone.Locations) == 0 {
+			return nil
+		}
+		return tx.Create(&zone.Locations).Error
+	})
+	if err != nil {
+		return entity.ShippingZone{}, err
+	}
+	return shippingRepository.FindZoneById(ctx, zone.Id)
+}
+
+// DeleteZone removes a zone with its locations and methods; orders keep the shipping they were charged.
+func (shippingRepository *shippingRepositoryImpl) DeleteZone(ctx context.Context, id uint) error {
+	return shippingRepository.DB.WithContext(ctx).Delete(&entity.ShippingZone{}, id).Error
+}
+
+func (shippingRepository *shippingRepositoryImpl) FindZoneById(ctx context.Context, id uint) (entity.ShippingZone, error) {
+	var zone entity.ShippingZone
+	result := shippingRepository.zones(ctx, false).Where("id = ?", id).First(&zone)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ShippingZone{}, errors.New("shipping zone not found")
+		}
+		return entity.ShippingZone{}, result.Error
+	}
+	return zone, nil
+}
+
+func (shippingRepository *shippingRepositoryImpl) FindZones(ctx context.Context) ([]entity.ShippingZone, error) {
+	var zones []entity.ShippingZone
+	err := shippingRepository.zones(ctx, false).Order("name").Find(&zones).Error
+	return zones, err
+}
+
+func (shippingRepository *shippingRepositoryImpl) FindZoneByName(ctx context.Context, name string) (entity.ShippingZone, error) {
+	var zone entity.ShippingZone
+	result := shippingRepository.DB.WithContext(ctx).Where("name = ?", name).First(&zone)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ShippingZone{}, errors.New("shipping zone not found")
+		}
+		return entity.ShippingZone{}, result.Error
+	}
+	return zone, nil
+}
+
+// FindZoneLocation finds the zone location listing exactly this county and town; an empty town is the
+// whole county.
+func (shippingRepository *shippingRepositoryImpl) FindZoneLocation(ctx context.Context, county string, town string) (entity.ShippingZoneLocation, error) {
+	var location entity.ShippingZoneLocation
+	result := shippingRepository.DB.WithContext(ctx).Where("county = ? AND town = ?", county, town).First(&location)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ShippingZoneLocation{}, errors.New("shipping zone location not found")
+		}
+		return entity.ShippingZoneLocation{}, result.Error
+	}
+	return location, nil
+}
+
+// FindZoneByLocation finds the zone that delivers to a town, or else to the rest of its county, with
+// only its active methods.
+func (shippingRepository *shippingRepositoryImpl) FindZoneByLocation(ctx context.Context, county string, town string) (entity.ShippingZone, error) {
+	var location entity.ShippingZoneLocation
+	result := shippingRepository.DB.WithContext(ctx).
+		Where("county = ? AND town IN (?, '')", county, town).
+		Order("town = '' ASC").
+		First(&location)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ShippingZone{}, errors.New("no shipping to this location")
+		}
+		return entity.ShippingZone{}, result.Error
+	}
+
+	var zone entity.ShippingZone
+	err := shippingRepository.zones(ctx, true).Where("id = ?", location.ZoneId).First(&zone).Error
+	return zone, err
+}
+
+func (shippingRepository *shippingRepositoryImpl) InsertMethod(ctx context.Context, method entity.ShippingMethod) (entity.ShippingMethod, error) {
+	result := shippingRepository.DB.WithContext(ctx).Create(&method)
+	if result.Error != nil {
+		return entity.ShippingMethod{}, result.Error
+	}
+	return shippingRepository.FindMethodById(ctx, method.Id)
+}
+
+// UpdateMethod saves every editable column and replaces the rate brackets of a method.
+func (shippingRepository *shippingRepositoryImpl) UpdateMethod(ctx context.Context, method entity.ShippingMethod) (entity.ShippingMethod, error) {
+	err := shippingRepository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).
+			Model(&method).
+			Select("name", "type", "rate_type", "flat_fee", "min_days", "max_days", "active", "updated_at").
+			Updates(&method).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("method_id = ?", method.Id).Delete(&entity.ShippingRate{}).Error; err != nil {
+			return err
+		}
+		for i := range method.Rates {
+			method.Rates[i].Id = 0
+			method.Rates[i].MethodId = method.Id
+		}
+		if len(method.Rates) == 0 {
+			return nil
+		}
+		return tx.Create(&method.Rates).Error
+	})
+	if err != nil {
+		return entity.ShippingMethod{}, err
+	}
+	return shippingRepository.FindMethodById(ctx, method.Id)
+}
+
+func (shippingRepository *shippingRepositoryImpl) DeleteMethod(ctx context.Context, id uint) error {
+	return shippingRepository.DB.WithContext(ctx).Delete(&entity.ShippingMethod{}, id).Error
+}
+
+func (shippingRepository *shippingRepositoryImpl) FindMethodById(ctx context.Context, id uint) (entity.ShippingMethod, error) {
+	var method entity.ShippingMethod
+	result := shippingRepository.DB.WithContext(ctx).
+		Preload("Rates", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_weight, min_order_value")
+		}).
+		Where("id = ?", id).
+		First(&method)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.ShippingMethod{}, errors.New("shipping method not found")
+		}
+		return entity.ShippingMethod{}, result.Error
+	}
+	return method, nil
+}
+
+// zones loads zones with their locations, and their methods with the rate brackets in order.
+func (shippingRepository *shippingRepositoryImpl) zones(ctx context.Context, activeOnly bool) *gorm.DB {
+	return shippingRepository.DB.WithContext(ctx).
+		Preload("Locations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("county, town")
+		}).
+		Preload("Methods", func(db *gorm.DB) *gorm.DB {
+			if activeOnly {
+				db = db.Where("active = ?", true)
+			}
+			return db.Order("id")
+		}).
+		Preload("Methods.Rates", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_weight, min_order_value")
+		})
+}
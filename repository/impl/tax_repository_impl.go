@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+)
+
+func NewTaxRepositoryImpl(DB *gorm.DB) repository.TaxRepository {
+	return &taxRepositoryImpl{DB: DB}
+}
+
+type taxRepositoryImpl struct {
+	*gorm.DB
+}
+
+func (taxRepository *taxRepositoryImpl) Insert(ctx context.Context, taxClass entity.TaxClass) (entity.TaxClass, error) {
+	result := taxRepository.DB.WithContext(ctx).Create(&taxClass)
+	if result.Error != nil {
+		return entity.TaxClass{}, result.Error
+	}
+	return taxRepository.FindByCode(ctx, taxClass.Code)
+}
+
+// Update changes the name and rate of a tax class. Placed orders keep the rate they were charged.
+func (taxRepository *taxRepositoryImpl) Update(ctx context.Context, taxClass entity.TaxClass) (entity.TaxClass, error) {
+	result := taxRepository.DB.WithContext(ctx).
+		Model(&taxClass).
+		Select("name", "rate", "exempt", "updated_at").
+		Updates(&taxClass)
+	if result.Error != nil {
+		return entity.TaxClass{}, result.Error
+	}
+	return taxRepository.FindByCode(ctx, taxClass.Code)
+}
+
+func (taxRepository *taxRepositoryImpl) FindByCode(ctx context.Context, code string) (entity.TaxClass, error) {
+	var taxClass entity.TaxClass
+	result := taxRepository.DB.WithContext(ctx).Where("code = ?", code).First(&taxClass)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return entity.TaxClass{}, errors.New("tax class not found")
+		}
+		return entity.TaxClass{}, result.Error
+	}
+	return taxClass, nil
+}
+
+func (taxRepository *taxRepositoryImpl) FindAll(ctx context.Context) ([]entity.TaxClass, error) {
+	var taxClasses []entity.TaxClass
+	err := taxRepository.DB.WithContext(ctx).Order("code").Find(&taxClasses).Error
+	return taxClasses, err
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/tech-hive/ecommerce/entity"
 	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
 	"github.com/tech-hive/ecommerce/repository"
 	"gorm.io/gorm"
 )
@@ -35,7 +36,7 @@ func (transactionRepository *transactionRepositoryImpl) FindById(ctx context.Con
 		Joins("join tb_transaction_detail on tb_transaction_detail.transaction_id = tb_transaction.transaction_id").
 		Joins("join tb_product on tb_product.product_id = tb_transaction_detail.product_id").
 		Preload("TransactionDetails").
-		Preload("TransactionDetails.Product").
+		Preload("TransactionDetails.Product", withArchivedProducts).
 		Where("tb_transaction.transaction_id = ?", id).
 		First(&transaction)
 	if result.RowsAffected == 0 {
@@ -44,15 +45,28 @@ func (transactionRepository *transactionRepositoryImpl) FindById(ctx context.Con
 	return transaction, nil
 }
 
-func (transactionRepository *transactionRepositoryImpl) FindAll(ctx context.Context) []entity.Transaction {
-	var transactions []entity.Transaction
-	transactionRepository.DB.WithContext(ctx).
-		Table("tb_transaction").
-		Select("tb_transaction.transaction_id, tb_transaction.total_price, tb_transaction_detail.transaction_detail_id, tb_transaction_detail.sub_total_price, tb_transaction_detail.price, tb_transaction_detail.quantity, tb_product.product_id, tb_product.name, tb_product.price, tb_product.quantity").
-		Joins("join tb_transaction_detail on tb_transaction_detail.transaction_id = tb_transaction.transaction_id").
-		Joins("join tb_product on tb_product.product_id = tb_transaction_detail.product_id").
-		Preload("TransactionDetails").
-		Preload("TransactionDetails.Product").
-		Find(&transactions)
-	return transactions
+var transactionListSpec = listQuerySpec[entity.Transaction]{
+	sortColumns: map[string]string{
+		"id":          "transaction_id",
+		"total_price": "total_price",
+	},
+	defaultSort: "total_price",
+	keyColumn:   "transaction_id",
+	preloads:    []string{"TransactionDetails", "TransactionDetails.Product"},
+	preloadScopes: map[string]func(*gorm.DB) *gorm.DB{
+		"TransactionDetails.Product": withArchivedProducts,
+	},
+	cursorValues: func(transaction entity.Transaction, sortBy string) (interface{}, interface{}) {
+		if sortBy == "id" {
+			return transaction.Id.String(), transaction.Id.String()
+		}
+		return transaction.TotalPrice, transaction.Id.String()
+	},
+}
+
+func (transactionRepository *transactionRepositoryImpl) FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]entity.Transaction, model.PageInfoModel) {
+	query := transactionRepository.DB.WithContext(ctx).Model(&entity.Transaction{})
+	transactions, pageInfo, err := findPage(query, transactionListSpec, listQuery)
+	exception.PanicLogging(err)
+	return transactions, pageInfo
 }
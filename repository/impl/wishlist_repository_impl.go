@@ -0,0 +1,69 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewWishlistRepositoryImpl(DB *gorm.DB) repository.WishlistRepository {
+	return &wishlistRepositoryImpl{DB: DB}
+}
+
+type wishlistRepositoryImpl struct {
+	*gorm.DB
+}
+
+var wishlistListSpec = listQuerySpec[entity.WishlistItem]{
+	sortColumns: map[string]string{
+		"created_at": "created_at",
+	},
+	defaultSort:   "created_at",
+	keyColumn:     "id",
+	preloads:      []string{"Product"},
+	preloadScopes: map[string]func(*gorm.DB) *gorm.DB{"Product": withArchivedProducts},
+	cursorValues: func(item entity.WishlistItem, sortBy string) (interface{}, interface{}) {
+		return item.CreatedAt.Format(cursorTimeLayout), item.Id
+	},
+}
+
+// Save adds the product to the user's wishlist, or updates its alert settings if it is already there.
+func (wishlistRepository *wishlistRepositoryImpl) Save(ctx context.Context, item entity.WishlistItem) (entity.WishlistItem, error) {
+	err := wishlistRepository.DB.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"notify_price_drop", "notify_back_in_stock"}),
+	}).Create(&item).Error
+	if err != nil {
+		return entity.WishlistItem{}, err
+	}
+
+	var saved entity.WishlistItem
+	err = wishlistRepository.DB.WithContext(ctx).
+		Preload("Product").
+		Where("user_id = ? AND product_id = ?", item.UserId, item.ProductId).
+		First(&saved).Error
+	return saved, err
+}
+
+func (wishlistRepository *wishlistRepositoryImpl) Delete(ctx context.Context, userId uint, productId string) error {
+	result := wishlistRepository.DB.WithContext(ctx).Where("user_id = ? AND product_id = ?", userId, productId).Delete(&entity.WishlistItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("product not in wishlist")
+	}
+	return nil
+}
+
+func (wishlistRepository *wishlistRepositoryImpl) FindAll(ctx context.Context, userId uint, listQuery model.ListQueryModel) ([]entity.WishlistItem, model.PageInfoModel, error) {
+	query := wishlistRepository.DB.WithContext(ctx).Model(&entity.WishlistItem{}).Where("user_id = ?", userId)
+	items, pageInfo, err := findPage(query, wishlistListSpec, listQuery)
+	if err != nil {
+		return []entity.WishlistItem{}, model.PageInfoModel{}, err
+	}
+	return items, pageInfo, nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+)
+
+type OrderDocumentRepository interface {
+	FindByOrderId(ctx context.Context, orderId uint, documentType string) (entity.OrderDocument, error)
+	Issue(ctx context.Context, document entity.OrderDocument, render func(number string) ([]byte, error)) (entity.OrderDocument, error)
+}
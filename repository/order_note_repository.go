@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+)
+
+type OrderNoteRepository interface {
+	Insert(ctx context.Context, note entity.OrderNote) (entity.OrderNote, error)
+	FindByOrderId(ctx context.Context, orderId uint) ([]entity.OrderNote, error)
+}
@@ -3,12 +3,19 @@ package repository
 import (
 	"context"
 	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"time"
 )
 
 type OrderRepository interface {
 	CreateOrder(ctx context.Context, order entity.Order) (entity.Order, error)
 	GetOrderById(ctx context.Context, orderId uint) (entity.Order, error)
-	GetOrdersByUserId(ctx context.Context, userId uint) ([]entity.Order, error)
+	GetOrderByNumber(ctx context.Context, number string) (entity.Order, error)
+	GetOrdersByUserId(ctx context.Context, userId uint, listQuery model.ListQueryModel) ([]entity.Order, model.PageInfoModel, error)
 	UpdateOrderStatus(ctx context.Context, orderId uint, status string) (entity.Order, error)
 	DeleteOrder(ctx context.Context, orderId uint) error
+	FindAll(ctx context.Context, search model.OrderSearchModel) ([]entity.Order, model.PageInfoModel, error)
+	UpdateOrderStatuses(ctx context.Context, orderIds []uint, status string) (model.OrderBulkStatusResultModel, []string, error)
+	FindUnpaidPending(ctx context.Context, createdBefore time.Time, afterId uint, limit int) ([]entity.Order, error)
+	CancelUnpaidOrder(ctx context.Context, orderId uint) ([]string, bool, error)
 }
\ No newline at end of file
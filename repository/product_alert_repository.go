@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"time"
+)
+
+type ProductAlertRepository interface {
+	FindPending(ctx context.Context, afterId uint, limit int) ([]entity.ProductAlert, error)
+	MarkSent(ctx context.Context, id uint, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, reason string, maxAttempts int32) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+)
+
+type ProductImportJobRepository interface {
+	Insert(ctx context.Context, job entity.ProductImportJob) (entity.ProductImportJob, error)
+	Update(ctx context.Context, job entity.ProductImportJob) error
+	FindById(ctx context.Context, id string) (entity.ProductImportJob, error)
+}
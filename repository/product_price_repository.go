@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"time"
+)
+
+type ProductPriceRepository interface {
+	FindHistory(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]entity.ProductPriceHistory, model.PageInfoModel, error)
+	InsertSchedule(ctx context.Context, schedule entity.ProductPriceSchedule) (entity.ProductPriceSchedule, error)
+	FindScheduleById(ctx context.Context, id uint) (entity.ProductPriceSchedule, error)
+	FindSchedules(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]entity.ProductPriceSchedule, model.PageInfoModel, error)
+	HasOverlappingSale(ctx context.Context, productId string, startsAt time.Time, endsAt time.Time) (bool, error)
+	FindDueScheduleIds(ctx context.Context, now time.Time) ([]uint, error)
+	ApplySchedule(ctx context.Context, id uint, now time.Time) (entity.ProductPriceSchedule, bool, error)
+	CancelSchedule(ctx context.Context, id uint, now time.Time) (entity.ProductPriceSchedule, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+)
+
+type ProductRecommendationRepository interface {
+	FindByProductId(ctx context.Context, productId string, kind string, limit int) ([]entity.ProductRecommendation, error)
+	FindCoPurchases(ctx context.Context) ([]entity.ProductRecommendation, error)
+	FindCandidates(ctx context.Context) ([]entity.Product, error)
+	ReplaceAll(ctx context.Context, recommendations []entity.ProductRecommendation) error
+}
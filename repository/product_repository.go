@@ -4,14 +4,25 @@ import (
  	"context"
  	"github.com/tech-hive/ecommerce/entity"
  	"github.com/tech-hive/ecommerce/model"
+ 	"gorm.io/gorm"
  )
 
 type ProductRepository interface {
   	Insert(ctx context.Context, product entity.Product) entity.Product
   	Update(ctx context.Context, product entity.Product) entity.Product
-  	Delete(ctx context.Context, product entity.Product)
+  	Archive(ctx context.Context, product entity.Product)
+  	Restore(ctx context.Context, product entity.Product)
+  	Purge(ctx context.Context, product entity.Product) error
+  	CountOrderItems(ctx context.Context, productId string) (int64, error)
   	FindById(ctx context.Context, id string) (entity.Product, error)
   	FindByProductId(ctx context.Context, productId string) (entity.Product, error)
-  	FindAl(ctx context.Context) ([]entity.Product, int64)
-  	Search(ctx context.Context, searchModel model.ProductSearchModel) ([]entity.Product, int64)
+  	Search(ctx context.Context, searchModel model.ProductSearchModel) ([]entity.Product, model.PageInfoModel)
+  	FindArchived(ctx context.Context, listQuery model.ListQueryModel) ([]entity.Product, model.PageInfoModel)
+  	FindByProductIdsOrSkus(ctx context.Context, productIds []string, skus []string) ([]entity.Product, error)
+  	SaveBatch(ctx context.Context, inserts []entity.Product, updates []entity.Product) error
+  	FindAllInBatches(ctx context.Context, batchSize int, process func(products []entity.Product) error) error
+  	FindBundleIds(ctx context.Context, productId string) ([]string, error)
+  	FindBundleComponents(ctx context.Context, bundleProductId string) ([]entity.ProductBundleComponent, error)
+  	// RefreshBundleStock runs in the caller's transaction and returns the ids of the bundles refreshed
+  	RefreshBundleStock(tx *gorm.DB, productIds []string) ([]string, error)
   }
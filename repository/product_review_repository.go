@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type ProductReviewRepository interface {
+	Insert(ctx context.Context, review entity.ProductReview) (entity.ProductReview, error)
+	FindById(ctx context.Context, id uint) (entity.ProductReview, error)
+	FindByProductAndUser(ctx context.Context, productId string, userId uint) (entity.ProductReview, error)
+	FindAll(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]entity.ProductReview, model.PageInfoModel, error)
+	UpdateStatus(ctx context.Context, id uint, status string) (entity.ProductReview, error)
+	FindDeliveredOrderId(ctx context.Context, userId uint, productId string) (uint, error)
+	RefreshProductRating(ctx context.Context, productId string) error
+}
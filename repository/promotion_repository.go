@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"time"
+)
+
+type PromotionRepository interface {
+	Insert(ctx context.Context, promotion entity.Promotion) (entity.Promotion, error)
+	Update(ctx context.Context, promotion entity.Promotion) (entity.Promotion, error)
+	Delete(ctx context.Context, id uint) error
+	FindById(ctx context.Context, id uint) (entity.Promotion, error)
+	FindByCode(ctx context.Context, code string) (entity.Promotion, error)
+	FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]entity.Promotion, model.PageInfoModel, error)
+	FindAutomatic(ctx context.Context, now time.Time) ([]entity.Promotion, error)
+	FindCartCoupons(ctx context.Context, cartId uint) ([]entity.Promotion, error)
+	AddCartCoupon(ctx context.Context, cartId uint, promotionId uint) error
+	RemoveCartCoupon(ctx context.Context, cartId uint, promotionId uint) error
+	CountRedemptions(ctx context.Context, userId uint, promotionIds []uint) (map[uint]int64, error)
+	ReleaseRedemptions(ctx context.Context, orderId uint) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+)
+
+type ShippingRepository interface {
+	InsertZone(ctx context.Context, zone entity.ShippingZone) (entity.ShippingZone, error)
+	UpdateZone(ctx context.Context, zone entity.ShippingZone) (entity.ShippingZone, error)
+	DeleteZone(ctx context.Context, id uint) error
+	FindZoneById(ctx context.Context, id uint) (entity.ShippingZone, error)
+	FindZones(ctx context.Context) ([]entity.ShippingZone, error)
+	FindZoneByName(ctx context.Context, name string) (entity.ShippingZone, error)
+	FindZoneLocation(ctx context.Context, county string, town string) (entity.ShippingZoneLocation, error)
+	FindZoneByLocation(ctx context.Context, county string, town string) (entity.ShippingZone, error)
+	InsertMethod(ctx context.Context, method entity.ShippingMethod) (entity.ShippingMethod, error)
+	UpdateMethod(ctx context.Context, method entity.ShippingMethod) (entity.ShippingMethod, error)
+	DeleteMethod(ctx context.Context, id uint) error
+	FindMethodById(ctx context.Context, id uint) (entity.ShippingMethod, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+)
+
+type TaxRepository interface {
+	Insert(ctx context.Context, taxClass entity.TaxClass) (entity.TaxClass, error)
+	Update(ctx context.Context, taxClass entity.TaxClass) (entity.TaxClass, error)
+	FindByCode(ctx context.Context, code string) (entity.TaxClass, error)
+	FindAll(ctx context.Context) ([]entity.TaxClass, error)
+}
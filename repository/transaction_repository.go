@@ -3,11 +3,12 @@ package repository
 import (
 	"context"
 	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
 )
 
 type TransactionRepository interface {
 	Insert(ctx context.Context, transaction entity.Transaction) entity.Transaction
 	Delete(ctx context.Context, transaction entity.Transaction)
 	FindById(ctx context.Context, id string) (entity.Transaction, error)
-	FindAll(ctx context.Context) []entity.Transaction
+	FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]entity.Transaction, model.PageInfoModel)
 }
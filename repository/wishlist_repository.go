@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type WishlistRepository interface {
+	Save(ctx context.Context, item entity.WishlistItem) (entity.WishlistItem, error)
+	Delete(ctx context.Context, userId uint, productId string) error
+	FindAll(ctx context.Context, userId uint, listQuery model.ListQueryModel) ([]entity.WishlistItem, model.PageInfoModel, error)
+}
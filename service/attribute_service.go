@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type AttributeService interface {
+	CreateDefinition(ctx context.Context, request model.AttributeDefinitionCreateModel) model.AttributeDefinitionModel
+	FindDefinitions(ctx context.Context, category string) []model.AttributeDefinitionModel
+	AttachToCategory(ctx context.Context, category string, attributeId uint, request model.CategoryAttributeModel) model.AttributeDefinitionModel
+	DetachFromCategory(ctx context.Context, category string, attributeId uint)
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type CartRecoveryService interface {
+	SendReminders(ctx context.Context) error
+	Report(ctx context.Context, query model.CartRecoveryReportQueryModel) model.CartRecoveryReportModel
+}
@@ -6,9 +6,20 @@ import (
 )
 
 type CartService interface {
-	GetCart(ctx context.Context, userId uint) (model.CartModel, error)
-	AddToCart(ctx context.Context, userId uint, request model.AddToCartModel) (model.CartItemModel, error)
-	UpdateCartItem(ctx context.Context, userId uint, cartItemId uint, request model.UpdateCartItemModel) (model.CartItemModel, error)
-	RemoveFromCart(ctx context.Context, userId uint, cartItemId uint) error
-	ClearCart(ctx context.Context, userId uint) error
+	GetCart(ctx context.Context, owner model.CartOwnerModel) (model.CartModel, error)
+	AddToCart(ctx context.Context, owner model.CartOwnerModel, request model.AddToCartModel) (model.CartItemModel, error)
+	UpdateCartItem(ctx context.Context, owner model.CartOwnerModel, cartItemId uint, request model.UpdateCartItemModel) (model.CartItemModel, error)
+	RemoveFromCart(ctx context.Context, owner model.CartOwnerModel, cartItemId uint) error
+	ClearCart(ctx context.Context, owner model.CartOwnerModel) error
+	ApplyCoupon(ctx context.Context, owner model.CartOwnerModel, request model.ApplyCouponModel) (model.CartModel, error)
+	RemoveCoupon(ctx context.Context, owner model.CartOwnerModel, code string) (model.CartModel, error)
+	GetShippingOptions(ctx context.Context, owner model.CartOwnerModel, county string, town string) (model.ShippingQuoteModel, error)
+	AcknowledgeChanges(ctx context.Context, owner model.CartOwnerModel, request model.CartAcknowledgeModel) (model.CartModel, error)
+	MergeGuestCart(ctx context.Context, userId uint, guestId string) (model.CartModel, error)
+	FindCarts(ctx context.Context, userId uint) ([]model.CartSummaryModel, error)
+	CreateCart(ctx context.Context, userId uint, request model.CartCreateOrUpdateModel) (model.CartSummaryModel, error)
+	RenameCart(ctx context.Context, userId uint, cartId uint, request model.CartCreateOrUpdateModel) (model.CartSummaryModel, error)
+	DeleteCart(ctx context.Context, userId uint, cartId uint) error
+	GetSavedItems(ctx context.Context, userId uint) ([]model.CartItemModel, error)
+	MoveCartItem(ctx context.Context, owner model.CartOwnerModel, cartItemId uint, request model.MoveCartItemModel) (model.MoveCartItemResultModel, error)
 }
\ No newline at end of file
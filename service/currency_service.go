@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type CurrencyService interface {
+	Create(ctx context.Context, request model.CurrencyCreateOrUpdateModel) model.CurrencyModel
+	Update(ctx context.Context, code string, request model.CurrencyCreateOrUpdateModel) model.CurrencyModel
+	FindAll(ctx context.Context) []model.CurrencyModel
+	ConvertProduct(ctx context.Context, currency string, product model.ProductModel) model.ProductModel
+	ConvertProducts(ctx context.Context, currency string, products []model.ProductModel) []model.ProductModel
+	ConvertCart(ctx context.Context, currency string, cart model.CartModel) (model.CartModel, error)
+	ConvertShippingQuote(ctx context.Context, currency string, quote model.ShippingQuoteModel) (model.ShippingQuoteModel, error)
+}
@@ -0,0 +1,181 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func NewAttributeServiceImpl(attributeRepository *repository.AttributeRepository) service.AttributeService {
+	return &attributeServiceImpl{AttributeRepository: *attributeRepository}
+}
+
+type attributeServiceImpl struct {
+	repository.AttributeRepository
+}
+
+// attributeCodePattern keeps codes usable as-is in attr=code:value search filters.
+var attributeCodePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+func (attributeService *attributeServiceImpl) CreateDefinition(ctx context.Context, request model.AttributeDefinitionCreateModel) model.AttributeDefinitionModel {
+	common.Validate(request)
+	if !attributeCodePattern.MatchString(request.Code) {
+		panic(common.NewValidationError("Code", "this field is lowercase letters, digits and underscores"))
+	}
+
+	definition := entity.AttributeDefinition{
+		Code: request.Code,
+		Name: request.Name,
+		Type: request.Type,
+		Unit: request.Unit,
+	}
+	if request.Type == "enum" {
+		options, err := json.Marshal(request.Options)
+		exception.PanicLogging(err)
+		definition.Options = string(options)
+	}
+
+	definition, err := attributeService.AttributeRepository.InsertDefinition(ctx, definition)
+	exception.PanicLogging(err)
+	return newAttributeDefinitionModel(definition)
+}
+
+// FindDefinitions lists every attribute, or only those attached to category when it is set.
+func (attributeService *attributeServiceImpl) FindDefinitions(ctx context.Context, category string) []model.AttributeDefinitionModel {
+	responses := []model.AttributeDefinitionModel{}
+	if category == "" {
+		definitions, err := attributeService.AttributeRepository.FindDefinitions(ctx)
+		exception.PanicLogging(err)
+		for _, definition := range definitions {
+			responses = append(responses, newAttributeDefinitionModel(definition))
+		}
+		return responses
+	}
+
+	categoryAttributes, err := attributeService.AttributeRepository.FindCategoryAttributes(ctx, category)
+	exception.PanicLogging(err)
+	for _, categoryAttribute := range categoryAttributes {
+		response := newAttributeDefinitionModel(categoryAttribute.Attribute)
+		response.Required = categoryAttribute.Required
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func (attributeService *attributeServiceImpl) AttachToCategory(ctx context.Context, category string, attributeId uint, request model.CategoryAttributeModel) model.AttributeDefinitionModel {
+	definition, err := attributeService.AttributeRepository.FindDefinitionById(ctx, attributeId)
+	if err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+
+	err = attributeService.AttributeRepository.SaveCategoryAttribute(ctx, entity.CategoryAttribute{
+		Category:    category,
+		AttributeId: attributeId,
+		Required:    request.Required,
+	})
+	exception.PanicLogging(err)
+
+	response := newAttributeDefinitionModel(definition)
+	response.Required = request.Required
+	return response
+}
+
+func (attributeService *attributeServiceImpl) DetachFromCategory(ctx context.Context, category string, attributeId uint) {
+	if err := attributeService.AttributeRepository.DeleteCategoryAttribute(ctx, category, attributeId); err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+}
+
+func newAttributeDefinitionModel(definition entity.AttributeDefinition) model.AttributeDefinitionModel {
+	return model.AttributeDefinitionModel{
+		Id:      definition.Id,
+		Code:    definition.Code,
+		Name:    definition.Name,
+		Type:    definition.Type,
+		Unit:    definition.Unit,
+		Options: attributeOptions(definition),
+	}
+}
+
+func attributeOptions(definition entity.AttributeDefinition) []string {
+	var options []string
+	if definition.Options != "" {
+		if err := json.Unmarshal([]byte(definition.Options), &options); err != nil {
+			common.NewLogger().Error("Failed to decode options of attribute ", definition.Code, ": ", err.Error())
+		}
+	}
+	return options
+}
+
+// newProductAttributeValue type-checks a value from a request against its definition. The
+// returned message is empty when the value is valid.
+func newProductAttributeValue(definition entity.AttributeDefinition, raw interface{}) (entity.ProductAttributeValue, string) {
+	value := entity.ProductAttributeValue{AttributeId: definition.Id}
+	switch definition.Type {
+	case "number":
+		number, ok := raw.(float64)
+		if !ok {
+			return value, "this field is number"
+		}
+		value.ValueNumber = &number
+		value.ValueText = strconv.FormatFloat(number, 'f', -1, 64)
+	case "boolean":
+		flag, ok := raw.(bool)
+		if !ok {
+			return value, "this field is boolean"
+		}
+		value.ValueText = strconv.FormatBool(flag)
+	case "enum":
+		text, ok := raw.(string)
+		if !ok {
+			return value, "this field is string"
+		}
+		options := attributeOptions(definition)
+		for _, option := range options {
+			if option == text {
+				value.ValueText = text
+				return value, ""
+			}
+		}
+		return value, "this field is oneof " + strings.Join(options, " ")
+	default:
+		text, ok := raw.(string)
+		if !ok {
+			return value, "this field is string"
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return value, "this field is required"
+		}
+		if len(text) > 255 {
+			return value, "this field is max=255"
+		}
+		value.ValueText = text
+	}
+	return value, ""
+}
+
+// productAttributeModelValue turns a stored value back into its JSON type.
+func productAttributeModelValue(value entity.ProductAttributeValue) interface{} {
+	switch value.Attribute.Type {
+	case "number":
+		if value.ValueNumber != nil {
+			return *value.ValueNumber
+		}
+	case "boolean":
+		return value.ValueText == "true"
+	}
+	return value.ValueText
+}
@@ -0,0 +1,66 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/repository"
+	"time"
+)
+
+// cartPricer prices carts the same way for the cart and the checkout: promotions first, then VAT on
+// the discounted lines.
+type cartPricer struct {
+	repository.PromotionRepository
+	repository.TaxRepository
+	TaxPolicy configuration.TaxPolicy
+}
+
+func newCartPricer(promotionRepository repository.PromotionRepository, taxRepository repository.TaxRepository, config configuration.Config) cartPricer {
+	return cartPricer{
+		PromotionRepository: promotionRepository,
+		TaxRepository:       taxRepository,
+		TaxPolicy:           configuration.NewTaxPolicy(config),
+	}
+}
+
+// cartPricing is a cart with the promotions that discount it and the tax charged on it.
+type cartPricing struct {
+	Subtotal         money.Money
+	Discounts        []promotionDiscount
+	DiscountTotal    money.Money
+	FreeShipping     bool
+	Coupons          []model.CartCouponModel
+	Lines            map[uint]lineTax // by cart item id
+	Taxes            []model.TaxBreakdownModel
+	TaxTotal         money.Money
+	PricesIncludeTax bool
+	ShippingCost     money.Money // set at checkout, once the shipping method is chosen
+}
+
+func (pricing cartPricing) Total() money.Money {
+	total := pricing.Subtotal.Sub(pricing.DiscountTotal).Add(pricing.ShippingCost)
+	if !pricing.PricesIncludeTax {
+		total = total.Add(pricing.TaxTotal)
+	}
+	return total
+}
+
+// price prices a cart for a customer, or for a guest when userId is 0.
+func (pricer cartPricer) price(ctx context.Context, cart entity.Cart, userId uint, now time.Time) (cartPricing, error) {
+	pricing, err := applyPromotions(ctx, pricer.PromotionRepository, cart, userId, now)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	taxClasses, err := pricer.TaxRepository.FindAll(ctx)
+	if err != nil {
+		return cartPricing{}, err
+	}
+	if err := applyTax(&pricing, cart.CartItems, taxClasses, pricer.TaxPolicy.PricesIncludeTax); err != nil {
+		return cartPricing{}, err
+	}
+	return pricing, nil
+}
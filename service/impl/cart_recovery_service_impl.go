@@ -0,0 +1,275 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"github.com/tech-hive/ecommerce/client"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
+	"github.com/google/uuid"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	cartRecoveryBatchSize   = 100
+	cartReminderMaxAttempts = 5
+	cartRecoveryReportDays  = 30
+	cartRecoveryLockKey     = "cart_recovery:send"
+	cartRecoveryLockTtl     = 4 * time.Minute
+)
+
+func NewCartRecoveryServiceImpl(reminderRepository *repository.CartReminderRepository, notifier *client.NotifierClient, cache *redis.Client, policy configuration.CartRecoveryPolicy) service.CartRecoveryService {
+	return &cartRecoveryServiceImpl{
+		CartReminderRepository: *reminderRepository,
+		NotifierClient:         *notifier,
+		Cache:                  cache,
+		Policy:                 policy,
+	}
+}
+
+type cartRecoveryServiceImpl struct {
+	repository.CartReminderRepository
+	client.NotifierClient
+	Cache  *redis.Client
+	Policy configuration.CartRecoveryPolicy
+}
+
+// SendReminders queues a reminder for every customer cart that has sat untouched past its next
+// reminder delay, then sends the queued reminders through the notifier. It runs from the cart
+// recovery scheduler; a reminder that fails to send is retried on later runs. A Redis lock keeps
+// several instances from sending the same reminders at once.
+func (recoveryService *cartRecoveryServiceImpl) SendReminders(ctx context.Context) error {
+	lockToken, locked, err := configuration.AcquireLock(recoveryService.Cache, ctx, cartRecoveryLockKey, cartRecoveryLockTtl)
+	if err != nil {
+		common.NewLogger().Error("Failed to take cart recovery lock: ", err.Error())
+	} else if !locked {
+		return nil
+	}
+	if locked {
+		defer configuration.ReleaseLock(recoveryService.Cache, ctx, cartRecoveryLockKey, lockToken)
+	}
+
+	if err := recoveryService.queueReminders(ctx, time.Now()); err != nil {
+		return err
+	}
+	return recoveryService.dispatchPending(ctx)
+}
+
+// queueReminders queues the reminder due for each abandoned cart. A cart untouched past several
+// delays, e.g. while the scheduler was down, gets only the latest one due.
+func (recoveryService *cartRecoveryServiceImpl) queueReminders(ctx context.Context, now time.Time) error {
+	idleSince := now.Add(-recoveryService.Policy.ReminderDelays[0])
+	var afterId uint
+	for {
+		carts, err := recoveryService.CartReminderRepository.FindAbandonedCarts(ctx, idleSince, afterId, cartRecoveryBatchSize)
+		if err != nil {
+			return err
+		}
+		cartIds := make([]uint, len(carts))
+		for i, cart := range carts {
+			cartIds[i] = cart.Id
+		}
+		latest, err := recoveryService.CartReminderRepository.FindLatestByCartIds(ctx, cartIds)
+		if err != nil {
+			return err
+		}
+
+		for _, cart := range carts {
+			afterId = cart.Id
+			stage := recoveryService.Policy.ReminderStage(now.Sub(cart.LastActivityAt))
+			previous, found := latest[cart.Id]
+			if found && previous.CartActivityAt.Equal(cart.LastActivityAt) && previous.Stage >= stage {
+				continue
+			}
+			if err := recoveryService.queueReminder(ctx, cart, stage, now); err != nil {
+				return err
+			}
+		}
+		if len(carts) < cartRecoveryBatchSize {
+			return nil
+		}
+	}
+}
+
+// queueReminder queues one reminder for a cart. The last reminder carries a one-time coupon, so a
+// customer is only offered a discount once the earlier reminders did not bring them back.
+func (recoveryService *cartRecoveryServiceImpl) queueReminder(ctx context.Context, cart entity.Cart, stage int, now time.Time) error {
+	var value money.Money
+	for _, item := range cart.CartItems {
+		value = value.Add(item.Price.Mul(item.Quantity))
+	}
+	reminder := entity.CartReminder{
+		CartId:         &cart.Id,
+		UserId:         *cart.UserId,
+		Stage:          stage,
+		CartActivityAt: cart.LastActivityAt,
+		CartValue:      value,
+		Status:         "pending",
+	}
+
+	var coupon *entity.Promotion
+	if stage == len(recoveryService.Policy.ReminderDelays) && recoveryService.Policy.CouponPercent > 0 {
+		promotion := recoveryService.newRecoveryCoupon(reminder.UserId, now)
+		coupon = &promotion
+	}
+
+	_, err := recoveryService.CartReminderRepository.Insert(ctx, reminder, coupon)
+	return err
+}
+
+// newRecoveryCoupon is a coupon for the whole order that the reminded customer alone can redeem,
+// once, until it expires.
+func (recoveryService *cartRecoveryServiceImpl) newRecoveryCoupon(userId uint, now time.Time) entity.Promotion {
+	code := "COMEBACK-" + strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:8])
+	percent := recoveryService.Policy.CouponPercent
+	usageLimit := int32(1)
+	endsAt := now.Add(recoveryService.Policy.CouponValidity)
+	return entity.Promotion{
+		Code:              &code,
+		Name:              fmt.Sprint("Come back for ", percent, "% off"),
+		Type:              "percentage",
+		Value:             percent,
+		Scope:             "order",
+		UserId:            &userId,
+		UsageLimit:        &usageLimit,
+		UsageLimitPerUser: &usageLimit,
+		Active:            true,
+		StartsAt:          &now,
+		EndsAt:            &endsAt,
+	}
+}
+
+func (recoveryService *cartRecoveryServiceImpl) dispatchPending(ctx context.Context) error {
+	var afterId uint
+	for {
+		reminders, err := recoveryService.CartReminderRepository.FindPending(ctx, afterId, cartRecoveryBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, reminder := range reminders {
+			afterId = reminder.Id
+			recoveryService.dispatch(ctx, reminder)
+		}
+		if len(reminders) < cartRecoveryBatchSize {
+			return nil
+		}
+	}
+}
+
+// dispatch sends a reminder, unless the customer came back to the cart since it was queued.
+func (recoveryService *cartRecoveryServiceImpl) dispatch(ctx context.Context, reminder entity.CartReminder) {
+	switch {
+	case reminder.Cart == nil:
+		recoveryService.markCancelled(ctx, reminder.Id, "cart deleted")
+		return
+	case !reminder.Cart.LastActivityAt.Equal(reminder.CartActivityAt):
+		recoveryService.markCancelled(ctx, reminder.Id, "cart changed")
+		return
+	case len(reminder.Cart.CartItems) == 0:
+		recoveryService.markCancelled(ctx, reminder.Id, "cart emptied")
+		return
+	}
+
+	if err := recoveryService.NotifierClient.NotifyCartReminder(ctx, newCartReminderModel(reminder)); err != nil {
+		recoveryService.markFailed(ctx, reminder.Id, err.Error())
+		return
+	}
+	if err := recoveryService.CartReminderRepository.MarkSent(ctx, reminder.Id, time.Now()); err != nil {
+		common.NewLogger().Error("Failed to mark cart reminder ", reminder.Id, " as sent: ", err.Error())
+	}
+}
+
+func (recoveryService *cartRecoveryServiceImpl) markFailed(ctx context.Context, id uint, reason string) {
+	if err := recoveryService.CartReminderRepository.MarkFailed(ctx, id, reason, cartReminderMaxAttempts); err != nil {
+		common.NewLogger().Error("Failed to record cart reminder ", id, " failure: ", err.Error())
+	}
+}
+
+func (recoveryService *cartRecoveryServiceImpl) markCancelled(ctx context.Context, id uint, reason string) {
+	if err := recoveryService.CartReminderRepository.MarkCancelled(ctx, id, reason); err != nil {
+		common.NewLogger().Error("Failed to cancel cart reminder ", id, ": ", err.Error())
+	}
+}
+
+// Report measures the reminders sent between two days, both included, and the carts they recovered:
+// orders placed from a reminded cart within the recovery window of the reminder.
+func (recoveryService *cartRecoveryServiceImpl) Report(ctx context.Context, query model.CartRecoveryReportQueryModel) model.CartRecoveryReportModel {
+	common.Validate(query)
+	now := time.Now()
+	to := now
+	if query.To != "" {
+		to, _ = time.ParseInLocation("2006-01-02", query.To, time.Local)
+	}
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local)
+	from := to.AddDate(0, 0, 1-cartRecoveryReportDays)
+	if query.From != "" {
+		from, _ = time.ParseInLocation("2006-01-02", query.From, time.Local)
+	}
+	if from.After(to) {
+		panic(common.NewValidationError("From", "must not be after to"))
+	}
+
+	report, err := recoveryService.CartReminderRepository.Report(ctx, from, to.AddDate(0, 0, 1), now.Add(-recoveryService.Policy.ReminderDelays[0]))
+	exception.PanicLogging(err)
+
+	report.From = from.Format("2006-01-02")
+	report.To = to.Format("2006-01-02")
+	report.RecoveryRate = percentOf(report.CartsRecovered, report.CartsReminded)
+	if report.Stages == nil {
+		report.Stages = []model.CartRecoveryStageModel{}
+	}
+	for i, stage := range report.Stages {
+		report.Stages[i].RecoveryRate = percentOf(stage.Recovered, stage.Sent)
+	}
+	return report
+}
+
+// percentOf is part as a percentage of whole, to two decimals.
+func percentOf(part int64, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(whole)) / 100
+}
+
+func newCartReminderModel(reminder entity.CartReminder) model.CartReminderModel {
+	response := model.CartReminderModel{
+		Id:             reminder.Id,
+		Stage:          reminder.Stage,
+		UserId:         reminder.UserId,
+		Email:          reminder.User.Email,
+		Name:           reminder.User.Name,
+		CartId:         reminder.Cart.Id,
+		CartName:       reminder.Cart.Name,
+		Items:          []model.CartReminderItemModel{},
+		LastActivityAt: reminder.CartActivityAt.Format(time.RFC3339),
+	}
+	for _, item := range reminder.Cart.CartItems {
+		response.Items = append(response.Items, model.CartReminderItemModel{
+			ProductId:   item.ProductId,
+			ProductName: item.Product.Name,
+			ImageUrl:    item.Product.ImageUrl,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+		})
+		response.CartValue = response.CartValue.Add(item.Price.Mul(item.Quantity))
+	}
+	if reminder.Promotion != nil && reminder.Promotion.Code != nil {
+		response.CouponCode = *reminder.Promotion.Code
+		response.CouponPercent = reminder.Promotion.Value
+		if reminder.Promotion.EndsAt != nil {
+			response.CouponEndsAt = reminder.Promotion.EndsAt.Format(time.RFC3339)
+		}
+	}
+	return response
+}
@@ -0,0 +1,116 @@
+package impl
+
+import (
+	"fmt"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/google/uuid"
+)
+
+const (
+	cartWarningPriceChanged       = "price_changed"
+	cartWarningInsufficientStock  = "insufficient_stock"
+	cartWarningOutOfStock         = "out_of_stock"
+	cartWarningProductUnavailable = "product_unavailable"
+)
+
+// revalidateCart compares every line of a cart with its product as it is now. The cart must have
+// CartItems.Product preloaded; archived and purged products load empty and are reported unavailable.
+func revalidateCart(cart entity.Cart) []model.CartWarningModel {
+	warnings := []model.CartWarningModel{}
+	for _, item := range cart.CartItems {
+		product := item.Product
+		if product.ProductId == uuid.Nil {
+			warnings = append(warnings, model.CartWarningModel{
+				CartItemId: item.Id,
+				ProductId:  item.ProductId,
+				Code:       cartWarningProductUnavailable,
+				Message:    "product is no longer available",
+				Quantity:   item.Quantity,
+			})
+			continue
+		}
+
+		switch {
+		case product.Stock <= 0:
+			warnings = append(warnings, model.CartWarningModel{
+				CartItemId: item.Id,
+				ProductId:  item.ProductId,
+				Code:       cartWarningOutOfStock,
+				Message:    fmt.Sprintf("%s is out of stock", product.Name),
+				Quantity:   item.Quantity,
+			})
+			continue
+		case product.Stock < item.Quantity:
+			warnings = append(warnings, model.CartWarningModel{
+				CartItemId:        item.Id,
+				ProductId:         item.ProductId,
+				Code:              cartWarningInsufficientStock,
+				Message:           fmt.Sprintf("only %d of %s left in stock", product.Stock, product.Name),
+				Quantity:          item.Quantity,
+				AvailableQuantity: product.Stock,
+			})
+		}
+
+		if product.Price != item.Price {
+			oldPrice, newPrice := item.Price, product.Price
+			warnings = append(warnings, model.CartWarningModel{
+				CartItemId: item.Id,
+				ProductId:  item.ProductId,
+				Code:       cartWarningPriceChanged,
+				Message:    fmt.Sprintf("price of %s changed from %s to %s", product.Name, oldPrice, newPrice),
+				OldPrice:   &oldPrice,
+				NewPrice:   &newPrice,
+			})
+		}
+	}
+	return warnings
+}
+
+// reviseCart applies the changes revalidateCart reports: lines take the current price, quantities
+// drop to the stock left and lines that can no longer be bought are removed.
+func reviseCart(cart entity.Cart) (revisedItems []entity.CartItem, removedItemIds []uint) {
+	for _, item := range cart.CartItems {
+		product := item.Product
+		if product.ProductId == uuid.Nil || product.Stock <= 0 {
+			removedItemIds = append(removedItemIds, item.Id)
+			continue
+		}
+		if product.Price == item.Price && product.Stock >= item.Quantity {
+			continue
+		}
+
+		item.Price = product.Price
+		if item.Quantity > product.Stock {
+			item.Quantity = product.Stock
+		}
+		revisedItems = append(revisedItems, item)
+	}
+	return revisedItems, removedItemIds
+}
+
+// acknowledgesRevision tells whether the changes a customer acknowledged are exactly the revision
+// reviseCart makes: every revised line at its price and quantity, and every removed line at
+// quantity 0.
+func acknowledgesRevision(changes []model.CartChangeModel, revisedItems []entity.CartItem, removedItemIds []uint) bool {
+	if len(changes) != len(revisedItems)+len(removedItemIds) {
+		return false
+	}
+	acknowledged := map[uint]model.CartChangeModel{}
+	for _, change := range changes {
+		acknowledged[change.CartItemId] = change
+	}
+	for _, item := range revisedItems {
+		change, ok := acknowledged[item.Id]
+		if !ok || change.Price != item.Price || change.Quantity != item.Quantity {
+			return false
+		}
+	}
+	for _, itemId := range removedItemIds {
+		change, ok := acknowledged[itemId]
+		if !ok || change.Quantity != 0 {
+			return false
+		}
+	}
+	return len(acknowledged) == len(changes)
+}
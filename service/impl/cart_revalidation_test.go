@@ -0,0 +1,110 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testRevalidationCart() entity.Cart {
+	product := func(name string, stock int32, price money.Money) entity.Product {
+		return entity.Product{ProductId: uuid.New(), Name: name, Stock: stock, Price: price}
+	}
+	return entity.Cart{CartItems: []entity.CartItem{
+		{Id: 1, ProductId: "phone", Quantity: 1, Price: money.New(1000, 0), Product: product("Phone", 5, money.New(1000, 0))},
+		{Id: 2, ProductId: "case", Quantity: 2, Price: money.New(500, 0), Product: product("Case", 5, money.New(450, 0))},
+		{Id: 3, ProductId: "cable", Quantity: 4, Price: money.New(300, 0), Product: product("Cable", 3, money.New(350, 0))},
+		{Id: 4, ProductId: "lamp", Quantity: 1, Price: money.New(800, 0), Product: product("Lamp", 0, money.New(900, 0))},
+		{Id: 5, ProductId: "book", Quantity: 1, Price: money.New(200, 0)}, // archived
+	}}
+}
+
+// amountOf points to an amount of whole shillings, as models hold the amounts they may leave out.
+func amountOf(units int64) *money.Money {
+	amount := money.New(units, 0)
+	return &amount
+}
+
+func TestRevalidateCart(t *testing.T) {
+	assert.Equal(t, []model.CartWarningModel{
+		{CartItemId: 2, ProductId: "case", Code: cartWarningPriceChanged, Message: "price of Case changed from 500.00 to 450.00",
+			OldPrice: amountOf(500), NewPrice: amountOf(450)},
+		{CartItemId: 3, ProductId: "cable", Code: cartWarningInsufficientStock, Message: "only 3 of Cable left in stock",
+			Quantity: 4, AvailableQuantity: 3},
+		{CartItemId: 3, ProductId: "cable", Code: cartWarningPriceChanged, Message: "price of Cable changed from 300.00 to 350.00",
+			OldPrice: amountOf(300), NewPrice: amountOf(350)},
+		{CartItemId: 4, ProductId: "lamp", Code: cartWarningOutOfStock, Message: "Lamp is out of stock", Quantity: 1},
+		{CartItemId: 5, ProductId: "book", Code: cartWarningProductUnavailable, Message: "product is no longer available", Quantity: 1},
+	}, revalidateCart(testRevalidationCart()))
+
+	assert.Equal(t, []model.CartWarningModel{}, revalidateCart(entity.Cart{}))
+}
+
+func TestReviseCart(t *testing.T) {
+	cart := testRevalidationCart()
+
+	revisedItems, removedItemIds := reviseCart(cart)
+
+	assert.Equal(t, []uint{4, 5}, removedItemIds)
+	assert.Len(t, revisedItems, 2)
+	assert.Equal(t, uint(2), revisedItems[0].Id)
+	assert.Equal(t, int32(2), revisedItems[0].Quantity)
+	assert.Equal(t, money.New(450, 0), revisedItems[0].Price)
+	assert.Equal(t, uint(3), revisedItems[1].Id)
+	assert.Equal(t, int32(3), revisedItems[1].Quantity)
+	assert.Equal(t, money.New(350, 0), revisedItems[1].Price)
+
+	// The cart itself is left as it was
+	assert.Equal(t, int32(4), cart.CartItems[2].Quantity)
+}
+
+func TestAcknowledgesRevision(t *testing.T) {
+	revisedItems, removedItemIds := reviseCart(testRevalidationCart())
+	acknowledged := func(changed ...model.CartChangeModel) bool {
+		changes := []model.CartChangeModel{
+			{CartItemId: 2, Price: money.New(450, 0), Quantity: 2},
+			{CartItemId: 3, Price: money.New(350, 0), Quantity: 3},
+			{CartItemId: 4, Quantity: 0},
+			{CartItemId: 5, Price: money.New(200, 0), Quantity: 0},
+		}
+		for _, change := range changed {
+			for i := range changes {
+				if changes[i].CartItemId == change.CartItemId {
+					changes[i] = change
+				}
+			}
+		}
+		return acknowledgesRevision(changes, revisedItems, removedItemIds)
+	}
+
+	assert.True(t, acknowledged())
+	assert.True(t, acknowledgesRevision([]model.CartChangeModel{}, nil, nil))
+
+	// The price or stock moved again since the warnings were read
+	assert.False(t, acknowledged(model.CartChangeModel{CartItemId: 2, Price: money.New(500, 0), Quantity: 2}))
+	assert.False(t, acknowledged(model.CartChangeModel{CartItemId: 3, Price: money.New(350, 0), Quantity: 4}))
+	assert.False(t, acknowledged(model.CartChangeModel{CartItemId: 4, Quantity: 1}))
+
+	// A change left out, repeated or not in the revision
+	assert.False(t, acknowledgesRevision([]model.CartChangeModel{
+		{CartItemId: 2, Price: money.New(450, 0), Quantity: 2},
+		{CartItemId: 3, Price: money.New(350, 0), Quantity: 3},
+		{CartItemId: 4, Quantity: 0},
+	}, revisedItems, removedItemIds))
+	assert.False(t, acknowledgesRevision([]model.CartChangeModel{
+		{CartItemId: 2, Price: money.New(450, 0), Quantity: 2},
+		{CartItemId: 3, Price: money.New(350, 0), Quantity: 3},
+		{CartItemId: 4, Quantity: 0},
+		{CartItemId: 4, Quantity: 0},
+	}, revisedItems, removedItemIds))
+	assert.False(t, acknowledgesRevision([]model.CartChangeModel{
+		{CartItemId: 1, Price: money.New(1000, 0), Quantity: 1},
+		{CartItemId: 2, Price: money.New(450, 0), Quantity: 2},
+		{CartItemId: 3, Price: money.New(350, 0), Quantity: 3},
+		{CartItemId: 4, Quantity: 0},
+	}, revisedItems, removedItemIds))
+	assert.False(t, acknowledgesRevision([]model.CartChangeModel{{CartItemId: 4, Quantity: 0}}, nil, nil))
+}
@@ -4,53 +4,79 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/configuration"
 	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
 	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
 	"github.com/tech-hive/ecommerce/repository"
 	"github.com/tech-hive/ecommerce/service"
 	"gorm.io/gorm"
+	"strings"
+	"time"
 )
 
-func NewCartServiceImpl(cartRepository *repository.CartRepository, productRepository *repository.ProductRepository, DB *gorm.DB) service.CartService {
+func NewCartServiceImpl(cartRepository *repository.CartRepository, productRepository *repository.ProductRepository, promotionRepository *repository.PromotionRepository, taxRepository *repository.TaxRepository, shippingRepository *repository.ShippingRepository, DB *gorm.DB, config configuration.Config) service.CartService {
 	return &cartServiceImpl{
-		CartRepository:    *cartRepository,
-		ProductRepository: *productRepository,
-		DB:                DB,
+		CartRepository:      *cartRepository,
+		ProductRepository:   *productRepository,
+		PromotionRepository: *promotionRepository,
+		ShippingRepository:  *shippingRepository,
+		DB:                  DB,
+		Pricer:              newCartPricer(*promotionRepository, *taxRepository, config),
 	}
 }
 
 type cartServiceImpl struct {
 	repository.CartRepository
 	repository.ProductRepository
-	DB *gorm.DB
+	repository.PromotionRepository
+	repository.ShippingRepository
+	DB     *gorm.DB
+	Pricer cartPricer
 }
 
-func (cartService *cartServiceImpl) GetCart(ctx context.Context, userId uint) (model.CartModel, error) {
-	cart, err := cartService.CartRepository.GetCartByUserId(ctx, userId)
+func (cartService *cartServiceImpl) GetCart(ctx context.Context, owner model.CartOwnerModel) (model.CartModel, error) {
+	cart, err := cartService.findCart(ctx, owner)
 	if err != nil {
-		if err.Error() == "cart not found" {
+		if err.Error() == "cart not found" && owner.CartId == 0 {
 			// Return empty cart if not found
 			return model.CartModel{
-				UserId: userId,
-				Items:  []model.CartItemModel{},
-				Total:  0,
+				UserId:           owner.UserId,
+				Items:            []model.CartItemModel{},
+				Discounts:        []model.DiscountModel{},
+				Coupons:          []model.CartCouponModel{},
+				Taxes:            []model.TaxBreakdownModel{},
+				PricesIncludeTax: cartService.Pricer.TaxPolicy.PricesIncludeTax,
+				Total:            money.Money{},
+				Warnings:         []model.CartWarningModel{},
 			}, nil
 		}
 		return model.CartModel{}, err
 	}
 
+	pricing, err := cartService.Pricer.price(ctx, cart, owner.UserId, time.Now())
+	if err != nil {
+		return model.CartModel{}, err
+	}
+
 	// Convert to model
 	var cartItems []model.CartItemModel
-	var total float64 = 0
 
 	for _, item := range cart.CartItems {
+		lineTax := pricing.Lines[item.Id]
 		cartItemModel := model.CartItemModel{
-			Id:        item.Id,
-			CartId:    item.CartId,
-			ProductId: item.ProductId,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
-			CreatedAt: item.CreatedAt.String(),
+			Id:             item.Id,
+			CartId:         item.CartId,
+			ProductId:      item.ProductId,
+			Quantity:       item.Quantity,
+			Price:          item.Price,
+			TaxClass:       lineTax.TaxClass,
+			TaxRate:        lineTax.Rate,
+			DiscountAmount: lineTax.DiscountAmount,
+			TaxAmount:      lineTax.TaxAmount,
+			CreatedAt:      item.CreatedAt.String(),
 			Product: model.ProductModel{
 				Id:          strconv.FormatUint(uint64(item.Product.Id), 10),
 				Name:        item.Product.Name,
@@ -61,23 +87,38 @@ func (cartService *cartServiceImpl) GetCart(ctx context.Context, userId uint) (m
 			},
 		}
 		cartItems = append(cartItems, cartItemModel)
-		total += item.Price * float64(item.Quantity)
+	}
+
+	discounts := []model.DiscountModel{}
+	for _, discount := range pricing.Discounts {
+		discounts = append(discounts, newDiscountModel(discount))
 	}
 
 	cartModel := model.CartModel{
-		Id:        cart.Id,
-		UserId:    cart.UserId,
-		Items:     cartItems,
-		Total:     total,
-		CreatedAt: cart.CreatedAt.String(),
+		Id:               cart.Id,
+		UserId:           owner.UserId,
+		Name:             cart.Name,
+		Items:            cartItems,
+		Subtotal:         pricing.Subtotal,
+		Discounts:        discounts,
+		DiscountTotal:    pricing.DiscountTotal,
+		FreeShipping:     pricing.FreeShipping,
+		Coupons:          pricing.Coupons,
+		Taxes:            pricing.Taxes,
+		TaxTotal:         pricing.TaxTotal,
+		PricesIncludeTax: pricing.PricesIncludeTax,
+		Total:            pricing.Total(),
+		Currency:         money.BaseCurrency,
+		Warnings:         revalidateCart(cart),
+		CreatedAt:        cart.CreatedAt.String(),
 	}
 
 	return cartModel, nil
 }
 
-func (cartService *cartServiceImpl) AddToCart(ctx context.Context, userId uint, request model.AddToCartModel) (model.CartItemModel, error) {
+func (cartService *cartServiceImpl) AddToCart(ctx context.Context, owner model.CartOwnerModel, request model.AddToCartModel) (model.CartItemModel, error) {
 	// Get or create cart
-	cart, err := cartService.CartRepository.GetOrCreateCart(ctx, userId)
+	cart, err := cartService.getOrCreateCart(ctx, owner)
 	if err != nil {
 		return model.CartItemModel{}, err
 	}
@@ -113,6 +154,7 @@ func (cartService *cartServiceImpl) AddToCart(ctx context.Context, userId uint,
 		if err != nil {
 			return model.CartItemModel{}, err
 		}
+		cartService.touchCart(ctx, cart.Id)
 
 		// Return updated item
 		return model.CartItemModel{
@@ -145,6 +187,7 @@ func (cartService *cartServiceImpl) AddToCart(ctx context.Context, userId uint,
 	if err != nil {
 		return model.CartItemModel{}, err
 	}
+	cartService.touchCart(ctx, cart.Id)
 
 	return model.CartItemModel{
 		Id:        resultItem.Id,
@@ -164,9 +207,9 @@ func (cartService *cartServiceImpl) AddToCart(ctx context.Context, userId uint,
 	}, nil
 }
 
-func (cartService *cartServiceImpl) UpdateCartItem(ctx context.Context, userId uint, cartItemId uint, request model.UpdateCartItemModel) (model.CartItemModel, error) {
+func (cartService *cartServiceImpl) UpdateCartItem(ctx context.Context, owner model.CartOwnerModel, cartItemId uint, request model.UpdateCartItemModel) (model.CartItemModel, error) {
 	// Get cart to verify ownership
-	cart, err := cartService.CartRepository.GetCartByUserId(ctx, userId)
+	cart, err := cartService.findCart(ctx, owner)
 	if err != nil {
 		return model.CartItemModel{}, err
 	}
@@ -200,6 +243,7 @@ func (cartService *cartServiceImpl) UpdateCartItem(ctx context.Context, userId u
 	if err != nil {
 		return model.CartItemModel{}, err
 	}
+	cartService.touchCart(ctx, cart.Id)
 
 	// Return updated item
 	return model.CartItemModel{
@@ -220,9 +264,9 @@ func (cartService *cartServiceImpl) UpdateCartItem(ctx context.Context, userId u
 	}, nil
 }
 
-func (cartService *cartServiceImpl) RemoveFromCart(ctx context.Context, userId uint, cartItemId uint) error {
+func (cartService *cartServiceImpl) RemoveFromCart(ctx context.Context, owner model.CartOwnerModel, cartItemId uint) error {
 	// Get cart to verify ownership
-	cart, err := cartService.CartRepository.GetCartByUserId(ctx, userId)
+	cart, err := cartService.findCart(ctx, owner)
 	if err != nil {
 		return err
 	}
@@ -240,14 +284,428 @@ func (cartService *cartServiceImpl) RemoveFromCart(ctx context.Context, userId u
 		return result.Error
 	}
 
-	return cartService.CartRepository.RemoveCartItem(ctx, cartItemId)
+	if err := cartService.CartRepository.RemoveCartItem(ctx, cartItemId); err != nil {
+		return err
+	}
+	cartService.touchCart(ctx, cart.Id)
+	return nil
 }
 
-func (cartService *cartServiceImpl) ClearCart(ctx context.Context, userId uint) error {
-	cart, err := cartService.CartRepository.GetCartByUserId(ctx, userId)
+func (cartService *cartServiceImpl) ClearCart(ctx context.Context, owner model.CartOwnerModel) error {
+	cart, err := cartService.findCart(ctx, owner)
 	if err != nil {
 		return err
 	}
 
-	return cartService.CartRepository.ClearCart(ctx, cart.Id)
+	if err := cartService.CartRepository.ClearCart(ctx, cart.Id); err != nil {
+		return err
+	}
+	cartService.touchCart(ctx, cart.Id)
+	return nil
+}
+
+// AcknowledgeChanges accepts the warnings GetCart reports, so the cart can be checked out: lines take
+// the current price, quantities drop to the stock left and unavailable products are removed. The
+// request must list the changes as the cart makes them now; when the products changed again since
+// the customer saw the warnings, nothing is changed and the fresh warnings come back in a
+// CartChangedError.
+func (cartService *cartServiceImpl) AcknowledgeChanges(ctx context.Context, owner model.CartOwnerModel, request model.CartAcknowledgeModel) (model.CartModel, error) {
+	common.Validate(request)
+
+	cart, err := cartService.findCart(ctx, owner)
+	if err != nil {
+		return model.CartModel{}, err
+	}
+
+	revisedItems, removedItemIds := reviseCart(cart)
+	if !acknowledgesRevision(request.Changes, revisedItems, removedItemIds) {
+		return model.CartModel{}, exception.CartChangedError{Warnings: revalidateCart(cart)}
+	}
+	if len(revisedItems) > 0 || len(removedItemIds) > 0 {
+		if err := cartService.CartRepository.ReviseCart(ctx, revisedItems, removedItemIds); err != nil {
+			return model.CartModel{}, err
+		}
+		cartService.touchCart(ctx, cart.Id)
+	}
+	return cartService.GetCart(ctx, owner)
+}
+
+// GetShippingOptions quotes the shipping methods that deliver the cart to a county and town.
+func (cartService *cartServiceImpl) GetShippingOptions(ctx context.Context, owner model.CartOwnerModel, county string, town string) (model.ShippingQuoteModel, error) {
+	county = strings.TrimSpace(county)
+	town = strings.TrimSpace(town)
+	if county == "" {
+		return model.ShippingQuoteModel{}, errors.New("county is required")
+	}
+
+	cart, err := cartService.findCart(ctx, owner)
+	if err != nil {
+		return model.ShippingQuoteModel{}, err
+	}
+	if len(cart.CartItems) == 0 {
+		return model.ShippingQuoteModel{}, errors.New("cart is empty")
+	}
+
+	zone, err := cartService.ShippingRepository.FindZoneByLocation(ctx, county, town)
+	if err != nil {
+		return model.ShippingQuoteModel{}, err
+	}
+	pricing, err := cartService.Pricer.price(ctx, cart, owner.UserId, time.Now())
+	if err != nil {
+		return model.ShippingQuoteModel{}, err
+	}
+	return quoteShipping(zone, county, town, cart, pricing), nil
+}
+
+// ApplyCoupon adds a coupon to the cart. The coupon must be usable now; whether it discounts the
+// cart, e.g. once the minimum spend is reached, shows in the coupons of the cart.
+func (cartService *cartServiceImpl) ApplyCoupon(ctx context.Context, owner model.CartOwnerModel, request model.ApplyCouponModel) (model.CartModel, error) {
+	common.Validate(request)
+
+	promotion, err := cartService.PromotionRepository.FindByCode(ctx, normalizeCouponCode(request.Code))
+	if err != nil {
+		return model.CartModel{}, err
+	}
+
+	var used int64
+	if owner.UserId != 0 {
+		redemptions, err := cartService.PromotionRepository.CountRedemptions(ctx, owner.UserId, []uint{promotion.Id})
+		if err != nil {
+			return model.CartModel{}, err
+		}
+		used = redemptions[promotion.Id]
+	}
+	if reason := promotionUnavailable(promotion, owner.UserId, used, time.Now()); reason != "" {
+		return model.CartModel{}, errors.New(reason)
+	}
+
+	cart, err := cartService.getOrCreateCart(ctx, owner)
+	if err != nil {
+		return model.CartModel{}, err
+	}
+	if err := cartService.PromotionRepository.AddCartCoupon(ctx, cart.Id, promotion.Id); err != nil {
+		return model.CartModel{}, err
+	}
+	cartService.touchCart(ctx, cart.Id)
+	return cartService.GetCart(ctx, owner)
+}
+
+func (cartService *cartServiceImpl) RemoveCoupon(ctx context.Context, owner model.CartOwnerModel, code string) (model.CartModel, error) {
+	cart, err := cartService.findCart(ctx, owner)
+	if err != nil {
+		return model.CartModel{}, err
+	}
+
+	promotion, err := cartService.PromotionRepository.FindByCode(ctx, normalizeCouponCode(code))
+	if err != nil {
+		return model.CartModel{}, err
+	}
+	if err := cartService.PromotionRepository.RemoveCartCoupon(ctx, cart.Id, promotion.Id); err != nil {
+		return model.CartModel{}, err
+	}
+	cartService.touchCart(ctx, cart.Id)
+	return cartService.GetCart(ctx, owner)
+}
+
+// MergeGuestCart moves the items of a guest cart into the customer's cart when the guest logs in.
+// A product in both carts gets the summed quantity, capped at the stock available; products that
+// are gone or out of stock are dropped. Coupons of the guest cart move along, and the guest cart is
+// deleted afterwards.
+func (cartService *cartServiceImpl) MergeGuestCart(ctx context.Context, userId uint, guestId string) (model.CartModel, error) {
+	owner := model.CartOwnerModel{UserId: userId}
+	guestCart, err := cartService.CartRepository.GetCartByGuestId(ctx, guestId)
+	if err != nil {
+		if err.Error() == "cart not found" {
+			return cartService.GetCart(ctx, owner)
+		}
+		return model.CartModel{}, err
+	}
+
+	cart, err := cartService.CartRepository.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.CartModel{}, err
+	}
+	existingItems := map[string]entity.CartItem{}
+	for _, item := range cart.CartItems {
+		existingItems[item.ProductId] = item
+	}
+
+	var mergedItems []entity.CartItem
+	for _, guestItem := range guestCart.CartItems {
+		product, err := cartService.ProductRepository.FindByProductId(ctx, guestItem.ProductId)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return model.CartModel{}, err
+		}
+
+		item, found := existingItems[guestItem.ProductId]
+		if !found {
+			item = entity.CartItem{
+				CartId:    cart.Id,
+				ProductId: guestItem.ProductId,
+				Price:     guestItem.Price,
+			}
+		}
+		quantity := item.Quantity + guestItem.Quantity
+		if quantity > product.Stock {
+			quantity = product.Stock
+		}
+		if quantity <= item.Quantity {
+			continue
+		}
+		item.Quantity = quantity
+		mergedItems = append(mergedItems, item)
+	}
+
+	coupons, err := cartService.PromotionRepository.FindCartCoupons(ctx, guestCart.Id)
+	if err != nil {
+		return model.CartModel{}, err
+	}
+	if err := cartService.CartRepository.MergeCart(ctx, guestCart.Id, mergedItems); err != nil {
+		return model.CartModel{}, err
+	}
+	for _, coupon := range coupons {
+		if err := cartService.PromotionRepository.AddCartCoupon(ctx, cart.Id, coupon.Id); err != nil {
+			return model.CartModel{}, err
+		}
+	}
+	cartService.touchCart(ctx, cart.Id)
+	return cartService.GetCart(ctx, owner)
+}
+
+// FindCarts lists the carts of a customer, default cart first, and their save-for-later list.
+func (cartService *cartServiceImpl) FindCarts(ctx context.Context, userId uint) ([]model.CartSummaryModel, error) {
+	carts, err := cartService.CartRepository.FindCartsByUserId(ctx, userId)
+	if err != nil {
+		return []model.CartSummaryModel{}, err
+	}
+
+	responses := []model.CartSummaryModel{}
+	for i, cart := range carts {
+		responses = append(responses, newCartSummaryModel(cart, i == 0 && cart.Type == "cart"))
+	}
+	return responses, nil
+}
+
+// CreateCart adds a named cart. A customer's first cart becomes their default one.
+func (cartService *cartServiceImpl) CreateCart(ctx context.Context, userId uint, request model.CartCreateOrUpdateModel) (model.CartSummaryModel, error) {
+	common.Validate(request)
+	name := strings.TrimSpace(request.Name)
+
+	carts, err := cartService.CartRepository.FindCartsByUserId(ctx, userId)
+	if err != nil {
+		return model.CartSummaryModel{}, err
+	}
+	if err := checkCartName(carts, 0, name); err != nil {
+		return model.CartSummaryModel{}, err
+	}
+
+	cart, err := cartService.CartRepository.CreateCart(ctx, entity.Cart{
+		UserId: &userId,
+		Name:   name,
+		Type:   "cart",
+	})
+	if err != nil {
+		return model.CartSummaryModel{}, err
+	}
+	return newCartSummaryModel(cart, len(carts) == 0 || carts[0].Type != "cart"), nil
+}
+
+func (cartService *cartServiceImpl) RenameCart(ctx context.Context, userId uint, cartId uint, request model.CartCreateOrUpdateModel) (model.CartSummaryModel, error) {
+	common.Validate(request)
+	name := strings.TrimSpace(request.Name)
+
+	carts, err := cartService.CartRepository.FindCartsByUserId(ctx, userId)
+	if err != nil {
+		return model.CartSummaryModel{}, err
+	}
+	for i, cart := range carts {
+		if cart.Id != cartId {
+			continue
+		}
+		if cart.Type != "cart" {
+			return model.CartSummaryModel{}, errors.New("the save-for-later list cannot be renamed")
+		}
+		if err := checkCartName(carts, cartId, name); err != nil {
+			return model.CartSummaryModel{}, err
+		}
+		if err := cartService.CartRepository.UpdateCartName(ctx, cartId, name); err != nil {
+			return model.CartSummaryModel{}, err
+		}
+		cart.Name = name
+		return newCartSummaryModel(cart, i == 0), nil
+	}
+	return model.CartSummaryModel{}, errors.New("cart not found")
+}
+
+// DeleteCart deletes a named cart with its items. The default cart and the save-for-later list stay.
+func (cartService *cartServiceImpl) DeleteCart(ctx context.Context, userId uint, cartId uint) error {
+	cart, err := cartService.findCustomerCart(ctx, userId, cartId)
+	if err != nil {
+		return err
+	}
+	if cart.Type != "cart" {
+		return errors.New("the save-for-later list cannot be deleted")
+	}
+	defaultCart, err := cartService.CartRepository.GetCartByUserId(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if defaultCart.Id == cart.Id {
+		return errors.New("the default cart cannot be deleted")
+	}
+	return cartService.CartRepository.DeleteCart(ctx, cartId)
+}
+
+// GetSavedItems lists the save-for-later list of a customer at today's prices.
+func (cartService *cartServiceImpl) GetSavedItems(ctx context.Context, userId uint) ([]model.CartItemModel, error) {
+	savedList, err := cartService.CartRepository.GetOrCreateSavedList(ctx, userId)
+	if err != nil {
+		return []model.CartItemModel{}, err
+	}
+
+	items := []model.CartItemModel{}
+	for _, item := range savedList.CartItems {
+		items = append(items, model.CartItemModel{
+			Id:        item.Id,
+			CartId:    item.CartId,
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+			Price:     item.Product.Price,
+			CreatedAt: item.CreatedAt.String(),
+			Product: model.ProductModel{
+				Id:          strconv.FormatUint(uint64(item.Product.Id), 10),
+				Name:        item.Product.Name,
+				Description: item.Product.Description,
+				Price:       item.Product.Price,
+				Stock:       item.Product.Stock,
+				ImageUrl:    item.Product.ImageUrl,
+			},
+		})
+	}
+	return items, nil
+}
+
+// MoveCartItem moves an item of a cart, or of the save-for-later list, to another cart of the customer
+// or to their save-for-later list. Items moved out of the save-for-later list take today's price,
+// like items added again; items moved between carts keep theirs. A product the target cart already
+// has gets the summed quantity, capped at the stock available like when merging a guest cart.
+func (cartService *cartServiceImpl) MoveCartItem(ctx context.Context, owner model.CartOwnerModel, cartItemId uint, request model.MoveCartItemModel) (model.MoveCartItemResultModel, error) {
+	common.Validate(request)
+	if owner.UserId == 0 {
+		return model.MoveCartItemResultModel{}, errors.New("log in to keep several carts")
+	}
+
+	var cartItem entity.CartItem
+	result := cartService.DB.WithContext(ctx).
+		Preload("Product").
+		Where("id = ?", cartItemId).
+		First(&cartItem)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return model.MoveCartItemResultModel{}, errors.New("cart item not found")
+		}
+		return model.MoveCartItemResultModel{}, result.Error
+	}
+	source, err := cartService.findCustomerCart(ctx, owner.UserId, cartItem.CartId)
+	if err != nil {
+		return model.MoveCartItemResultModel{}, errors.New("cart item not found")
+	}
+
+	var target entity.Cart
+	if request.SaveForLater {
+		target, err = cartService.CartRepository.GetOrCreateSavedList(ctx, owner.UserId)
+	} else {
+		target, err = cartService.findCustomerCart(ctx, owner.UserId, request.CartId)
+	}
+	if err != nil {
+		return model.MoveCartItemResultModel{}, err
+	}
+	if target.Id == source.Id {
+		return model.MoveCartItemResultModel{}, errors.New("the item is already in this cart")
+	}
+
+	if source.Type == "saved" {
+		cartItem.Price = cartItem.Product.Price
+	}
+	quantity, limited, err := cartService.CartRepository.MoveCartItem(ctx, cartItem, target.Id, cartItem.Product.Stock)
+	if err != nil {
+		return model.MoveCartItemResultModel{}, err
+	}
+	cartService.touchCart(ctx, source.Id)
+	cartService.touchCart(ctx, target.Id)
+	return model.MoveCartItemResultModel{CartId: target.Id, Quantity: quantity, Limited: limited}, nil
+}
+
+// checkCartName rejects an empty name, or one that another cart of the customer has.
+func checkCartName(carts []entity.Cart, cartId uint, name string) error {
+	if name == "" {
+		return errors.New("cart name is required")
+	}
+	for _, cart := range carts {
+		if cart.Id != cartId && cart.Type == "cart" && strings.EqualFold(cart.Name, name) {
+			return errors.New("you already have a cart named " + name)
+		}
+	}
+	return nil
+}
+
+func newCartSummaryModel(cart entity.Cart, isDefault bool) model.CartSummaryModel {
+	summary := model.CartSummaryModel{
+		Id:        cart.Id,
+		Name:      cart.Name,
+		Type:      cart.Type,
+		Default:   isDefault,
+		ItemCount: len(cart.CartItems),
+		CreatedAt: cart.CreatedAt.String(),
+	}
+	for _, item := range cart.CartItems {
+		summary.Quantity += item.Quantity
+	}
+	return summary
+}
+
+// touchCart records a change to the items or coupons of a cart, so a cart being worked on is not
+// reminded of as abandoned. Failing to record it only delays the reminders, so it does not fail the
+// change.
+func (cartService *cartServiceImpl) touchCart(ctx context.Context, cartId uint) {
+	if err := cartService.CartRepository.TouchCart(ctx, cartId, time.Now()); err != nil {
+		common.NewLogger().Error("Failed to record activity of cart ", cartId, ": ", err.Error())
+	}
+}
+
+// findCart returns the cart of a customer or of a guest.
+func (cartService *cartServiceImpl) findCart(ctx context.Context, owner model.CartOwnerModel) (entity.Cart, error) {
+	if owner.CartId != 0 {
+		return cartService.findCustomerCart(ctx, owner.UserId, owner.CartId)
+	}
+	if owner.GuestId != "" {
+		return cartService.CartRepository.GetCartByGuestId(ctx, owner.GuestId)
+	}
+	return cartService.CartRepository.GetCartByUserId(ctx, owner.UserId)
+}
+
+func (cartService *cartServiceImpl) getOrCreateCart(ctx context.Context, owner model.CartOwnerModel) (entity.Cart, error) {
+	if owner.CartId != 0 {
+		return cartService.findCustomerCart(ctx, owner.UserId, owner.CartId)
+	}
+	if owner.GuestId != "" {
+		return cartService.CartRepository.GetOrCreateGuestCart(ctx, owner.GuestId)
+	}
+	return cartService.CartRepository.GetOrCreateCart(ctx, owner.UserId)
+}
+
+// findCustomerCart returns one of the carts, or the save-for-later list, of a customer. Guests only
+// have their one cart.
+func (cartService *cartServiceImpl) findCustomerCart(ctx context.Context, userId uint, cartId uint) (entity.Cart, error) {
+	cart, err := cartService.CartRepository.GetCartById(ctx, cartId)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+	if userId == 0 || cart.UserId == nil || *cart.UserId != userId {
+		return entity.Cart{}, errors.New("cart not found")
+	}
+	return cart, nil
 }
\ No newline at end of file
@@ -0,0 +1,135 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+)
+
+// Prices, carts and orders are kept in KES; the display currencies convert each amount at their rate
+// and round it on its own, so converted lines can be a unit off their converted total.
+
+func convertAmount(amount money.Money, currency entity.Currency) money.Money {
+	return amount.Convert(currency.Code, currency.Rate, currency.Decimals)
+}
+
+// convertOptionalAmount converts the amounts models leave out when there are none.
+func convertOptionalAmount(amount *money.Money, currency entity.Currency) *money.Money {
+	if amount == nil {
+		return nil
+	}
+	converted := convertAmount(*amount, currency)
+	return &converted
+}
+
+func convertProductModel(product model.ProductModel, currency entity.Currency) model.ProductModel {
+	product.Price = convertAmount(product.Price, currency)
+	product.OriginalPrice = convertOptionalAmount(product.OriginalPrice, currency)
+	product.Currency = currency.Code
+	return product
+}
+
+func convertCartModel(cart model.CartModel, currency entity.Currency) model.CartModel {
+	items := make([]model.CartItemModel, len(cart.Items))
+	for i, item := range cart.Items {
+		item.Price = convertAmount(item.Price, currency)
+		item.DiscountAmount = convertAmount(item.DiscountAmount, currency)
+		item.TaxAmount = convertAmount(item.TaxAmount, currency)
+		item.Product = convertProductModel(item.Product, currency)
+		items[i] = item
+	}
+	warnings := make([]model.CartWarningModel, len(cart.Warnings))
+	for i, warning := range cart.Warnings {
+		warning.OldPrice = convertOptionalAmount(warning.OldPrice, currency)
+		warning.NewPrice = convertOptionalAmount(warning.NewPrice, currency)
+		warnings[i] = warning
+	}
+
+	cart.Items = items
+	cart.Warnings = warnings
+	cart.Subtotal = convertAmount(cart.Subtotal, currency)
+	cart.Discounts = convertDiscountModels(cart.Discounts, currency)
+	cart.DiscountTotal = convertAmount(cart.DiscountTotal, currency)
+	cart.Taxes = convertTaxModels(cart.Taxes, currency)
+	cart.TaxTotal = convertAmount(cart.TaxTotal, currency)
+	cart.Total = convertAmount(cart.Total, currency)
+	cart.Currency = currency.Code
+	return cart
+}
+
+func convertShippingQuoteModel(quote model.ShippingQuoteModel, currency entity.Currency) model.ShippingQuoteModel {
+	options := make([]model.ShippingOptionModel, len(quote.Options))
+	for i, option := range quote.Options {
+		option.Cost = convertAmount(option.Cost, currency)
+		option.OriginalCost = convertOptionalAmount(option.OriginalCost, currency)
+		options[i] = option
+	}
+
+	quote.Options = options
+	quote.OrderValue = convertAmount(quote.OrderValue, currency)
+	quote.Currency = currency.Code
+	return quote
+}
+
+// convertOrderModel shows an order in the currency it was placed in, at the rate locked on the order.
+// BaseTotal stays the KES amount that is paid.
+func convertOrderModel(orderModel model.OrderModel, order entity.Order) model.OrderModel {
+	currency := orderCurrency(order)
+	orderModel.Currency = currency.Code
+	orderModel.ExchangeRate = currency.Rate
+	orderModel.BaseTotal = order.Total
+	if currency.Code == money.BaseCurrency {
+		return orderModel
+	}
+
+	items := make([]model.OrderItemModel, len(orderModel.OrderItems))
+	for i, item := range orderModel.OrderItems {
+		item.Price = convertAmount(item.Price, currency)
+		item.DiscountAmount = convertAmount(item.DiscountAmount, currency)
+		item.TaxAmount = convertAmount(item.TaxAmount, currency)
+		item.Product = convertProductModel(item.Product, currency)
+		items[i] = item
+	}
+	if orderModel.Shipping != nil {
+		shipping := *orderModel.Shipping
+		shipping.Cost = convertAmount(shipping.Cost, currency)
+		orderModel.Shipping = &shipping
+	}
+
+	orderModel.OrderItems = items
+	orderModel.Subtotal = convertAmount(orderModel.Subtotal, currency)
+	orderModel.Discounts = convertDiscountModels(orderModel.Discounts, currency)
+	orderModel.DiscountTotal = convertAmount(orderModel.DiscountTotal, currency)
+	orderModel.Taxes = convertTaxModels(orderModel.Taxes, currency)
+	orderModel.TaxTotal = convertAmount(orderModel.TaxTotal, currency)
+	orderModel.ShippingCost = convertAmount(orderModel.ShippingCost, currency)
+	orderModel.Total = convertAmount(orderModel.Total, currency)
+	return orderModel
+}
+
+// orderCurrency is the currency locked on an order; orders placed before currencies existed are in KES.
+func orderCurrency(order entity.Order) entity.Currency {
+	if order.Currency == "" || order.ExchangeRate == 0 {
+		return entity.Currency{Code: money.BaseCurrency, Rate: 1, Decimals: 2}
+	}
+	return entity.Currency{Code: order.Currency, Rate: order.ExchangeRate, Decimals: order.CurrencyDecimals}
+}
+
+func convertDiscountModels(discounts []model.DiscountModel, currency entity.Currency) []model.DiscountModel {
+	converted := make([]model.DiscountModel, len(discounts))
+	for i, discount := range discounts {
+		discount.Amount = convertAmount(discount.Amount, currency)
+		converted[i] = discount
+	}
+	return converted
+}
+
+func convertTaxModels(taxes []model.TaxBreakdownModel, currency entity.Currency) []model.TaxBreakdownModel {
+	converted := make([]model.TaxBreakdownModel, len(taxes))
+	for i, tax := range taxes {
+		tax.NetAmount = convertAmount(tax.NetAmount, currency)
+		tax.TaxAmount = convertAmount(tax.TaxAmount, currency)
+		converted[i] = tax
+	}
+	return converted
+}
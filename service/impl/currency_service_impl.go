@@ -0,0 +1,158 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
+	"strings"
+	"time"
+)
+
+func NewCurrencyServiceImpl(currencyRepository *repository.CurrencyRepository, cache *redis.Client) service.CurrencyService {
+	return &currencyServiceImpl{CurrencyRepository: *currencyRepository, Cache: cache}
+}
+
+type currencyServiceImpl struct {
+	repository.CurrencyRepository
+	Cache *redis.Client
+}
+
+func (currencyService *currencyServiceImpl) Create(ctx context.Context, request model.CurrencyCreateOrUpdateModel) model.CurrencyModel {
+	common.Validate(request)
+	if request.Code == "" {
+		panic(common.NewValidationError("Code", "this field is required"))
+	}
+	if _, err := currencyService.CurrencyRepository.FindByCode(ctx, request.Code); err == nil {
+		panic(common.NewValidationError("Code", "this field is unique"))
+	}
+
+	currency, err := currencyService.CurrencyRepository.Insert(ctx, newCurrency(request.Code, request))
+	exception.PanicLogging(err)
+	return newCurrencyModel(currency)
+}
+
+// Update changes the rate of a currency for the catalogue, carts and new orders; placed orders keep the
+// rate they were placed at.
+func (currencyService *currencyServiceImpl) Update(ctx context.Context, code string, request model.CurrencyCreateOrUpdateModel) model.CurrencyModel {
+	common.Validate(request)
+	if _, err := currencyService.CurrencyRepository.FindByCode(ctx, code); err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+	currency := newCurrency(code, request)
+	if code == money.BaseCurrency && (currency.Rate != 1 || !currency.Active) {
+		panic(common.NewValidationError("Rate", "the base currency has a rate of 1 and stays active"))
+	}
+
+	currency, err := currencyService.CurrencyRepository.Update(ctx, currency)
+	exception.PanicLogging(err)
+
+	// Catalogue pages in this currency are cached with the old rate
+	invalidateCatalogue(currencyService.Cache, ctx)
+	return newCurrencyModel(currency)
+}
+
+func (currencyService *currencyServiceImpl) FindAll(ctx context.Context) []model.CurrencyModel {
+	currencies, err := currencyService.CurrencyRepository.FindAll(ctx)
+	exception.PanicLogging(err)
+
+	responses := []model.CurrencyModel{}
+	for _, currency := range currencies {
+		responses = append(responses, newCurrencyModel(currency))
+	}
+	return responses
+}
+
+func (currencyService *currencyServiceImpl) ConvertProduct(ctx context.Context, code string, product model.ProductModel) model.ProductModel {
+	currency, convert := currencyService.displayCurrency(ctx, code)
+	if !convert {
+		return product
+	}
+	return convertProductModel(product, currency)
+}
+
+func (currencyService *currencyServiceImpl) ConvertProducts(ctx context.Context, code string, products []model.ProductModel) []model.ProductModel {
+	currency, convert := currencyService.displayCurrency(ctx, code)
+	if !convert {
+		return products
+	}
+	converted := make([]model.ProductModel, len(products))
+	for i, product := range products {
+		converted[i] = convertProductModel(product, currency)
+	}
+	return converted
+}
+
+func (currencyService *currencyServiceImpl) ConvertCart(ctx context.Context, code string, cart model.CartModel) (model.CartModel, error) {
+	currency, convert, err := currencyService.findDisplayCurrency(ctx, code)
+	if err != nil || !convert {
+		return cart, err
+	}
+	return convertCartModel(cart, currency), nil
+}
+
+func (currencyService *currencyServiceImpl) ConvertShippingQuote(ctx context.Context, code string, quote model.ShippingQuoteModel) (model.ShippingQuoteModel, error) {
+	currency, convert, err := currencyService.findDisplayCurrency(ctx, code)
+	if err != nil || !convert {
+		return quote, err
+	}
+	return convertShippingQuoteModel(quote, currency), nil
+}
+
+// displayCurrency is findDisplayCurrency for the panicking product endpoints.
+func (currencyService *currencyServiceImpl) displayCurrency(ctx context.Context, code string) (entity.Currency, bool) {
+	currency, convert, err := currencyService.findDisplayCurrency(ctx, code)
+	if err != nil {
+		panic(common.NewValidationError("currency", err.Error()))
+	}
+	return currency, convert
+}
+
+// findDisplayCurrency looks up the currency a client asked for. It reports false for KES, or when
+// no currency was asked for, as the amounts are already in KES.
+func (currencyService *currencyServiceImpl) findDisplayCurrency(ctx context.Context, code string) (entity.Currency, bool, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || code == money.BaseCurrency {
+		return entity.Currency{}, false, nil
+	}
+	currency, err := findActiveCurrency(ctx, currencyService.CurrencyRepository, code)
+	return currency, err == nil, err
+}
+
+// findActiveCurrency is a currency that customers can see prices and place orders in.
+func findActiveCurrency(ctx context.Context, currencyRepository repository.CurrencyRepository, code string) (entity.Currency, error) {
+	currency, err := currencyRepository.FindByCode(ctx, code)
+	if err != nil || !currency.Active {
+		return entity.Currency{}, errors.New("currency " + code + " is not supported")
+	}
+	return currency, nil
+}
+
+func newCurrency(code string, request model.CurrencyCreateOrUpdateModel) entity.Currency {
+	return entity.Currency{
+		Code:     code,
+		Name:     request.Name,
+		Rate:     request.Rate,
+		Decimals: request.Decimals,
+		Active:   request.Active == nil || *request.Active,
+	}
+}
+
+func newCurrencyModel(currency entity.Currency) model.CurrencyModel {
+	return model.CurrencyModel{
+		Code:      currency.Code,
+		Name:      currency.Name,
+		Rate:      currency.Rate,
+		Decimals:  currency.Decimals,
+		Active:    currency.Active,
+		UpdatedAt: currency.UpdatedAt.Format(time.RFC3339),
+	}
+}
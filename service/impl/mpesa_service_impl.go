@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"errors"
+	"github.com/tech-hive/ecommerce/common"
 	"github.com/tech-hive/ecommerce/configuration"
 	"github.com/tech-hive/ecommerce/entity"
 	"github.com/tech-hive/ecommerce/model"
@@ -43,6 +44,16 @@ func (mpesaService *mpesaServiceImpl) InitiateSTKPush(ctx context.Context, reque
 		return model.MpesaPaymentResponse{}, errors.New("order is already paid")
 	}
 
+	// Cancelled orders, including the ones that expired unpaid, have released their stock
+	if order.Status == "cancelled" {
+		return model.MpesaPaymentResponse{}, errors.New("order is cancelled")
+	}
+
+	// The customer pays the order total to the cent
+	if request.Amount != order.Total {
+		return model.MpesaPaymentResponse{}, errors.New("amount does not match the order total of " + order.Total.String())
+	}
+
 	// Generate M-Pesa request (for reference, actual request is simulated)
 	// timestamp := mpesaService.GenerateTimestamp()
 	// password := mpesaService.GeneratePassword()
@@ -53,12 +64,12 @@ func (mpesaService *mpesaServiceImpl) InitiateSTKPush(ctx context.Context, reque
 	//     Password:          password,
 	//     Timestamp:         timestamp,
 	//     TransactionType:   "CustomerPayBillOnline",
-	//     Amount:            request.Amount,
+	//     Amount:            order.Total.Units(),
 	//     PartyA:            request.PhoneNumber,
 	//     PartyB:            mpesaService.Config.Get("MPESA_SHORTCODE"),
 	//     PhoneNumber:       request.PhoneNumber,
 	//     CallBackURL:       mpesaService.Config.Get("MPESA_CALLBACK_URL"),
-	//     AccountReference:  fmt.Sprintf("Order #%d", request.OrderId),
+	//     AccountReference:  common.OrderAccountReference(order.Number),
 	//     TransactionDesc:   "Payment for order",
 	// }
 
@@ -86,6 +97,7 @@ func (mpesaService *mpesaServiceImpl) InitiateSTKPush(ctx context.Context, reque
 		ResponseCode:      responseCode,
 		ResponseMessage:   responseMessage,
 		CustomerMessage:   customerMessage,
+		AccountReference:  common.OrderAccountReference(order.Number),
 	}
 
 	// Create payment record regardless of response code
@@ -133,14 +145,48 @@ func (mpesaService *mpesaServiceImpl) ProcessCallback(ctx context.Context, callb
 
 	// Update payment status based on callback
 	if callback.ResultCode == 0 {
-		// Payment successful
-		mpesaService.DB.WithContext(ctx).Model(&payment).Updates(map[string]interface{}{
-			"status":  "success",
-			"paid_at": time.Now(),
-		})
-
-		// Update order status to confirmed
-		mpesaService.OrderRepository.UpdateOrderStatus(ctx, payment.OrderId, "confirmed")
+		// M-Pesa repeats callbacks it got no answer to
+		if payment.Status == "success" {
+			return nil
+		}
+
+		// Payment successful. The update only applies while the payment is pending, so it cannot
+		// race the order expiry job, which cancels the pending payments of the orders it cancels
+		paid := map[string]interface{}{
+			"status":         "success",
+			"receipt_number": callbackValue(callback, "MpesaReceiptNumber"),
+			"paid_at":        time.Now(),
+		}
+		result := mpesaService.DB.WithContext(ctx).Model(&entity.Payment{}).
+			Where("id = ? AND status = ?", payment.Id, "pending").
+			Updates(paid)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// A payment completed after its order expired does not revive the order; its stock is back on
+		// sale. The money received is still recorded, to be refunded
+		if result.RowsAffected == 0 {
+			if err := mpesaService.DB.WithContext(ctx).Model(&entity.Payment{}).Where("id = ?", payment.Id).Updates(paid).Error; err != nil {
+				return err
+			}
+			common.NewLogger().Error("Payment ", payment.TransactionId, " completed after order ", payment.OrderId, " expired; refund it")
+			return nil
+		}
+
+		// Confirm the order, unless the customer cancelled it meanwhile
+		result = mpesaService.DB.WithContext(ctx).Model(&entity.Order{}).
+			Where("id = ? AND status = ?", payment.OrderId, "pending").
+			Update("status", "confirmed")
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var order entity.Order
+			if err := mpesaService.DB.WithContext(ctx).Select("id", "status").Where("id = ?", payment.OrderId).First(&order).Error; err == nil && order.Status == "cancelled" {
+				common.NewLogger().Error("Payment ", payment.TransactionId, " completed after order ", payment.OrderId, " was cancelled; refund it")
+			}
+		}
 	} else {
 		// Payment failed
 		mpesaService.DB.WithContext(ctx).Model(&payment).Update("status", "failed")
@@ -189,6 +235,20 @@ func (mpesaService *mpesaServiceImpl) simulatePaymentCompletion(ctx context.Cont
 }
 
 // Helper functions
+
+// callbackValue is an item of the callback metadata M-Pesa sends for successful payments, or nil.
+func callbackValue(callback model.MpesaCallbackRequest, name string) *string {
+	if callback.CallbackMetadata == nil {
+		return nil
+	}
+	for _, item := range callback.CallbackMetadata.Item {
+		if item.Name == name {
+			return &item.Value
+		}
+	}
+	return nil
+}
+
 func generateRandomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, length)
@@ -0,0 +1,184 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
+	"strings"
+	"time"
+)
+
+func NewOrderAdminServiceImpl(orderRepository *repository.OrderRepository, noteRepository *repository.OrderNoteRepository, promotionRepository *repository.PromotionRepository, cache *redis.Client) service.OrderAdminService {
+	return &orderAdminServiceImpl{
+		OrderRepository:     *orderRepository,
+		OrderNoteRepository: *noteRepository,
+		PromotionRepository: *promotionRepository,
+		Cache:               cache,
+	}
+}
+
+type orderAdminServiceImpl struct {
+	repository.OrderRepository
+	repository.OrderNoteRepository
+	repository.PromotionRepository
+	Cache *redis.Client
+}
+
+func (adminService *orderAdminServiceImpl) FindAll(ctx context.Context, search model.OrderSearchModel) ([]model.AdminOrderModel, model.PageInfoModel) {
+	common.Validate(search)
+	if search.Number != "" {
+		number, err := common.ParseOrderNumber(search.Number)
+		if err != nil {
+			panic(common.NewValidationError("Number", err.Error()))
+		}
+		search.Number = number
+	}
+	if search.From != "" && search.To != "" && search.From > search.To {
+		panic(common.NewValidationError("From", "must not be after to"))
+	}
+	if search.MinTotal.IsPositive() && search.MaxTotal.IsPositive() && search.MinTotal.GreaterThan(search.MaxTotal) {
+		panic(common.NewValidationError("MinTotal", "must not be more than max_total"))
+	}
+	search.Customer = strings.TrimSpace(search.Customer)
+
+	orders, pageInfo, err := adminService.OrderRepository.FindAll(ctx, search)
+	exception.PanicLogging(err)
+
+	responses := []model.AdminOrderModel{}
+	for _, order := range orders {
+		responses = append(responses, newAdminOrderModel(order))
+	}
+	return responses, pageInfo
+}
+
+// FindById returns any customer's order with its internal notes.
+func (adminService *orderAdminServiceImpl) FindById(ctx context.Context, orderId uint) model.AdminOrderModel {
+	order := adminService.findOrder(ctx, orderId)
+	notes, err := adminService.OrderNoteRepository.FindByOrderId(ctx, orderId)
+	exception.PanicLogging(err)
+
+	response := newAdminOrderModel(order)
+	response.Notes = []model.OrderNoteModel{}
+	for _, note := range notes {
+		response.Notes = append(response.Notes, newOrderNoteModel(note))
+	}
+	return response
+}
+
+func (adminService *orderAdminServiceImpl) AddNote(ctx context.Context, orderId uint, authorId uint, request model.OrderNoteCreateModel) model.OrderNoteModel {
+	request.Body = strings.TrimSpace(request.Body)
+	common.Validate(request)
+	adminService.findOrder(ctx, orderId)
+
+	note, err := adminService.OrderNoteRepository.Insert(ctx, entity.OrderNote{
+		OrderId:  orderId,
+		AuthorId: &authorId,
+		Body:     request.Body,
+	})
+	exception.PanicLogging(err)
+	return newOrderNoteModel(note)
+}
+
+// UpdateStatuses sets the status of several orders at once and reports the orders it did not change.
+// Cancelling orders puts their stock back and gives back their promotion uses, as expiring them does.
+func (adminService *orderAdminServiceImpl) UpdateStatuses(ctx context.Context, request model.OrderBulkStatusModel) model.OrderBulkStatusResultModel {
+	common.Validate(request)
+
+	var orderIds []uint
+	requested := map[uint]bool{}
+	for _, orderId := range request.OrderIds {
+		if !requested[orderId] {
+			requested[orderId] = true
+			orderIds = append(orderIds, orderId)
+		}
+	}
+
+	result, restocked, err := adminService.OrderRepository.UpdateOrderStatuses(ctx, orderIds, request.Status)
+	exception.PanicLogging(err)
+
+	if request.Status == "cancelled" && len(result.Updated) > 0 {
+		for _, orderId := range result.Updated {
+			if err := adminService.PromotionRepository.ReleaseRedemptions(ctx, orderId); err != nil {
+				common.NewLogger().Error("Failed to release promotion redemptions of order ", orderId, ": ", err.Error())
+			}
+		}
+		evictProducts(adminService.Cache, ctx, restocked...)
+		invalidateCatalogue(adminService.Cache, ctx)
+	}
+
+	found := map[uint]bool{}
+	for _, orderId := range append(result.Updated, result.Unchanged...) {
+		found[orderId] = true
+	}
+	for _, refusal := range result.Refused {
+		found[refusal.OrderId] = true
+	}
+	result.NotFound = []uint{}
+	for _, orderId := range orderIds {
+		if !found[orderId] {
+			result.NotFound = append(result.NotFound, orderId)
+		}
+	}
+	return result
+}
+
+func (adminService *orderAdminServiceImpl) findOrder(ctx context.Context, orderId uint) entity.Order {
+	order, err := adminService.OrderRepository.GetOrderById(ctx, orderId)
+	if err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+	return order
+}
+
+func newAdminOrderModel(order entity.Order) model.AdminOrderModel {
+	response := model.AdminOrderModel{
+		OrderModel:    newOrderModel(order),
+		PaymentStatus: orderPaymentStatus(order),
+	}
+	if order.User != nil {
+		response.Customer = &model.OrderCustomerModel{
+			Id:    order.User.Id,
+			Name:  order.User.Name,
+			Email: order.User.Email,
+		}
+	}
+	return response
+}
+
+// orderPaymentStatus tells how far the payment of an order got: paid, pending while an attempt is
+// open, failed when every attempt failed, or none.
+func orderPaymentStatus(order entity.Order) string {
+	status := "none"
+	for _, payment := range order.Payments {
+		switch {
+		case payment.Status == "success":
+			return "paid"
+		case payment.Status == "pending":
+			status = "pending"
+		case status == "none":
+			status = "failed"
+		}
+	}
+	return status
+}
+
+func newOrderNoteModel(note entity.OrderNote) model.OrderNoteModel {
+	response := model.OrderNoteModel{
+		Id:        note.Id,
+		OrderId:   note.OrderId,
+		AuthorId:  note.AuthorId,
+		Body:      note.Body,
+		CreatedAt: note.CreatedAt.Format(time.RFC3339),
+	}
+	if note.Author != nil {
+		response.AuthorName = note.Author.Name
+	}
+	return response
+}
@@ -0,0 +1,225 @@
+package impl
+
+import (
+	"fmt"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/pdf"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout of invoices and receipts on A4, in points from the top-left corner.
+const (
+	documentLeft       = 40.0
+	documentRight      = pdf.PageWidth - 40
+	documentBottom     = pdf.PageHeight - 60
+	documentRowHeight  = 16.0
+	documentDateFormat = "2 Jan 2006"
+)
+
+// Right edges of the item table columns after the item name.
+var documentColumns = []struct {
+	title string
+	right float64
+}{
+	{"Qty", 300},
+	{"Unit price", 370},
+	{"VAT rate", 425},
+	{"VAT", 485},
+	{"Amount", documentRight},
+}
+
+// renderOrderDocument lays out the invoice, or the receipt of payment, of an order. Amounts are in
+// KES, the currency orders are paid in; orders placed in another currency also show their total in it.
+func renderOrderDocument(issuer configuration.InvoiceIssuer, order entity.Order, documentType string, number string, issuedAt time.Time, payment *entity.Payment) []byte {
+	title := "Tax Invoice"
+	if documentType == "receipt" {
+		title = "Receipt"
+	}
+	document := pdf.New(title + " " + number)
+	document.AddPage()
+
+	// Issuer and document details
+	document.Text(documentLeft, 60, pdf.Bold, 16, issuer.Name)
+	document.TextRight(documentRight, 60, pdf.Bold, 18, strings.ToUpper(title))
+	y := 76.0
+	for _, line := range []string{issuer.Address, taxPinLine(issuer.TaxPin)} {
+		if line != "" {
+			document.Text(documentLeft, y, pdf.Regular, 9, line)
+			y += 12
+		}
+	}
+	details := [][2]string{
+		{title + " no.", number},
+		{"Date", issuedAt.Format(documentDateFormat)},
+		{"Order no.", order.Number},
+		{"Order date", order.CreatedAt.Format(documentDateFormat)},
+	}
+	for i, detail := range details {
+		detailY := 80 + float64(i)*13
+		document.Text(380, detailY, pdf.Regular, 9, detail[0])
+		document.TextRight(documentRight, detailY, pdf.Bold, 9, detail[1])
+	}
+
+	// Customer and delivery address
+	y = 150
+	document.Text(documentLeft, y, pdf.Bold, 10, "Bill to")
+	document.Text(300, y, pdf.Bold, 10, "Ship to")
+	var billTo []string
+	if order.User != nil {
+		billTo = []string{order.User.Name, order.User.Email}
+	}
+	shipTo := orderShipTo(order)
+	for i := 0; i < len(billTo) || i < len(shipTo); i++ {
+		lineY := y + 14 + float64(i)*12
+		if i < len(billTo) {
+			document.Text(documentLeft, lineY, pdf.Regular, 9, pdf.Truncate(billTo[i], pdf.Regular, 9, 250))
+		}
+		if i < len(shipTo) {
+			document.Text(300, lineY, pdf.Regular, 9, pdf.Truncate(shipTo[i], pdf.Regular, 9, documentRight-300))
+		}
+	}
+
+	// Items
+	y = 240
+	documentTableHeader(document, y)
+	y += documentRowHeight
+	for _, item := range order.OrderItems {
+		if y > documentBottom {
+			document.AddPage()
+			y = 60
+			documentTableHeader(document, y)
+			y += documentRowHeight
+		}
+		name := pdf.Truncate(item.Product.Name, pdf.Regular, 9, documentColumns[0].right-40-documentLeft)
+		document.Text(documentLeft, y, pdf.Regular, 9, name)
+		values := []string{
+			strconv.Itoa(int(item.Quantity)),
+			formatAmount(item.Price),
+			formatRate(item.TaxRate),
+			formatAmount(item.TaxAmount),
+			formatAmount(item.Price.Mul(item.Quantity)),
+		}
+		for i, value := range values {
+			document.TextRight(documentColumns[i].right, y, pdf.Regular, 9, value)
+		}
+		y += documentRowHeight
+	}
+	document.Line(documentLeft, y-10, documentRight, y-10, 0.5)
+
+	// Totals
+	totals := [][2]string{{"Subtotal", formatAmount(order.Subtotal)}}
+	for _, discount := range order.Discounts {
+		label := discount.Name
+		if discount.Code != nil {
+			label += " (" + *discount.Code + ")"
+		}
+		totals = append(totals, [2]string{label, "-" + formatAmount(discount.Amount)})
+	}
+	if order.ShippingMethod != nil {
+		totals = append(totals, [2]string{"Shipping (" + *order.ShippingMethod + ")", formatAmount(order.ShippingCost)})
+	}
+	for _, tax := range order.Taxes {
+		label := fmt.Sprint(tax.Name, " ", formatRate(tax.Rate), " on ", formatAmount(tax.NetAmount))
+		if tax.Exempt {
+			label = tax.Name + " on " + formatAmount(tax.NetAmount)
+		}
+		if order.PricesIncludeTax {
+			label += ", included"
+		}
+		totals = append(totals, [2]string{label, formatAmount(tax.TaxAmount)})
+	}
+	if y+float64(len(totals)+4)*documentRowHeight > documentBottom {
+		document.AddPage()
+		y = 60
+	}
+	y += 4
+	for _, total := range totals {
+		document.TextRight(documentColumns[2].right+60, y, pdf.Regular, 9, pdf.Truncate(total[0], pdf.Regular, 9, 300))
+		document.TextRight(documentRight, y, pdf.Regular, 9, total[1])
+		y += 14
+	}
+	document.TextRight(documentColumns[2].right+60, y+2, pdf.Bold, 11, "Total (KES)")
+	document.TextRight(documentRight, y+2, pdf.Bold, 11, formatAmount(order.Total))
+	y += 18
+	if currency := orderCurrency(order); currency.Code != money.BaseCurrency {
+		converted := fmt.Sprint("Total in ", currency.Code, " at ", strconv.FormatFloat(currency.Rate, 'f', -1, 64), " per KES: ",
+			formatAmount(convertAmount(order.Total, currency)))
+		document.TextRight(documentRight, y, pdf.Regular, 8, converted)
+		y += 14
+	}
+
+	// Payment
+	if payment != nil {
+		if y+5*documentRowHeight > documentBottom {
+			document.AddPage()
+			y = 60
+		}
+		y += 16
+		document.Text(documentLeft, y, pdf.Bold, 10, "Payment received")
+		lines := []string{"Method: M-Pesa"}
+		if payment.ReceiptNumber != nil {
+			lines = append(lines, "M-Pesa receipt no.: "+*payment.ReceiptNumber)
+		}
+		lines = append(lines,
+			"Paid on: "+payment.PaidAt.Format(documentDateFormat+" 15:04"),
+			"Amount paid: KES "+formatAmount(order.Total))
+		for _, line := range lines {
+			y += 13
+			document.Text(documentLeft, y, pdf.Regular, 9, line)
+		}
+	}
+
+	document.Text(documentLeft, pdf.PageHeight-40, pdf.Regular, 8, "Thank you for shopping with "+issuer.Name+".")
+	return document.Bytes()
+}
+
+func documentTableHeader(document *pdf.Document, y float64) {
+	document.Text(documentLeft, y, pdf.Bold, 9, "Item")
+	for _, column := range documentColumns {
+		document.TextRight(column.right, y, pdf.Bold, 9, column.title)
+	}
+	document.Line(documentLeft, y+5, documentRight, y+5, 0.5)
+}
+
+// orderShipTo is the delivery address of an order, one line per part.
+func orderShipTo(order entity.Order) []string {
+	var lines []string
+	for _, part := range []*string{order.ShippingAddress, order.ShippingTown, order.ShippingCounty} {
+		if part != nil && *part != "" {
+			lines = append(lines, *part)
+		}
+	}
+	if order.ShippingMethod != nil {
+		lines = append(lines, "Via "+*order.ShippingMethod)
+	}
+	return lines
+}
+
+func taxPinLine(taxPin string) string {
+	if taxPin == "" {
+		return ""
+	}
+	return "KRA PIN: " + taxPin
+}
+
+// formatAmount writes an amount with thousands separators, e.g. "12,500.00".
+func formatAmount(amount money.Money) string {
+	text := amount.String()
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	units, cents, _ := strings.Cut(text, ".")
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + "," + units[i:]
+	}
+	return sign + units + "." + cents
+}
+
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64) + "%"
+}
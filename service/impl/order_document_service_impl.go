@@ -0,0 +1,121 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"time"
+)
+
+func NewOrderDocumentServiceImpl(orderRepository *repository.OrderRepository, documentRepository *repository.OrderDocumentRepository, issuer configuration.InvoiceIssuer) service.OrderDocumentService {
+	return &orderDocumentServiceImpl{
+		OrderRepository:         *orderRepository,
+		OrderDocumentRepository: *documentRepository,
+		Issuer:                  issuer,
+	}
+}
+
+type orderDocumentServiceImpl struct {
+	repository.OrderRepository
+	repository.OrderDocumentRepository
+	Issuer configuration.InvoiceIssuer
+}
+
+// GetDocument returns the invoice or the payment receipt of a customer's order. A document is
+// issued, numbered and stored the first time it is asked for, and the stored one is returned from
+// then on. Cancelled orders get no new invoice or receipt, and unpaid orders no receipt.
+func (documentService *orderDocumentServiceImpl) GetDocument(ctx context.Context, orderId uint, userId uint, documentType string) (model.OrderDocumentModel, error) {
+	if documentType != "invoice" && documentType != "receipt" {
+		return model.OrderDocumentModel{}, errors.New("document type must be invoice or receipt")
+	}
+	order, err := documentService.OrderRepository.GetOrderById(ctx, orderId)
+	if err != nil {
+		return model.OrderDocumentModel{}, err
+	}
+	if order.UserId != userId {
+		return model.OrderDocumentModel{}, errors.New("order not found")
+	}
+	return documentService.issueDocument(ctx, order, documentType)
+}
+
+// GetAnyDocument returns the invoice or the payment receipt of any customer's order, for admins
+// and accountants. It issues documents as GetDocument does.
+func (documentService *orderDocumentServiceImpl) GetAnyDocument(ctx context.Context, orderId uint, documentType string) (model.OrderDocumentModel, error) {
+	if documentType != "invoice" && documentType != "receipt" {
+		return model.OrderDocumentModel{}, errors.New("document type must be invoice or receipt")
+	}
+	order, err := documentService.OrderRepository.GetOrderById(ctx, orderId)
+	if err != nil {
+		return model.OrderDocumentModel{}, err
+	}
+	return documentService.issueDocument(ctx, order, documentType)
+}
+
+// issueDocument returns the stored document of an order, or issues it.
+func (documentService *orderDocumentServiceImpl) issueDocument(ctx context.Context, order entity.Order, documentType string) (model.OrderDocumentModel, error) {
+	document, err := documentService.OrderDocumentRepository.FindByOrderId(ctx, order.Id, documentType)
+	if err == nil {
+		return newOrderDocumentModel(document), nil
+	}
+	if err.Error() != "document not found" {
+		return model.OrderDocumentModel{}, err
+	}
+
+	var payment *entity.Payment
+	switch documentType {
+	case "invoice":
+		if order.Status == "cancelled" {
+			return model.OrderDocumentModel{}, errors.New("cancelled orders have no invoice")
+		}
+	case "receipt":
+		// A payment that lands after the order was cancelled is refunded, not receipted
+		if order.Status == "cancelled" {
+			return model.OrderDocumentModel{}, errors.New("cancelled orders have no receipt")
+		}
+		payment = successfulPayment(order)
+		if payment == nil {
+			return model.OrderDocumentModel{}, errors.New("the order has not been paid")
+		}
+	}
+
+	document = entity.OrderDocument{Type: documentType, OrderId: order.Id}
+	if payment != nil {
+		document.PaymentId = &payment.Id
+	}
+	issuedAt := time.Now()
+	document, err = documentService.OrderDocumentRepository.Issue(ctx, document, func(number string) ([]byte, error) {
+		return renderOrderDocument(documentService.Issuer, order, documentType, number, issuedAt, payment), nil
+	})
+	if err != nil {
+		// Another request may have issued it meanwhile
+		if issued, findErr := documentService.OrderDocumentRepository.FindByOrderId(ctx, order.Id, documentType); findErr == nil {
+			return newOrderDocumentModel(issued), nil
+		}
+		return model.OrderDocumentModel{}, err
+	}
+	return newOrderDocumentModel(document), nil
+}
+
+// successfulPayment is the payment that paid for an order, if any.
+func successfulPayment(order entity.Order) *entity.Payment {
+	for i := range order.Payments {
+		if order.Payments[i].Status == "success" {
+			return &order.Payments[i]
+		}
+	}
+	return nil
+}
+
+func newOrderDocumentModel(document entity.OrderDocument) model.OrderDocumentModel {
+	return model.OrderDocumentModel{
+		Number:    document.Number,
+		Type:      document.Type,
+		OrderId:   document.OrderId,
+		Content:   document.Content,
+		CreatedAt: document.CreatedAt.Format(time.RFC3339),
+	}
+}
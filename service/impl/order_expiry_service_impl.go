@@ -0,0 +1,90 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/client"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
+	"time"
+)
+
+const orderExpiryBatchSize = 100
+
+func NewOrderExpiryServiceImpl(orderRepository *repository.OrderRepository, promotionRepository *repository.PromotionRepository, notifier *client.NotifierClient, cache *redis.Client, policy configuration.OrderExpiryPolicy) service.OrderExpiryService {
+	return &orderExpiryServiceImpl{
+		OrderRepository:     *orderRepository,
+		PromotionRepository: *promotionRepository,
+		NotifierClient:      *notifier,
+		Cache:               cache,
+		Policy:              policy,
+	}
+}
+
+type orderExpiryServiceImpl struct {
+	repository.OrderRepository
+	repository.PromotionRepository
+	client.NotifierClient
+	Cache  *redis.Client
+	Policy configuration.OrderExpiryPolicy
+}
+
+// ExpireUnpaid cancels the pending orders that were not paid within the payment timeout, puts their
+// stock back and tells the customers. It runs from the order expiry scheduler; an order that fails
+// to expire is tried again on the next run.
+func (expiryService *orderExpiryServiceImpl) ExpireUnpaid(ctx context.Context) error {
+	createdBefore := time.Now().Add(-expiryService.Policy.PaymentTimeout)
+	var afterId uint
+	for {
+		orders, err := expiryService.OrderRepository.FindUnpaidPending(ctx, createdBefore, afterId, orderExpiryBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			afterId = order.Id
+			if err := expiryService.expire(ctx, order); err != nil {
+				common.NewLogger().Error("Failed to expire order ", order.Id, ": ", err.Error())
+			}
+		}
+		if len(orders) < orderExpiryBatchSize {
+			return nil
+		}
+	}
+}
+
+func (expiryService *orderExpiryServiceImpl) expire(ctx context.Context, order entity.Order) error {
+	restocked, cancelled, err := expiryService.OrderRepository.CancelUnpaidOrder(ctx, order.Id)
+	if err != nil || !cancelled {
+		return err
+	}
+	evictProducts(expiryService.Cache, ctx, restocked...)
+	invalidateCatalogue(expiryService.Cache, ctx)
+
+	// Give back the promotion uses of the order
+	if err := expiryService.PromotionRepository.ReleaseRedemptions(ctx, order.Id); err != nil {
+		common.NewLogger().Error("Failed to release promotion redemptions: ", err.Error())
+	}
+
+	if order.User == nil {
+		return nil
+	}
+	notification := model.OrderNotificationModel{
+		Type:      "expired",
+		OrderId:   order.Id,
+		Number:    order.Number,
+		UserId:    order.UserId,
+		Email:     order.User.Email,
+		Name:      order.User.Name,
+		Total:     order.Total,
+		CreatedAt: order.CreatedAt.Format(time.RFC3339),
+	}
+	if err := expiryService.NotifierClient.NotifyOrder(ctx, notification); err != nil {
+		common.NewLogger().Error("Failed to notify the customer of expired order ", order.Id, ": ", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOrderPaymentStatus(t *testing.T) {
+	payments := func(statuses ...string) entity.Order {
+		order := entity.Order{}
+		for _, status := range statuses {
+			order.Payments = append(order.Payments, entity.Payment{Status: status})
+		}
+		return order
+	}
+
+	cases := map[string]struct {
+		order  entity.Order
+		status string
+	}{
+		"no payment attempt":          {order: payments(), status: "none"},
+		"paid":                        {order: payments("success"), status: "paid"},
+		"paid after a failed attempt": {order: payments("failed", "success"), status: "paid"},
+		"paid with an attempt open":   {order: payments("pending", "success"), status: "paid"},
+		"attempt open":                {order: payments("failed", "pending"), status: "pending"},
+		"open attempt listed first":   {order: payments("pending", "failed"), status: "pending"},
+		"every attempt failed":        {order: payments("failed", "failed"), status: "failed"},
+	}
+	for name, c := range cases {
+		assert.Equal(t, c.status, orderPaymentStatus(c.order), name)
+	}
+}
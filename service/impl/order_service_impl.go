@@ -4,20 +4,40 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/configuration"
 	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
 	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
 	"github.com/tech-hive/ecommerce/repository"
 	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
 	"github.com/google/uuid"
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"sort"
+	"strings"
+	"time"
 )
 
-func NewOrderServiceImpl(orderRepository *repository.OrderRepository, cartRepository *repository.CartRepository, productRepository *repository.ProductRepository, DB *gorm.DB) service.OrderService {
+// orderNumberAttempts is how many order numbers are tried before placing an order fails.
+const orderNumberAttempts = 5
+
+func NewOrderServiceImpl(orderRepository *repository.OrderRepository, cartRepository *repository.CartRepository, productRepository *repository.ProductRepository, promotionRepository *repository.PromotionRepository, taxRepository *repository.TaxRepository, shippingRepository *repository.ShippingRepository, currencyRepository *repository.CurrencyRepository, cartReminderRepository *repository.CartReminderRepository, DB *gorm.DB, cache *redis.Client, config configuration.Config) service.OrderService {
 	return &orderServiceImpl{
-		OrderRepository:   *orderRepository,
-		CartRepository:    *cartRepository,
-		ProductRepository: *productRepository,
-		DB:                DB,
+		OrderRepository:        *orderRepository,
+		CartRepository:         *cartRepository,
+		ProductRepository:      *productRepository,
+		PromotionRepository:    *promotionRepository,
+		ShippingRepository:     *shippingRepository,
+		CurrencyRepository:     *currencyRepository,
+		CartReminderRepository: *cartReminderRepository,
+		DB:                     DB,
+		Cache:                  cache,
+		Pricer:                 newCartPricer(*promotionRepository, *taxRepository, config),
+		RecoveryPolicy:         configuration.NewCartRecoveryPolicy(config),
+		NumberPrefix:           configuration.NewOrderNumberPrefix(config),
 	}
 }
 
@@ -25,31 +45,119 @@ type orderServiceImpl struct {
 	repository.OrderRepository
 	repository.CartRepository
 	repository.ProductRepository
-	DB *gorm.DB
+	repository.PromotionRepository
+	repository.ShippingRepository
+	repository.CurrencyRepository
+	repository.CartReminderRepository
+	DB             *gorm.DB
+	Cache          *redis.Client
+	Pricer         cartPricer
+	RecoveryPolicy configuration.CartRecoveryPolicy
+	NumberPrefix   string
 }
 
 func (orderService *orderServiceImpl) CreateOrder(ctx context.Context, userId uint, request model.CreateOrderModel) (model.OrderModel, error) {
-	// Get cart
-	cart, err := orderService.CartRepository.GetCartByUserId(ctx, userId)
-	if err != nil {
+	common.Validate(request)
+
+	// Get the cart the customer chose to check out, or their default cart
+	var cart entity.Cart
+	var err error
+	if request.CartId != 0 {
+		cart, err = orderService.CartRepository.GetCartById(ctx, request.CartId)
+	} else {
+		cart, err = orderService.CartRepository.GetCartByUserId(ctx, userId)
+	}
+	if err != nil || cart.UserId == nil || *cart.UserId != userId {
 		return model.OrderModel{}, errors.New("cart not found")
 	}
+	if cart.Type != "cart" {
+		return model.OrderModel{}, errors.New("move the items to a cart to check them out")
+	}
 
 	if len(cart.CartItems) == 0 {
 		return model.OrderModel{}, errors.New("cart is empty")
 	}
 
-	// Calculate total
-	var total float64 = 0
-	for _, item := range cart.CartItems {
-		total += item.Price * float64(item.Quantity)
+	// Items keep the price they were added at; the customer has to acknowledge any change to
+	// prices or stock before the order is placed
+	if warnings := revalidateCart(cart); len(warnings) > 0 {
+		return model.OrderModel{}, exception.CartChangedError{Warnings: warnings}
+	}
+
+	// Calculate total with the promotions and tax of the cart
+	pricing, err := orderService.Pricer.price(ctx, cart, userId, time.Now())
+	if err != nil {
+		return model.OrderModel{}, err
 	}
 
-	// Create order
+	// Charge the chosen shipping method at the rate quoted for the cart
+	county := strings.TrimSpace(request.County)
+	town := strings.TrimSpace(request.Town)
+	zone, err := orderService.ShippingRepository.FindZoneByLocation(ctx, county, town)
+	if err != nil {
+		return model.OrderModel{}, err
+	}
+	var shipping *model.ShippingOptionModel
+	for _, option := range quoteShipping(zone, county, town, cart, pricing).Options {
+		if option.MethodId == request.ShippingMethodId {
+			shipping = &option
+			break
+		}
+	}
+	if shipping == nil {
+		return model.OrderModel{}, errors.New("shipping method is not available for this address")
+	}
+	pricing.ShippingCost = shipping.Cost
+
+	// Lock the customer's currency at today's rate; the order is still priced and paid in KES
+	currency := entity.Currency{Code: money.BaseCurrency, Rate: 1, Decimals: 2}
+	if code := strings.ToUpper(request.Currency); code != "" && code != money.BaseCurrency {
+		currency, err = findActiveCurrency(ctx, orderService.CurrencyRepository, code)
+		if err != nil {
+			return model.OrderModel{}, err
+		}
+	}
+
+	// Create order, with a snapshot of its discounts, shipping and of the tax charged at today's rates
 	order := entity.Order{
-		UserId: userId,
-		Total:  total,
-		Status: "pending",
+		UserId:           userId,
+		Subtotal:         pricing.Subtotal,
+		DiscountTotal:    pricing.DiscountTotal,
+		FreeShipping:     pricing.FreeShipping,
+		TaxTotal:         pricing.TaxTotal,
+		ShippingMethodId: &shipping.MethodId,
+		ShippingMethod:   &shipping.Name,
+		ShippingType:     &shipping.Type,
+		ShippingCost:     shipping.Cost,
+		ShippingAddress:  &request.ShippingAddress,
+		ShippingCounty:   &county,
+		ShippingTown:     &town,
+		PricesIncludeTax: pricing.PricesIncludeTax,
+		Total:            pricing.Total(),
+		Currency:         currency.Code,
+		ExchangeRate:     currency.Rate,
+		CurrencyDecimals: currency.Decimals,
+		Status:           "pending",
+	}
+	for _, discount := range pricing.Discounts {
+		promotionId := discount.Promotion.Id
+		order.Discounts = append(order.Discounts, entity.OrderDiscount{
+			PromotionId: &promotionId,
+			Code:        discount.Promotion.Code,
+			Name:        discount.Promotion.Name,
+			Type:        discount.Promotion.Type,
+			Amount:      discount.Amount,
+		})
+	}
+	for _, tax := range pricing.Taxes {
+		order.Taxes = append(order.Taxes, entity.OrderTax{
+			TaxClass:  tax.TaxClass,
+			Name:      tax.Name,
+			Rate:      tax.Rate,
+			Exempt:    tax.Exempt,
+			NetAmount: tax.NetAmount,
+			TaxAmount: tax.TaxAmount,
+		})
 	}
 
 	// Use transaction to ensure data consistency
@@ -60,14 +168,34 @@ func (orderService *orderServiceImpl) CreateOrder(ctx context.Context, userId ui
 		}
 	}()
 
-	// Create order
-	if err := tx.Create(&order).Error; err != nil {
+	// Create order under a new order number; MySQL only undoes the failed insert, so a number that
+	// is already taken can be retried within the transaction
+	for attempt := 1; ; attempt++ {
+		order.Number = common.NewOrderNumber(orderService.NumberPrefix, time.Now())
+		err := tx.Create(&order).Error
+		if err == nil {
+			break
+		}
+		if !isDuplicateOrderNumber(err) || attempt == orderNumberAttempts {
+			tx.Rollback()
+			return model.OrderModel{}, err
+		}
+	}
+
+	// Count the promotions used against their limits; coupons are used up with the cart
+	if err := redeemPromotions(tx, pricing.Discounts, userId, order.Id); err != nil {
+		tx.Rollback()
+		return model.OrderModel{}, err
+	}
+	if err := tx.Where("cart_id = ?", cart.Id).Delete(&entity.CartCoupon{}).Error; err != nil {
 		tx.Rollback()
 		return model.OrderModel{}, err
 	}
 
 	// Create order items and update product stock
 	var orderItems []entity.OrderItem
+	var stockChanged []string
+	taken := map[string]int32{} // units taken by product, which a cancellation puts back
 	for _, cartItem := range cart.CartItems {
 		// Get product to check current stock
 		product, err := orderService.ProductRepository.FindByProductId(ctx, cartItem.ProductId)
@@ -88,19 +216,44 @@ func (orderService *orderServiceImpl) CreateOrder(ctx context.Context, userId ui
 			tx.Rollback()
 			return model.OrderModel{}, errors.New("invalid product ID format")
 		}
+		lineTax := pricing.Lines[cartItem.Id]
 		orderItem := entity.OrderItem{
-			OrderId:   order.Id,
-			ProductId: productUUID,
-			Quantity:  cartItem.Quantity,
-			Price:     cartItem.Price,
+			OrderId:        order.Id,
+			ProductId:      productUUID,
+			Quantity:       cartItem.Quantity,
+			Price:          cartItem.Price,
+			TaxClass:       &lineTax.TaxClass,
+			TaxRate:        lineTax.Rate,
+			DiscountAmount: lineTax.DiscountAmount,
+			TaxAmount:      lineTax.TaxAmount,
 		}
 		orderItems = append(orderItems, orderItem)
 
+		// Bundles are sold as a unit but take their stock from their components
+		if product.Type == "bundle" {
+			components, err := orderService.ProductRepository.FindBundleComponents(ctx, cartItem.ProductId)
+			if err != nil {
+				tx.Rollback()
+				return model.OrderModel{}, err
+			}
+			for _, component := range components {
+				if err := decrementStock(tx, component.ComponentProductId, component.Quantity*cartItem.Quantity); err != nil {
+					tx.Rollback()
+					return model.OrderModel{}, errors.New("insufficient stock for product: " + product.Name)
+				}
+				stockChanged = append(stockChanged, component.ComponentProductId)
+				taken[component.ComponentProductId] += component.Quantity * cartItem.Quantity
+			}
+			continue
+		}
+
 		// Update product stock
-		if err := tx.Model(&product).Update("quantity", gorm.Expr("quantity - ?", cartItem.Quantity)).Error; err != nil {
+		if err := decrementStock(tx, cartItem.ProductId, cartItem.Quantity); err != nil {
 			tx.Rollback()
-			return model.OrderModel{}, err
+			return model.OrderModel{}, errors.New("insufficient stock for product: " + product.Name)
 		}
+		stockChanged = append(stockChanged, cartItem.ProductId)
+		taken[cartItem.ProductId] += cartItem.Quantity
 	}
 
 	// Create order items
@@ -108,6 +261,17 @@ func (orderService *orderServiceImpl) CreateOrder(ctx context.Context, userId ui
 		tx.Rollback()
 		return model.OrderModel{}, err
 	}
+	if err := tx.Create(newOrderStock(order.Id, taken)).Error; err != nil {
+		tx.Rollback()
+		return model.OrderModel{}, err
+	}
+
+	// Bundles made of the sold products now have less stock too
+	bundleIds, err := orderService.ProductRepository.RefreshBundleStock(tx, stockChanged)
+	if err != nil {
+		tx.Rollback()
+		return model.OrderModel{}, err
+	}
 
 	// Clear cart
 	if err := orderService.CartRepository.ClearCart(ctx, cart.Id); err != nil {
@@ -120,6 +284,17 @@ func (orderService *orderServiceImpl) CreateOrder(ctx context.Context, userId ui
 		return model.OrderModel{}, err
 	}
 
+	// Cached products and catalogue pages show the stock before the sale
+	evictProducts(orderService.Cache, ctx, stockChanged...)
+	evictProducts(orderService.Cache, ctx, bundleIds...)
+	invalidateCatalogue(orderService.Cache, ctx)
+
+	// An order from a cart the customer was reminded of counts as a recovered cart
+	recoveredAt := time.Now()
+	if err := orderService.CartReminderRepository.MarkRecovered(ctx, cart.Id, order.Id, recoveredAt.Add(-orderService.RecoveryPolicy.RecoveryWindow), recoveredAt); err != nil {
+		common.NewLogger().Error("Failed to record recovery of cart ", cart.Id, ": ", err.Error())
+	}
+
 	// Get created order with all details
 	return orderService.GetOrderById(ctx, order.Id, userId)
 }
@@ -135,110 +310,43 @@ func (orderService *orderServiceImpl) GetOrderById(ctx context.Context, orderId
 		return model.OrderModel{}, errors.New("order not found")
 	}
 
-	// Convert to model
-	var orderItems []model.OrderItemModel
-	for _, item := range order.OrderItems {
-		orderItemModel := model.OrderItemModel{
-			Id:        item.Id,
-			OrderId:   item.OrderId,
-			ProductId: item.ProductId.String(),
-			Quantity:  item.Quantity,
-			Price:     item.Price,
-			CreatedAt: item.CreatedAt.String(),
-			Product: model.ProductModel{
-				Id:          strconv.FormatUint(uint64(item.Product.Id), 10),
-				Name:        item.Product.Name,
-				Description: item.Product.Description,
-				Price:       item.Product.Price,
-				Stock:       item.Product.Stock,
-				ImageUrl:    item.Product.ImageUrl,
-			},
-		}
-		orderItems = append(orderItems, orderItemModel)
-	}
+	return newOrderModel(order), nil
+}
 
-	// Convert payments
-	var paymentModel *model.PaymentModel
-	if len(order.Payments) > 0 {
-		payment := order.Payments[0] // Assuming one payment per order
-		paymentModel = &model.PaymentModel{
-			Id:            payment.Id,
-			OrderId:       payment.OrderId,
-			TransactionId: payment.TransactionId,
-			Status:        payment.Status,
-			PaidAt:        payment.PaidAt.String(),
-		}
+// GetOrderByNumber finds any customer's order by its order number, for admins.
+func (orderService *orderServiceImpl) GetOrderByNumber(ctx context.Context, number string) (model.OrderModel, error) {
+	number, err := common.ParseOrderNumber(number)
+	if err != nil {
+		return model.OrderModel{}, err
 	}
-
-	orderModel := model.OrderModel{
-		Id:         order.Id,
-		UserId:     order.UserId,
-		Total:      order.Total,
-		Status:     order.Status,
-		CreatedAt:  order.CreatedAt.String(),
-		OrderItems: orderItems,
-		Payment:    paymentModel,
+	order, err := orderService.OrderRepository.GetOrderByNumber(ctx, number)
+	if err != nil {
+		return model.OrderModel{}, err
 	}
-
-	return orderModel, nil
+	return newOrderModel(order), nil
 }
 
-func (orderService *orderServiceImpl) GetOrdersByUserId(ctx context.Context, userId uint) ([]model.OrderModel, error) {
-	orders, err := orderService.OrderRepository.GetOrdersByUserId(ctx, userId)
+func (orderService *orderServiceImpl) GetOrdersByUserId(ctx context.Context, userId uint, listQuery model.ListQueryModel) ([]model.OrderModel, model.PageInfoModel, error) {
+	// Order numbers are matched however the customer typed them
+	if number, ok := listQuery.Filters["number"]; ok {
+		parsed, err := common.ParseOrderNumber(number)
+		if err != nil {
+			return []model.OrderModel{}, model.PageInfoModel{}, common.NewValidationError("number", err.Error())
+		}
+		listQuery.Filters["number"] = parsed
+	}
+
+	orders, pageInfo, err := orderService.OrderRepository.GetOrdersByUserId(ctx, userId, listQuery)
 	if err != nil {
-		return []model.OrderModel{}, err
+		return []model.OrderModel{}, model.PageInfoModel{}, err
 	}
 
 	var orderModels []model.OrderModel
 	for _, order := range orders {
-		// Convert to model
-		var orderItems []model.OrderItemModel
-		for _, item := range order.OrderItems {
-			orderItemModel := model.OrderItemModel{
-				Id:        item.Id,
-				OrderId:   item.OrderId,
-				ProductId: item.ProductId.String(),
-				Quantity:  item.Quantity,
-				Price:     item.Price,
-				CreatedAt: item.CreatedAt.String(),
-				Product: model.ProductModel{
-					Id:          strconv.FormatUint(uint64(item.Product.Id), 10),
-					Name:        item.Product.Name,
-					Description: item.Product.Description,
-					Price:       item.Product.Price,
-					Stock:       item.Product.Stock,
-					ImageUrl:    item.Product.ImageUrl,
-				},
-			}
-			orderItems = append(orderItems, orderItemModel)
-		}
-
-		// Convert payments
-		var paymentModel *model.PaymentModel
-		if len(order.Payments) > 0 {
-			payment := order.Payments[0]
-			paymentModel = &model.PaymentModel{
-				Id:            payment.Id,
-				OrderId:       payment.OrderId,
-				TransactionId: payment.TransactionId,
-				Status:        payment.Status,
-				PaidAt:        payment.PaidAt.String(),
-			}
-		}
-
-		orderModel := model.OrderModel{
-			Id:         order.Id,
-			UserId:     order.UserId,
-			Total:      order.Total,
-			Status:     order.Status,
-			CreatedAt:  order.CreatedAt.String(),
-			OrderItems: orderItems,
-			Payment:    paymentModel,
-		}
-		orderModels = append(orderModels, orderModel)
+		orderModels = append(orderModels, newOrderModel(order))
 	}
 
-	return orderModels, nil
+	return orderModels, pageInfo, nil
 }
 
 func (orderService *orderServiceImpl) UpdateOrderStatus(ctx context.Context, orderId uint, request model.UpdateOrderStatusModel) (model.OrderModel, error) {
@@ -254,38 +362,7 @@ func (orderService *orderServiceImpl) UpdateOrderStatus(ctx context.Context, ord
 		return model.OrderModel{}, err
 	}
 
-	// Convert to model
-	var orderItems []model.OrderItemModel
-	for _, item := range updatedOrder.OrderItems {
-		orderItemModel := model.OrderItemModel{
-			Id:        item.Id,
-			OrderId:   item.OrderId,
-			ProductId: item.ProductId.String(),
-			Quantity:  item.Quantity,
-			Price:     item.Price,
-			CreatedAt: item.CreatedAt.String(),
-			Product: model.ProductModel{
-				Id:          strconv.FormatUint(uint64(item.Product.Id), 10),
-				Name:        item.Product.Name,
-				Description: item.Product.Description,
-				Price:       item.Product.Price,
-				Stock:       item.Product.Stock,
-				ImageUrl:    item.Product.ImageUrl,
-			},
-		}
-		orderItems = append(orderItems, orderItemModel)
-	}
-
-	orderModel := model.OrderModel{
-		Id:         updatedOrder.Id,
-		UserId:     updatedOrder.UserId,
-		Total:      updatedOrder.Total,
-		Status:     updatedOrder.Status,
-		CreatedAt:  updatedOrder.CreatedAt.String(),
-		OrderItems: orderItems,
-	}
-
-	return orderModel, nil
+	return newOrderModel(updatedOrder), nil
 }
 
 func (orderService *orderServiceImpl) CancelOrder(ctx context.Context, orderId uint, userId uint) error {
@@ -311,8 +388,228 @@ func (orderService *orderServiceImpl) CancelOrder(ctx context.Context, orderId u
 		return err
 	}
 
+	// Give back the promotion uses of the order
+	if err := orderService.PromotionRepository.ReleaseRedemptions(ctx, orderId); err != nil {
+		common.NewLogger().Error("Failed to release promotion redemptions: ", err.Error())
+	}
+
 	// If order was paid, you might want to implement refund logic here
 	// For now, we'll just cancel the order
 
 	return nil
-}
\ No newline at end of file
+}
+
+// Reorder puts the items of a past order back into the customer's default cart at today's prices.
+// Items no longer sold are left out, and items short of stock are added as far as the stock goes,
+// counting what the cart already holds.
+func (orderService *orderServiceImpl) Reorder(ctx context.Context, orderId uint, userId uint) (model.ReorderResultModel, error) {
+	order, err := orderService.OrderRepository.GetOrderById(ctx, orderId)
+	if err != nil {
+		return model.ReorderResultModel{}, err
+	}
+	if order.UserId != userId {
+		return model.ReorderResultModel{}, errors.New("order not found")
+	}
+
+	cart, err := orderService.CartRepository.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.ReorderResultModel{}, err
+	}
+	cartItems := map[string]entity.CartItem{}
+	for _, item := range cart.CartItems {
+		cartItems[item.ProductId] = item
+	}
+
+	result := model.ReorderResultModel{
+		OrderId:  order.Id,
+		CartId:   cart.Id,
+		Added:    []model.ReorderItemModel{},
+		NotAdded: []model.ReorderItemModel{},
+	}
+	var changed []string // product ids of the cart items to save
+	listed := map[string]bool{}
+	for _, orderItem := range order.OrderItems {
+		productId := orderItem.ProductId.String()
+		line := model.ReorderItemModel{
+			ProductId:    productId,
+			Name:         orderItem.Product.Name,
+			Ordered:      orderItem.Quantity,
+			OrderedPrice: orderItem.Price,
+		}
+
+		product, err := orderService.ProductRepository.FindByProductId(ctx, productId)
+		if err != nil {
+			if err != gorm.ErrRecordNotFound {
+				return model.ReorderResultModel{}, err
+			}
+			line.Reason = "unavailable"
+			result.NotAdded = append(result.NotAdded, line)
+			continue
+		}
+		price := product.Price
+		line.Name = product.Name
+		line.Price = &price
+
+		item, found := cartItems[productId]
+		if !found {
+			item = entity.CartItem{
+				CartId:    cart.Id,
+				ProductId: productId,
+			}
+		}
+		item, line.Added, line.Reason = reorderCartItem(item, product, orderItem.Quantity)
+		if line.Added == 0 {
+			result.NotAdded = append(result.NotAdded, line)
+			continue
+		}
+
+		if !listed[productId] {
+			listed[productId] = true
+			changed = append(changed, productId)
+		}
+		cartItems[productId] = item
+		result.Added = append(result.Added, line)
+	}
+
+	if len(changed) > 0 {
+		saved := make([]entity.CartItem, len(changed))
+		for i, productId := range changed {
+			saved[i] = cartItems[productId]
+		}
+		if err := orderService.CartRepository.SaveCartItems(ctx, saved); err != nil {
+			return model.ReorderResultModel{}, err
+		}
+		if err := orderService.CartRepository.TouchCart(ctx, cart.Id, time.Now()); err != nil {
+			common.NewLogger().Error("Failed to record activity of cart ", cart.Id, ": ", err.Error())
+		}
+	}
+	return result, nil
+}
+
+// reorderCartItem adds what fits of an ordered quantity to the cart line of its product, which then
+// charges today's price for all its units. Nothing is added when the line has no room left.
+func reorderCartItem(item entity.CartItem, product entity.Product, ordered int32) (entity.CartItem, int32, string) {
+	added, reason := reorderQuantity(ordered, product.Stock, item.Quantity)
+	if added > 0 {
+		item.Quantity += added
+		item.Price = product.Price
+	}
+	return item, added, reason
+}
+
+// reorderQuantity tells how much of an ordered quantity fits next to what the cart already holds of a
+// product, and why not all of it when stock runs short: low_stock, or out_of_stock when none fits.
+func reorderQuantity(ordered int32, stock int32, inCart int32) (int32, string) {
+	available := stock - inCart
+	switch {
+	case available <= 0:
+		return 0, "out_of_stock"
+	case ordered > available:
+		return available, "low_stock"
+	}
+	return ordered, ""
+}
+
+// newOrderModel converts an order with its items, payment, discounts and taxes.
+func newOrderModel(order entity.Order) model.OrderModel {
+	var orderItems []model.OrderItemModel
+	for _, item := range order.OrderItems {
+		orderItemModel := model.OrderItemModel{
+			Id:             item.Id,
+			OrderId:        item.OrderId,
+			ProductId:      item.ProductId.String(),
+			Quantity:       item.Quantity,
+			Price:          item.Price,
+			TaxClass:       orderItemTaxClass(item),
+			TaxRate:        item.TaxRate,
+			DiscountAmount: item.DiscountAmount,
+			TaxAmount:      item.TaxAmount,
+			CreatedAt:      item.CreatedAt.String(),
+			Product: model.ProductModel{
+				Id:          strconv.FormatUint(uint64(item.Product.Id), 10),
+				Name:        item.Product.Name,
+				Description: item.Product.Description,
+				Price:       item.Product.Price,
+				Stock:       item.Product.Stock,
+				ImageUrl:    item.Product.ImageUrl,
+			},
+		}
+		orderItems = append(orderItems, orderItemModel)
+	}
+
+	// Convert payments
+	var paymentModel *model.PaymentModel
+	if len(order.Payments) > 0 {
+		payment := order.Payments[0] // Assuming one payment per order
+		paymentModel = newPaymentModel(payment)
+	}
+
+	orderModel := model.OrderModel{
+		Id:               order.Id,
+		Number:           order.Number,
+		UserId:           order.UserId,
+		Subtotal:         order.Subtotal,
+		Discounts:        newOrderDiscountModels(order.Discounts),
+		DiscountTotal:    order.DiscountTotal,
+		FreeShipping:     order.FreeShipping,
+		Taxes:            newOrderTaxModels(order.Taxes),
+		TaxTotal:         order.TaxTotal,
+		Shipping:         newOrderShippingModel(order),
+		ShippingCost:     order.ShippingCost,
+		PricesIncludeTax: order.PricesIncludeTax,
+		Total:            order.Total,
+		Status:           order.Status,
+		CreatedAt:        order.CreatedAt.String(),
+		OrderItems:       orderItems,
+		Payment:          paymentModel,
+	}
+
+	return convertOrderModel(orderModel, order)
+}
+
+func newPaymentModel(payment entity.Payment) *model.PaymentModel {
+	paymentModel := &model.PaymentModel{
+		Id:            payment.Id,
+		OrderId:       payment.OrderId,
+		TransactionId: payment.TransactionId,
+		Status:        payment.Status,
+		PaidAt:        payment.PaidAt.String(),
+	}
+	if payment.ReceiptNumber != nil {
+		paymentModel.ReceiptNumber = *payment.ReceiptNumber
+	}
+	return paymentModel
+}
+
+// isDuplicateOrderNumber tells whether an insert failed because its order number is taken.
+func isDuplicateOrderNumber(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 && strings.Contains(mysqlErr.Message, "uk_tb_order_number")
+}
+
+// decrementStock takes quantity units of a product within the order transaction, failing
+// instead of letting the stock go negative.
+// newOrderStock records the units an order took by product, in product order.
+func newOrderStock(orderId uint, taken map[string]int32) []entity.OrderStock {
+	stock := make([]entity.OrderStock, 0, len(taken))
+	for productId, quantity := range taken {
+		stock = append(stock, entity.OrderStock{OrderId: orderId, ProductId: productId, Quantity: quantity})
+	}
+	sort.Slice(stock, func(i, j int) bool {
+		return stock[i].ProductId < stock[j].ProductId
+	})
+	return stock
+}
+
+func decrementStock(tx *gorm.DB, productId string, quantity int32) error {
+	result := tx.Model(&entity.Product{}).
+		Where("product_id = ? AND quantity >= ?", productId, quantity).
+		Update("quantity", gorm.Expr("quantity - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("insufficient stock")
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewOrderStock(t *testing.T) {
+	// A product ordered on its own and inside a bundle is recorded once, in product order
+	stock := newOrderStock(12, map[string]int32{"strap": 3, "case": 4, "charger": 1})
+	assert.Equal(t, []entity.OrderStock{
+		{OrderId: 12, ProductId: "case", Quantity: 4},
+		{OrderId: 12, ProductId: "charger", Quantity: 1},
+		{OrderId: 12, ProductId: "strap", Quantity: 3},
+	}, stock)
+}
@@ -0,0 +1,90 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/client"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"time"
+)
+
+const (
+	productAlertBatchSize   = 100
+	productAlertMaxAttempts = 5
+)
+
+func NewProductAlertServiceImpl(alertRepository *repository.ProductAlertRepository, notifier *client.NotifierClient) service.ProductAlertService {
+	return &productAlertServiceImpl{
+		ProductAlertRepository: *alertRepository,
+		NotifierClient:         *notifier,
+	}
+}
+
+type productAlertServiceImpl struct {
+	repository.ProductAlertRepository
+	client.NotifierClient
+}
+
+// DispatchPending sends queued price-drop and back-in-stock alerts through the notifier. It runs
+// from the alert scheduler; an alert that fails to send is retried on later runs.
+func (alertService *productAlertServiceImpl) DispatchPending(ctx context.Context) error {
+	var afterId uint
+	for {
+		alerts, err := alertService.ProductAlertRepository.FindPending(ctx, afterId, productAlertBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, alert := range alerts {
+			afterId = alert.Id
+			alertService.dispatch(ctx, alert)
+		}
+		if len(alerts) < productAlertBatchSize {
+			return nil
+		}
+	}
+}
+
+func (alertService *productAlertServiceImpl) dispatch(ctx context.Context, alert entity.ProductAlert) {
+	if alert.Product.DeletedAt.Valid {
+		alertService.markFailed(ctx, alert.Id, "product archived", 0)
+		return
+	}
+
+	if err := alertService.NotifierClient.Notify(ctx, newProductAlertModel(alert)); err != nil {
+		alertService.markFailed(ctx, alert.Id, err.Error(), productAlertMaxAttempts)
+		return
+	}
+	if err := alertService.ProductAlertRepository.MarkSent(ctx, alert.Id, time.Now()); err != nil {
+		common.NewLogger().Error("Failed to mark product alert ", alert.Id, " as sent: ", err.Error())
+	}
+}
+
+func (alertService *productAlertServiceImpl) markFailed(ctx context.Context, id uint, reason string, maxAttempts int32) {
+	if err := alertService.ProductAlertRepository.MarkFailed(ctx, id, reason, maxAttempts); err != nil {
+		common.NewLogger().Error("Failed to record product alert ", id, " failure: ", err.Error())
+	}
+}
+
+func newProductAlertModel(alert entity.ProductAlert) model.ProductAlertModel {
+	response := model.ProductAlertModel{
+		Id:          alert.Id,
+		Type:        alert.Type,
+		UserId:      alert.UserId,
+		Email:       alert.User.Email,
+		Name:        alert.User.Name,
+		ProductId:   alert.ProductId,
+		ProductName: alert.Product.Name,
+		OldPrice:    alert.OldPrice,
+		NewPrice:    alert.Product.Price,
+		Stock:       alert.Product.Stock,
+		CreatedAt:   alert.CreatedAt.Format(time.RFC3339),
+	}
+	if alert.NewPrice != nil {
+		response.NewPrice = *alert.NewPrice
+	}
+	return response
+}
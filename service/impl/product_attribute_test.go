@@ -0,0 +1,53 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckProductAttributes(t *testing.T) {
+	storage := entity.AttributeDefinition{Id: 1, Code: "storage_gb", Type: "number"}
+	colour := entity.AttributeDefinition{Id: 2, Code: "colour", Type: "text"}
+	pages := entity.AttributeDefinition{Id: 3, Code: "pages", Type: "number"}
+	phones := []entity.CategoryAttribute{{Attribute: storage, Required: true}, {Attribute: colour}}
+	books := []entity.CategoryAttribute{{Attribute: pages, Required: true}, {Attribute: colour}}
+
+	size := float64(128)
+	current := []entity.ProductAttributeValue{
+		{AttributeId: 1, Attribute: storage, ValueText: "128", ValueNumber: &size},
+		{AttributeId: 2, Attribute: colour, ValueText: "Black"},
+	}
+	fields := func(messages []map[string]interface{}) []string {
+		failed := []string{}
+		for _, message := range messages {
+			failed = append(failed, message["field"].(string))
+		}
+		return failed
+	}
+
+	// Created, or updated with attributes: checked strictly
+	values, messages := checkProductAttributes(phones, "phones", map[string]interface{}{"storage_gb": float64(64)}, nil)
+	assert.Empty(t, messages)
+	assert.Len(t, values, 1)
+
+	_, messages = checkProductAttributes(phones, "phones", map[string]interface{}{"colour": "Red", "pages": float64(300)}, nil)
+	assert.Equal(t, []string{"attributes.storage_gb", "attributes.pages"}, fields(messages))
+
+	// Updated without attributes: the current values are kept
+	values, messages = checkProductAttributes(phones, "phones", nil, current)
+	assert.Empty(t, messages)
+	assert.Len(t, values, 2)
+
+	// A new category drops the attributes it does not have, but still requires its own
+	_, messages = checkProductAttributes(books, "books", nil, current)
+	assert.Equal(t, []string{"attributes.pages"}, fields(messages))
+
+	values, messages = checkProductAttributes([]entity.CategoryAttribute{books[1]}, "books", nil, current)
+	assert.Empty(t, messages)
+	assert.Equal(t, []entity.ProductAttributeValue{{AttributeId: 2, ValueText: "Black"}}, values)
+
+	// Imported products carry no attributes: new ones only pass when nothing is required
+	_, messages = checkProductAttributes(phones, "phones", nil, nil)
+	assert.Equal(t, []string{"attributes.storage_gb"}, fields(messages))
+}
@@ -0,0 +1,29 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/configuration"
+	"github.com/go-redis/redis/v9"
+)
+
+const productCachePrefix = "product"
+
+// evictProducts drops the cached products so the next read sees the write.
+// A Redis failure is logged rather than failing the write; the entries then expire by TTL.
+func evictProducts(cache *redis.Client, ctx context.Context, ids ...string) {
+	for _, id := range ids {
+		if err := configuration.DeleteCache(cache, ctx, productCachePrefix, id); err != nil {
+			common.NewLogger().Error("Failed to evict product ", id, " from cache: ", err.Error())
+		}
+	}
+}
+
+// invalidateCatalogue drops every cached catalogue response after a product write.
+// A Redis failure is logged rather than failing the write; cached pages then expire by TTL.
+func invalidateCatalogue(cache *redis.Client, ctx context.Context) {
+	err := configuration.InvalidateCacheNamespace(cache, ctx, configuration.ProductCatalogueCacheNamespace)
+	if err != nil {
+		common.NewLogger().Error("Failed to invalidate product catalogue cache: ", err.Error())
+	}
+}
@@ -0,0 +1,480 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
+	"github.com/google/uuid"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func NewProductImportServiceImpl(productRepository *repository.ProductRepository, jobRepository *repository.ProductImportJobRepository, attributeRepository *repository.AttributeRepository, cache *redis.Client) service.ProductImportService {
+	return &productImportServiceImpl{
+		ProductRepository:          *productRepository,
+		ProductImportJobRepository: *jobRepository,
+		AttributeRepository:        *attributeRepository,
+		Cache:                      cache,
+	}
+}
+
+const productImportBatchSize = 100
+
+// productImportColumns is the CSV header of an export and the columns an import accepts.
+var productImportColumns = []string{"id", "sku", "name", "description", "category", "price", "stock", "image_url"}
+
+type productImportServiceImpl struct {
+	repository.ProductRepository
+	repository.ProductImportJobRepository
+	repository.AttributeRepository
+	Cache *redis.Client
+}
+
+// productImportRow is a parsed row with its 1-based position in the file (the CSV header is row 1).
+type productImportRow struct {
+	row   int
+	value model.ProductImportRowModel
+}
+
+// Import parses the whole file up front so malformed input is rejected with the request,
+// then records a job and upserts the rows in the background.
+func (importService *productImportServiceImpl) Import(ctx context.Context, format string, data []byte) model.ProductImportJobModel {
+	var rows []productImportRow
+	var parseErrors []model.ProductImportRowErrorModel
+	switch format {
+	case "csv":
+		rows, parseErrors = parseProductImportCsv(data)
+	case "json":
+		rows = parseProductImportJson(data)
+	default:
+		panic(common.NewValidationError("format", "this field is oneof csv json"))
+	}
+
+	failedRows := map[int]bool{}
+	for _, rowError := range parseErrors {
+		failedRows[rowError.Row] = true
+	}
+
+	job, err := importService.ProductImportJobRepository.Insert(ctx, entity.ProductImportJob{
+		Id:          uuid.New(),
+		Format:      format,
+		Status:      "pending",
+		TotalRows:   len(rows) + len(failedRows),
+		FailedCount: len(failedRows),
+	})
+	exception.PanicLogging(err)
+
+	// The request context ends with the response, so the job runs detached from it.
+	go importService.runImport(context.Background(), job, rows, parseErrors)
+
+	return newProductImportJobModel(job)
+}
+
+func (importService *productImportServiceImpl) FindJobById(ctx context.Context, id string) model.ProductImportJobModel {
+	job, err := importService.ProductImportJobRepository.FindById(ctx, id)
+	if err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+	return newProductImportJobModel(job)
+}
+
+func (importService *productImportServiceImpl) Export(ctx context.Context, format string, writer io.Writer) error {
+	switch format {
+	case "csv":
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(productImportColumns); err != nil {
+			return err
+		}
+		err := importService.ProductRepository.FindAllInBatches(ctx, productImportBatchSize, func(products []entity.Product) error {
+			for _, product := range products {
+				row := newProductImportRowModel(product)
+				err := csvWriter.Write([]string{
+					row.Id,
+					row.Sku,
+					row.Name,
+					row.Description,
+					row.Category,
+					row.Price.String(),
+					strconv.FormatInt(int64(row.Stock), 10),
+					row.ImageUrl,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	case "json":
+		// Written as one array, batch by batch, so large catalogues aren't held in memory twice.
+		if _, err := io.WriteString(writer, "["); err != nil {
+			return err
+		}
+		first := true
+		err := importService.ProductRepository.FindAllInBatches(ctx, productImportBatchSize, func(products []entity.Product) error {
+			for _, product := range products {
+				data, err := json.Marshal(newProductImportRowModel(product))
+				if err != nil {
+					return err
+				}
+				if !first {
+					data = append([]byte(","), data...)
+				}
+				first = false
+				if _, err := writer.Write(data); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(writer, "]")
+		return err
+	default:
+		return common.NewValidationError("format", "this field is oneof csv json")
+	}
+}
+
+func (importService *productImportServiceImpl) runImport(ctx context.Context, job entity.ProductImportJob, rows []productImportRow, rowErrors []model.ProductImportRowErrorModel) {
+	defer func() {
+		if r := recover(); r != nil {
+			common.NewLogger().Error("Product import ", job.Id.String(), " failed: ", r)
+			job.Status = "failed"
+			importService.saveImportJob(ctx, job, rowErrors)
+		}
+	}()
+
+	job.Status = "running"
+	job.ProcessedRows = job.FailedCount
+	importService.saveImportJob(ctx, job, rowErrors)
+
+	// Rows are checked against each other up front; a later duplicate id or SKU would
+	// otherwise silently overwrite the earlier row or fail its whole batch.
+	var valid []productImportRow
+	seenIds := map[string]int{}
+	seenSkus := map[string]int{}
+	for _, row := range rows {
+		messages := common.ValidationMessages(row.value)
+		for _, message := range messages {
+			rowErrors = append(rowErrors, model.ProductImportRowErrorModel{
+				Row:     row.row,
+				Field:   message["field"].(string),
+				Message: message["message"].(string),
+			})
+		}
+		if len(messages) > 0 {
+			job.FailedCount++
+			job.ProcessedRows++
+			continue
+		}
+
+		row.value.Id = strings.ToLower(row.value.Id)
+		if previous, ok := seenIds[row.value.Id]; ok && row.value.Id != "" {
+			rowErrors = append(rowErrors, productImportDuplicateError(row.row, "Id", previous))
+			job.FailedCount++
+			job.ProcessedRows++
+			continue
+		}
+		if previous, ok := seenSkus[row.value.Sku]; ok && row.value.Sku != "" {
+			rowErrors = append(rowErrors, productImportDuplicateError(row.row, "Sku", previous))
+			job.FailedCount++
+			job.ProcessedRows++
+			continue
+		}
+		seenIds[row.value.Id] = row.row
+		seenSkus[row.value.Sku] = row.row
+		valid = append(valid, row)
+	}
+
+	var savedIds []string
+	for start := 0; start < len(valid); start += productImportBatchSize {
+		end := start + productImportBatchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+		batch := valid[start:end]
+
+		created, updated, rejected, err := importService.saveImportBatch(ctx, batch)
+		if err != nil {
+			for _, row := range batch {
+				rowErrors = append(rowErrors, model.ProductImportRowErrorModel{
+					Row:     row.row,
+					Message: "batch rolled back: " + err.Error(),
+				})
+			}
+			job.FailedCount += len(batch)
+		} else {
+			rowErrors = append(rowErrors, rejected...)
+			job.CreatedCount += len(created)
+			job.UpdatedCount += len(updated)
+			job.FailedCount += len(batch) - len(created) - len(updated)
+			savedIds = append(savedIds, updated...)
+		}
+		job.ProcessedRows += len(batch)
+		importService.saveImportJob(ctx, job, rowErrors)
+	}
+
+	if job.CreatedCount+job.UpdatedCount > 0 {
+		evictProducts(importService.Cache, ctx, savedIds...)
+		invalidateCatalogue(importService.Cache, ctx)
+	}
+
+	finishedAt := time.Now()
+	job.Status = "completed"
+	job.FinishedAt = &finishedAt
+	importService.saveImportJob(ctx, job, rowErrors)
+}
+
+// saveImportBatch upserts one batch, matching rows by product id and then by SKU, and
+// returns the product ids it created and updated. Rows are held to the attribute rule of the
+// product form; as imports carry no attributes, updated products keep theirs, and rows whose
+// category requires attributes the product lacks are rejected with their errors.
+func (importService *productImportServiceImpl) saveImportBatch(ctx context.Context, batch []productImportRow) ([]string, []string, []model.ProductImportRowErrorModel, error) {
+	var productIds, skus []string
+	for _, row := range batch {
+		if row.value.Id != "" {
+			productIds = append(productIds, row.value.Id)
+		}
+		if row.value.Sku != "" {
+			skus = append(skus, row.value.Sku)
+		}
+	}
+
+	existing, err := importService.ProductRepository.FindByProductIdsOrSkus(ctx, productIds, skus)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	byId := map[string]entity.Product{}
+	bySku := map[string]entity.Product{}
+	for _, product := range existing {
+		byId[product.ProductId.String()] = product
+		if product.Sku != nil {
+			bySku[*product.Sku] = product
+		}
+	}
+
+	var inserts, updates []entity.Product
+	var created, updated []string
+	var rejected []model.ProductImportRowErrorModel
+	categoryAttributes := map[string][]entity.CategoryAttribute{}
+	for _, row := range batch {
+		product, found := byId[row.value.Id]
+		if !found && row.value.Sku != "" {
+			product, found = bySku[row.value.Sku]
+		}
+		if !found && row.value.Id != "" {
+			product.ProductId = uuid.MustParse(row.value.Id)
+		}
+
+		attributes, ok := categoryAttributes[row.value.Category]
+		if !ok {
+			attributes, err = importService.AttributeRepository.FindCategoryAttributes(ctx, row.value.Category)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			categoryAttributes[row.value.Category] = attributes
+		}
+		values, messages := checkProductAttributes(attributes, row.value.Category, nil, product.Attributes)
+		if len(messages) > 0 {
+			for _, message := range messages {
+				rejected = append(rejected, model.ProductImportRowErrorModel{
+					Row:     row.row,
+					Field:   message["field"].(string),
+					Message: message["message"].(string),
+				})
+			}
+			continue
+		}
+		product.Attributes = values
+
+		product.Sku = productSku(row.value.Sku)
+		product.Name = row.value.Name
+		product.Description = row.value.Description
+		product.Category = row.value.Category
+		product.Price = row.value.Price
+		product.Stock = row.value.Stock
+		product.ImageUrl = row.value.ImageUrl
+
+		if found {
+			updates = append(updates, product)
+			updated = append(updated, product.ProductId.String())
+		} else {
+			inserts = append(inserts, product)
+		}
+	}
+
+	if err := importService.ProductRepository.SaveBatch(ctx, inserts, updates); err != nil {
+		return nil, nil, nil, err
+	}
+	for _, product := range inserts {
+		created = append(created, product.ProductId.String())
+	}
+	return created, updated, rejected, nil
+}
+
+func (importService *productImportServiceImpl) saveImportJob(ctx context.Context, job entity.ProductImportJob, rowErrors []model.ProductImportRowErrorModel) {
+	errorsJson, err := json.Marshal(rowErrors)
+	if err != nil {
+		common.NewLogger().Error("Failed to encode errors of product import ", job.Id.String(), ": ", err.Error())
+		return
+	}
+	job.Errors = string(errorsJson)
+	if err := importService.ProductImportJobRepository.Update(ctx, job); err != nil {
+		common.NewLogger().Error("Failed to save product import ", job.Id.String(), ": ", err.Error())
+	}
+}
+
+func parseProductImportCsv(data []byte) ([]productImportRow, []model.ProductImportRowErrorModel) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		panic(common.NewValidationError("file", "this field is csv: "+err.Error()))
+	}
+	if len(records) == 0 {
+		panic(common.NewValidationError("file", "this field is required"))
+	}
+
+	columns := map[string]int{}
+	for index, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !isProductImportColumn(name) {
+			panic(common.NewValidationError("file", "column "+name+" is not oneof "+strings.Join(productImportColumns, " ")))
+		}
+		columns[name] = index
+	}
+	value := func(record []string, name string) string {
+		index, ok := columns[name]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	var rows []productImportRow
+	var rowErrors []model.ProductImportRowErrorModel
+	for index, record := range records[1:] {
+		rowNumber := index + 2
+		row := model.ProductImportRowModel{Id: value(record, "id")}
+		row.Sku = value(record, "sku")
+		row.Name = value(record, "name")
+		row.Description = value(record, "description")
+		row.Category = value(record, "category")
+		row.ImageUrl = value(record, "image_url")
+
+		failed := false
+		if price := value(record, "price"); price != "" {
+			parsed, err := money.Parse(price)
+			if err != nil {
+				rowErrors = append(rowErrors, model.ProductImportRowErrorModel{Row: rowNumber, Field: "Price", Message: "this field is number"})
+				failed = true
+			}
+			row.Price = parsed
+		}
+		if stock := value(record, "stock"); stock != "" {
+			parsed, err := strconv.ParseInt(stock, 10, 32)
+			if err != nil {
+				rowErrors = append(rowErrors, model.ProductImportRowErrorModel{Row: rowNumber, Field: "Stock", Message: "this field is integer"})
+				failed = true
+			}
+			row.Stock = int32(parsed)
+		}
+		if !failed {
+			rows = append(rows, productImportRow{row: rowNumber, value: row})
+		}
+	}
+	return rows, rowErrors
+}
+
+func parseProductImportJson(data []byte) []productImportRow {
+	var values []model.ProductImportRowModel
+	if err := json.Unmarshal(data, &values); err != nil {
+		panic(common.NewValidationError("file", "this field is json array of products: "+err.Error()))
+	}
+
+	rows := make([]productImportRow, 0, len(values))
+	for index, value := range values {
+		rows = append(rows, productImportRow{row: index + 1, value: value})
+	}
+	return rows
+}
+
+func isProductImportColumn(name string) bool {
+	for _, column := range productImportColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+func productImportDuplicateError(row int, field string, previous int) model.ProductImportRowErrorModel {
+	return model.ProductImportRowErrorModel{
+		Row:     row,
+		Field:   field,
+		Message: fmt.Sprintf("this field duplicates row %d", previous),
+	}
+}
+
+func newProductImportRowModel(product entity.Product) model.ProductImportRowModel {
+	row := model.ProductImportRowModel{Id: product.ProductId.String()}
+	row.Sku = productSkuValue(product.Sku)
+	row.Name = product.Name
+	row.Description = product.Description
+	row.Category = product.Category
+	row.Price = product.Price
+	if product.CompareAtPrice != nil {
+		// Export the regular price; re-importing a sale price would make it permanent.
+		row.Price = *product.CompareAtPrice
+	}
+	row.Stock = product.Stock
+	row.ImageUrl = product.ImageUrl
+	return row
+}
+
+func newProductImportJobModel(job entity.ProductImportJob) model.ProductImportJobModel {
+	rowErrors := []model.ProductImportRowErrorModel{}
+	if job.Errors != "" {
+		if err := json.Unmarshal([]byte(job.Errors), &rowErrors); err != nil {
+			common.NewLogger().Error("Failed to decode errors of product import ", job.Id.String(), ": ", err.Error())
+		}
+	}
+
+	response := model.ProductImportJobModel{
+		Id:            job.Id.String(),
+		Format:        job.Format,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  job.CreatedCount,
+		UpdatedCount:  job.UpdatedCount,
+		FailedCount:   job.FailedCount,
+		Errors:        rowErrors,
+		CreatedAt:     job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.FinishedAt != nil {
+		response.FinishedAt = job.FinishedAt.Format(time.RFC3339)
+	}
+	return response
+}
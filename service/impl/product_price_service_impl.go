@@ -0,0 +1,175 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
+	"time"
+)
+
+func NewProductPriceServiceImpl(priceRepository *repository.ProductPriceRepository, productRepository *repository.ProductRepository, cache *redis.Client) service.ProductPriceService {
+	return &productPriceServiceImpl{
+		ProductPriceRepository: *priceRepository,
+		ProductRepository:      *productRepository,
+		Cache:                  cache,
+	}
+}
+
+type productPriceServiceImpl struct {
+	repository.ProductPriceRepository
+	repository.ProductRepository
+	Cache *redis.Client
+}
+
+func (priceService *productPriceServiceImpl) CreateSchedule(ctx context.Context, productId string, request model.ProductPriceScheduleCreateModel) model.ProductPriceScheduleModel {
+	common.Validate(request)
+
+	product, err := priceService.ProductRepository.FindById(ctx, productId)
+	if err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+
+	schedule := entity.ProductPriceSchedule{
+		ProductId: product.ProductId.String(),
+		Type:      request.Type,
+		Price:     request.Price,
+		StartsAt:  request.StartsAt,
+		Status:    "pending",
+	}
+	if request.Type == "sale" {
+		if !request.EndsAt.After(request.StartsAt) {
+			panic(common.NewValidationError("EndsAt", "this field is after starts_at"))
+		}
+		if !request.EndsAt.After(time.Now()) {
+			panic(common.NewValidationError("EndsAt", "this field is in the future"))
+		}
+		overlaps, err := priceService.ProductPriceRepository.HasOverlappingSale(ctx, schedule.ProductId, request.StartsAt, *request.EndsAt)
+		exception.PanicLogging(err)
+		if overlaps {
+			panic(common.NewValidationError("StartsAt", "this field overlaps another sale of the product"))
+		}
+		schedule.EndsAt = request.EndsAt
+	}
+
+	schedule, err = priceService.ProductPriceRepository.InsertSchedule(ctx, schedule)
+	exception.PanicLogging(err)
+
+	// A schedule that is already due takes effect now rather than on the next scheduler tick.
+	if !schedule.StartsAt.After(time.Now()) {
+		schedule = priceService.applySchedule(ctx, schedule.Id)
+		invalidateCatalogue(priceService.Cache, ctx)
+	}
+	return newProductPriceScheduleModel(schedule)
+}
+
+func (priceService *productPriceServiceImpl) FindSchedules(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]model.ProductPriceScheduleModel, model.PageInfoModel) {
+	schedules, pageInfo, err := priceService.ProductPriceRepository.FindSchedules(ctx, productId, listQuery)
+	exception.PanicLogging(err)
+
+	responses := []model.ProductPriceScheduleModel{}
+	for _, schedule := range schedules {
+		responses = append(responses, newProductPriceScheduleModel(schedule))
+	}
+	return responses, pageInfo
+}
+
+// CancelSchedule drops a pending schedule, or ends a running sale now and restores the regular price.
+func (priceService *productPriceServiceImpl) CancelSchedule(ctx context.Context, id uint) model.ProductPriceScheduleModel {
+	schedule, err := priceService.ProductPriceRepository.FindScheduleById(ctx, id)
+	if err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+
+	wasActive := schedule.Status == "active"
+	schedule, err = priceService.ProductPriceRepository.CancelSchedule(ctx, id, time.Now())
+	if err != nil {
+		panic(common.NewValidationError("id", err.Error()))
+	}
+
+	if wasActive {
+		evictProducts(priceService.Cache, ctx, schedule.ProductId)
+		invalidateCatalogue(priceService.Cache, ctx)
+	}
+	return newProductPriceScheduleModel(schedule)
+}
+
+func (priceService *productPriceServiceImpl) FindHistory(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]model.ProductPriceHistoryModel, model.PageInfoModel) {
+	histories, pageInfo, err := priceService.ProductPriceRepository.FindHistory(ctx, productId, listQuery)
+	exception.PanicLogging(err)
+
+	responses := []model.ProductPriceHistoryModel{}
+	for _, history := range histories {
+		responses = append(responses, model.ProductPriceHistoryModel{
+			Id:         history.Id,
+			ProductId:  history.ProductId,
+			OldPrice:   history.OldPrice,
+			NewPrice:   history.NewPrice,
+			Reason:     history.Reason,
+			ScheduleId: history.ScheduleId,
+			CreatedAt:  history.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return responses, pageInfo
+}
+
+// ApplyDueSchedules starts and ends sales and applies scheduled prices that are due. It runs
+// from the price scheduler; a schedule that fails is logged and retried on the next run.
+func (priceService *productPriceServiceImpl) ApplyDueSchedules(ctx context.Context) error {
+	now := time.Now()
+	ids, err := priceService.ProductPriceRepository.FindDueScheduleIds(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, id := range ids {
+		schedule, applied, err := priceService.ProductPriceRepository.ApplySchedule(ctx, id, now)
+		if err != nil {
+			common.NewLogger().Error("Failed to apply price schedule ", id, ": ", err.Error())
+			continue
+		}
+		if applied {
+			evictProducts(priceService.Cache, ctx, schedule.ProductId)
+			changed = true
+		}
+	}
+
+	if changed {
+		invalidateCatalogue(priceService.Cache, ctx)
+	}
+	return nil
+}
+
+func (priceService *productPriceServiceImpl) applySchedule(ctx context.Context, id uint) entity.ProductPriceSchedule {
+	schedule, applied, err := priceService.ProductPriceRepository.ApplySchedule(ctx, id, time.Now())
+	exception.PanicLogging(err)
+	if applied {
+		evictProducts(priceService.Cache, ctx, schedule.ProductId)
+	}
+	return schedule
+}
+
+func newProductPriceScheduleModel(schedule entity.ProductPriceSchedule) model.ProductPriceScheduleModel {
+	response := model.ProductPriceScheduleModel{
+		Id:        schedule.Id,
+		ProductId: schedule.ProductId,
+		Type:      schedule.Type,
+		Price:     schedule.Price,
+		StartsAt:  schedule.StartsAt.Format(time.RFC3339),
+		Status:    schedule.Status,
+		CreatedAt: schedule.CreatedAt.Format(time.RFC3339),
+	}
+	if schedule.EndsAt != nil {
+		response.EndsAt = schedule.EndsAt.Format(time.RFC3339)
+	}
+	return response
+}
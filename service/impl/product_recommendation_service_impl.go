@@ -0,0 +1,190 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
+	"sort"
+	"time"
+)
+
+const (
+	productRecommendationLimit = 8
+	// productRecommendationCategoryPool caps how many of the best rated products of a category are
+	// considered as related to each product in it, so large categories stay cheap to rank.
+	productRecommendationCategoryPool = 50
+	productRecommendationLockKey      = "product_recommendation:refresh"
+	productRecommendationLockTtl      = 10 * time.Minute
+
+	// Related products blend how often they were bought together, whether they share the
+	// category, and their rating; the weights add up to 1.
+	relatedCoPurchaseWeight = 0.6
+	relatedCategoryWeight   = 0.3
+	relatedRatingWeight     = 0.1
+)
+
+func NewProductRecommendationServiceImpl(recommendationRepository *repository.ProductRecommendationRepository, productRepository *repository.ProductRepository, cache *redis.Client) service.ProductRecommendationService {
+	return &productRecommendationServiceImpl{
+		ProductRecommendationRepository: *recommendationRepository,
+		ProductRepository:               *productRepository,
+		Cache:                           cache,
+	}
+}
+
+type productRecommendationServiceImpl struct {
+	repository.ProductRecommendationRepository
+	repository.ProductRepository
+	Cache *redis.Client
+}
+
+func (recommendationService *productRecommendationServiceImpl) FindByProductId(ctx context.Context, productId string) model.ProductRecommendationModel {
+	product, err := recommendationService.ProductRepository.FindById(ctx, productId)
+	if err != nil || product.DeletedAt.Valid {
+		panic(exception.NotFoundError{
+			Message: "product Not Found",
+		})
+	}
+
+	boughtTogether, err := recommendationService.ProductRecommendationRepository.FindByProductId(ctx, productId, "bought_together", productRecommendationLimit)
+	exception.PanicLogging(err)
+	related, err := recommendationService.ProductRecommendationRepository.FindByProductId(ctx, productId, "related", productRecommendationLimit)
+	exception.PanicLogging(err)
+
+	return model.ProductRecommendationModel{
+		FrequentlyBoughtTogether: newRecommendedProductModels(boughtTogether),
+		Related:                  newRecommendedProductModels(related),
+	}
+}
+
+// Refresh rebuilds every recommendation from order history and the catalogue. It runs from the
+// recommendation scheduler; a Redis lock keeps several instances from rebuilding at once.
+func (recommendationService *productRecommendationServiceImpl) Refresh(ctx context.Context) error {
+	locked, err := recommendationService.Cache.SetNX(ctx, productRecommendationLockKey, time.Now().Unix(), productRecommendationLockTtl).Result()
+	if err != nil {
+		common.NewLogger().Error("Failed to take product recommendation lock: ", err.Error())
+	} else if !locked {
+		return nil
+	}
+
+	products, err := recommendationService.ProductRecommendationRepository.FindCandidates(ctx)
+	if err != nil {
+		return err
+	}
+	coPurchases, err := recommendationService.ProductRecommendationRepository.FindCoPurchases(ctx)
+	if err != nil {
+		return err
+	}
+
+	recommendations := buildProductRecommendations(products, coPurchases, time.Now())
+	if err := recommendationService.ProductRecommendationRepository.ReplaceAll(ctx, recommendations); err != nil {
+		return err
+	}
+	common.NewLogger().Info("Refreshed ", len(recommendations), " product recommendations")
+	return nil
+}
+
+// buildProductRecommendations ranks, for every product, the products most often bought together
+// with it and the most related ones. Out of stock products are never recommended.
+func buildProductRecommendations(products []entity.Product, coPurchases []entity.ProductRecommendation, computedAt time.Time) []entity.ProductRecommendation {
+	catalogue := map[string]entity.Product{}
+	categories := map[string][]entity.Product{}
+	for _, product := range products {
+		id := product.ProductId.String()
+		catalogue[id] = product
+		if product.Category != "" && product.Stock > 0 {
+			categories[product.Category] = append(categories[product.Category], product)
+		}
+	}
+	for category, members := range categories {
+		sort.SliceStable(members, func(i, j int) bool {
+			return members[i].RatingAverage > members[j].RatingAverage
+		})
+		if len(members) > productRecommendationCategoryPool {
+			categories[category] = members[:productRecommendationCategoryPool]
+		}
+	}
+
+	boughtWith := map[string]map[string]float64{}
+	for _, coPurchase := range coPurchases {
+		recommended, ok := catalogue[coPurchase.RecommendedProductId]
+		if !ok || recommended.Stock <= 0 {
+			continue
+		}
+		if boughtWith[coPurchase.ProductId] == nil {
+			boughtWith[coPurchase.ProductId] = map[string]float64{}
+		}
+		boughtWith[coPurchase.ProductId][coPurchase.RecommendedProductId] = coPurchase.Score
+	}
+
+	var recommendations []entity.ProductRecommendation
+	for id, product := range catalogue {
+		orders := boughtWith[id]
+		mostOrders := 0.0
+		for _, count := range orders {
+			if count > mostOrders {
+				mostOrders = count
+			}
+		}
+		recommendations = append(recommendations, rankProductRecommendations(id, "bought_together", orders, catalogue, computedAt)...)
+
+		related := map[string]float64{}
+		for recommendedId, count := range orders {
+			related[recommendedId] += relatedCoPurchaseWeight * count / mostOrders
+		}
+		for _, member := range categories[product.Category] {
+			related[member.ProductId.String()] += relatedCategoryWeight
+		}
+		delete(related, id)
+		for recommendedId := range related {
+			related[recommendedId] += relatedRatingWeight * catalogue[recommendedId].RatingAverage / 5
+		}
+		recommendations = append(recommendations, rankProductRecommendations(id, "related", related, catalogue, computedAt)...)
+	}
+	return recommendations
+}
+
+// rankProductRecommendations keeps the best scored products, breaking ties by rating.
+func rankProductRecommendations(productId string, kind string, scores map[string]float64, catalogue map[string]entity.Product, computedAt time.Time) []entity.ProductRecommendation {
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		if catalogue[ids[i]].RatingAverage != catalogue[ids[j]].RatingAverage {
+			return catalogue[ids[i]].RatingAverage > catalogue[ids[j]].RatingAverage
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > productRecommendationLimit {
+		ids = ids[:productRecommendationLimit]
+	}
+
+	recommendations := make([]entity.ProductRecommendation, 0, len(ids))
+	for rank, id := range ids {
+		recommendations = append(recommendations, entity.ProductRecommendation{
+			ProductId:            productId,
+			Kind:                 kind,
+			RecommendedProductId: id,
+			Score:                scores[id],
+			Rank:                 rank + 1,
+			ComputedAt:           computedAt,
+		})
+	}
+	return recommendations
+}
+
+func newRecommendedProductModels(recommendations []entity.ProductRecommendation) []model.ProductModel {
+	responses := []model.ProductModel{}
+	for _, recommendation := range recommendations {
+		responses = append(responses, newProductModel(recommendation.RecommendedProduct))
+	}
+	return responses
+}
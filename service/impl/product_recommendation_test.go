@@ -0,0 +1,77 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBuildProductRecommendations(t *testing.T) {
+	phone := entity.Product{ProductId: uuid.New(), Category: "phones", Stock: 5, RatingAverage: 4}
+	tablet := entity.Product{ProductId: uuid.New(), Category: "phones", Stock: 3, RatingAverage: 3}
+	phoneCase := entity.Product{ProductId: uuid.New(), Category: "accessories", Stock: 10, RatingAverage: 4.5}
+	charger := entity.Product{ProductId: uuid.New(), Category: "accessories", RatingAverage: 5} // out of stock
+	coPurchases := []entity.ProductRecommendation{
+		{ProductId: phone.ProductId.String(), RecommendedProductId: phoneCase.ProductId.String(), Score: 3},
+		{ProductId: phone.ProductId.String(), RecommendedProductId: charger.ProductId.String(), Score: 5},
+		{ProductId: phone.ProductId.String(), RecommendedProductId: uuid.NewString(), Score: 9}, // purged
+	}
+	computedAt := time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC)
+
+	recommendations := buildProductRecommendations([]entity.Product{phone, tablet, phoneCase, charger}, coPurchases, computedAt)
+
+	ranked := map[string][]entity.ProductRecommendation{}
+	for _, recommendation := range recommendations {
+		key := recommendation.ProductId + " " + recommendation.Kind
+		ranked[key] = append(ranked[key], recommendation)
+		assert.Equal(t, computedAt, recommendation.ComputedAt)
+	}
+	ids := func(product entity.Product, kind string) []string {
+		recommended := []string{}
+		for _, recommendation := range ranked[product.ProductId.String()+" "+kind] {
+			recommended = append(recommended, recommendation.RecommendedProductId)
+		}
+		return recommended
+	}
+
+	// Out of stock and purged products are never recommended
+	assert.Equal(t, []string{phoneCase.ProductId.String()}, ids(phone, "bought_together"))
+	assert.Equal(t, []string{}, ids(tablet, "bought_together"))
+
+	// Related mixes co-purchases, the category and ratings, and leaves the product itself out
+	assert.Equal(t, []string{phoneCase.ProductId.String(), tablet.ProductId.String()}, ids(phone, "related"))
+	related := ranked[phone.ProductId.String()+" related"]
+	assert.InDelta(t, relatedCoPurchaseWeight+relatedRatingWeight*4.5/5, related[0].Score, 0.0001)
+	assert.InDelta(t, relatedCategoryWeight+relatedRatingWeight*3/5, related[1].Score, 0.0001)
+	assert.Equal(t, 2, related[1].Rank)
+
+	assert.Equal(t, []string{phone.ProductId.String()}, ids(tablet, "related"))
+	assert.Equal(t, []string{phoneCase.ProductId.String()}, ids(charger, "related"))
+	assert.Equal(t, []string{}, ids(phoneCase, "related"))
+}
+
+func TestRankProductRecommendations(t *testing.T) {
+	catalogue := map[string]entity.Product{
+		"a": {RatingAverage: 3},
+		"b": {RatingAverage: 5},
+		"c": {RatingAverage: 5},
+	}
+	scores := map[string]float64{"a": 1, "b": 1, "c": 1, "d": 2}
+	for i := 0; i < productRecommendationLimit; i++ {
+		scores[string(rune('e'+i))] = 0.5
+	}
+
+	recommendations := rankProductRecommendations("p", "related", scores, catalogue, time.Time{})
+
+	// Ties go to the best rated, then to the lowest id; the rest is cut at the limit
+	assert.Len(t, recommendations, productRecommendationLimit)
+	ranked := []string{}
+	for rank, recommendation := range recommendations {
+		assert.Equal(t, rank+1, recommendation.Rank)
+		ranked = append(ranked, recommendation.RecommendedProductId)
+	}
+	assert.Equal(t, []string{"d", "b", "c", "a", "e", "f", "g", "h"}, ranked)
+	assert.Equal(t, entity.ProductRecommendation{ProductId: "p", Kind: "related", RecommendedProductId: "d", Score: 2, Rank: 1}, recommendations[0])
+}
@@ -0,0 +1,138 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"github.com/go-redis/redis/v9"
+	"gorm.io/gorm"
+)
+
+func NewProductReviewServiceImpl(reviewRepository *repository.ProductReviewRepository, productRepository *repository.ProductRepository, cache *redis.Client) service.ProductReviewService {
+	return &productReviewServiceImpl{
+		ProductReviewRepository: *reviewRepository,
+		ProductRepository:       *productRepository,
+		Cache:                   cache,
+	}
+}
+
+type productReviewServiceImpl struct {
+	repository.ProductReviewRepository
+	repository.ProductRepository
+	Cache *redis.Client
+}
+
+func (reviewService *productReviewServiceImpl) Create(ctx context.Context, userId uint, productId string, request model.ProductReviewCreateModel) (model.ProductReviewModel, error) {
+	common.Validate(request)
+
+	if _, err := reviewService.ProductRepository.FindByProductId(ctx, productId); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.ProductReviewModel{}, errors.New("product not found")
+		}
+		return model.ProductReviewModel{}, err
+	}
+
+	// Only verified buyers can review
+	orderId, err := reviewService.ProductReviewRepository.FindDeliveredOrderId(ctx, userId, productId)
+	if err != nil {
+		return model.ProductReviewModel{}, err
+	}
+
+	if _, err := reviewService.ProductReviewRepository.FindByProductAndUser(ctx, productId, userId); err == nil {
+		return model.ProductReviewModel{}, errors.New("product already reviewed")
+	}
+
+	images, err := json.Marshal(request.Images)
+	if err != nil {
+		return model.ProductReviewModel{}, err
+	}
+
+	// New reviews wait for moderation before they count towards the rating
+	review, err := reviewService.ProductReviewRepository.Insert(ctx, entity.ProductReview{
+		ProductId: productId,
+		UserId:    userId,
+		OrderId:   orderId,
+		Rating:    request.Rating,
+		Title:     request.Title,
+		Body:      request.Body,
+		Images:    string(images),
+		Status:    "pending",
+	})
+	if err != nil {
+		return model.ProductReviewModel{}, err
+	}
+
+	return newProductReviewModel(review), nil
+}
+
+func (reviewService *productReviewServiceImpl) FindByProductId(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]model.ProductReviewModel, model.PageInfoModel, error) {
+	// The storefront only ever sees approved reviews
+	listQuery.Filters = map[string]string{"status": "approved"}
+	return reviewService.findAll(ctx, productId, listQuery)
+}
+
+func (reviewService *productReviewServiceImpl) FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]model.ProductReviewModel, model.PageInfoModel, error) {
+	return reviewService.findAll(ctx, "", listQuery)
+}
+
+func (reviewService *productReviewServiceImpl) Moderate(ctx context.Context, reviewId uint, request model.ProductReviewModerationModel) (model.ProductReviewModel, error) {
+	common.Validate(request)
+
+	if _, err := reviewService.ProductReviewRepository.FindById(ctx, reviewId); err != nil {
+		return model.ProductReviewModel{}, err
+	}
+
+	review, err := reviewService.ProductReviewRepository.UpdateStatus(ctx, reviewId, request.Status)
+	if err != nil {
+		return model.ProductReviewModel{}, err
+	}
+
+	if err := reviewService.ProductReviewRepository.RefreshProductRating(ctx, review.ProductId); err != nil {
+		return model.ProductReviewModel{}, err
+	}
+
+	// The rating is part of the cached product and catalogue pages
+	evictProducts(reviewService.Cache, ctx, review.ProductId)
+	invalidateCatalogue(reviewService.Cache, ctx)
+
+	return newProductReviewModel(review), nil
+}
+
+func (reviewService *productReviewServiceImpl) findAll(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]model.ProductReviewModel, model.PageInfoModel, error) {
+	reviews, pageInfo, err := reviewService.ProductReviewRepository.FindAll(ctx, productId, listQuery)
+	if err != nil {
+		return []model.ProductReviewModel{}, model.PageInfoModel{}, err
+	}
+
+	reviewModels := []model.ProductReviewModel{}
+	for _, review := range reviews {
+		reviewModels = append(reviewModels, newProductReviewModel(review))
+	}
+	return reviewModels, pageInfo, nil
+}
+
+func newProductReviewModel(review entity.ProductReview) model.ProductReviewModel {
+	images := []string{}
+	if review.Images != "" {
+		_ = json.Unmarshal([]byte(review.Images), &images)
+	}
+
+	return model.ProductReviewModel{
+		Id:           review.Id,
+		ProductId:    review.ProductId,
+		UserId:       review.UserId,
+		ReviewerName: review.User.Name,
+		OrderId:      review.OrderId,
+		Rating:       review.Rating,
+		Title:        review.Title,
+		Body:         review.Body,
+		Images:       images,
+		Status:       review.Status,
+		CreatedAt:    review.CreatedAt.String(),
+	}
+}
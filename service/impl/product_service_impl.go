@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"fmt"
 	"github.com/tech-hive/ecommerce/common"
 	"github.com/tech-hive/ecommerce/configuration"
 	"github.com/tech-hive/ecommerce/entity"
@@ -11,98 +12,398 @@ import (
 	"github.com/tech-hive/ecommerce/service"
 	"github.com/go-redis/redis/v9"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"time"
 )
 
-func NewProductServiceImpl(productRepository *repository.ProductRepository, cache *redis.Client) service.ProductService {
-	return &productServiceImpl{ProductRepository: *productRepository, Cache: cache}
+func NewProductServiceImpl(productRepository *repository.ProductRepository, attributeRepository *repository.AttributeRepository, taxRepository *repository.TaxRepository, cache *redis.Client, config configuration.Config) service.ProductService {
+	return &productServiceImpl{
+		ProductRepository:   *productRepository,
+		AttributeRepository: *attributeRepository,
+		TaxRepository:       *taxRepository,
+		Cache:               cache,
+		CachePolicy:         configuration.NewCachePolicy(config, productCachePrefix),
+	}
 }
 
 type productServiceImpl struct {
 	repository.ProductRepository
-	Cache *redis.Client
+	repository.AttributeRepository
+	repository.TaxRepository
+	Cache       *redis.Client
+	CachePolicy configuration.CachePolicy
 }
 
 func (service *productServiceImpl) Create(ctx context.Context, productModel model.ProductCreateOrUpdateModel) model.ProductCreateOrUpdateModel {
 	common.Validate(productModel)
 	product := entity.Product{
+		Sku:         productSku(productModel.Sku),
+		Type:        productType(productModel.Type),
 		Name:        productModel.Name,
 		Description: productModel.Description,
+		Category:    productModel.Category,
+		TaxClass:    service.productTaxClass(ctx, productModel.TaxClass),
 		Price:       productModel.Price,
+		Weight:      productModel.Weight,
 		Stock:       productModel.Stock,
 		ImageUrl:    productModel.ImageUrl,
+		Attributes:  service.productAttributeValues(ctx, productModel.Category, productModel.Attributes, nil),
 	}
+	product.Components = service.productBundleComponents(ctx, "", product.Type, productModel.Components)
 	service.ProductRepository.Insert(ctx, product)
+	invalidateCatalogue(service.Cache, ctx)
 	return productModel
 }
 
+// Update edits a product on sale; archived products must be restored first.
 func (service *productServiceImpl) Update(ctx context.Context, productModel model.ProductCreateOrUpdateModel, id string) model.ProductCreateOrUpdateModel {
 	common.Validate(productModel)
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		panic(common.NewValidationError("id", "id must be a valid product id"))
+	}
+	current := service.findProduct(ctx, id)
+	if current.DeletedAt.Valid {
+		panic(exception.NotFoundError{
+			Message: "product Not Found",
+		})
+	}
+
+	components := productModel.Components
+	if components == nil && productType(productModel.Type) == "bundle" {
+		for _, component := range current.Components {
+			components = append(components, model.ProductBundleComponentModel{
+				ProductId: component.ComponentProductId,
+				Quantity:  component.Quantity,
+			})
+		}
+	}
+
 	product := entity.Product{
-		ProductId:   uuid.MustParse(id),
+		ProductId:   productId,
+		Sku:         productSku(productModel.Sku),
+		Type:        productType(productModel.Type),
 		Name:        productModel.Name,
 		Description: productModel.Description,
+		Category:    productModel.Category,
+		TaxClass:    service.productTaxClass(ctx, productModel.TaxClass),
 		Price:       productModel.Price,
+		Weight:      productModel.Weight,
 		Stock:       productModel.Stock,
 		ImageUrl:    productModel.ImageUrl,
+		Attributes:  service.productAttributeValues(ctx, productModel.Category, productModel.Attributes, current.Attributes),
 	}
+	product.Components = service.productBundleComponents(ctx, id, product.Type, components)
 	service.ProductRepository.Update(ctx, product)
+	evictProducts(service.Cache, ctx, id)
+	service.evictBundles(ctx, id)
+	invalidateCatalogue(service.Cache, ctx)
 	return productModel
 }
 
+// Delete archives the product; it stays resolvable from orders and can be restored or purged.
 func (service *productServiceImpl) Delete(ctx context.Context, id string) {
+	product := service.findProduct(ctx, id)
+	service.ProductRepository.Archive(ctx, product)
+	evictProducts(service.Cache, ctx, id)
+	service.evictBundles(ctx, id)
+	invalidateCatalogue(service.Cache, ctx)
+}
+
+func (service *productServiceImpl) Restore(ctx context.Context, id string) model.ProductModel {
+	product := service.findProduct(ctx, id)
+	if product.DeletedAt.Valid {
+		service.ProductRepository.Restore(ctx, product)
+		product.DeletedAt = gorm.DeletedAt{}
+		evictProducts(service.Cache, ctx, id)
+		service.evictBundles(ctx, id)
+		invalidateCatalogue(service.Cache, ctx)
+	}
+	return newProductModel(product)
+}
+
+// Purge permanently deletes an archived product that no order refers to.
+func (service *productServiceImpl) Purge(ctx context.Context, id string) {
+	product := service.findProduct(ctx, id)
+	if !product.DeletedAt.Valid {
+		panic(common.NewValidationError("id", "product must be archived before it is purged"))
+	}
+
+	orderItems, err := service.ProductRepository.CountOrderItems(ctx, id)
+	exception.PanicLogging(err)
+	if orderItems > 0 {
+		panic(common.NewValidationError("id", fmt.Sprintf("product is referenced by %d order items and cannot be purged", orderItems)))
+	}
+	bundleIds, err := service.ProductRepository.FindBundleIds(ctx, id)
+	exception.PanicLogging(err)
+	if len(bundleIds) > 0 {
+		panic(common.NewValidationError("id", fmt.Sprintf("product is a component of %d bundles and cannot be purged", len(bundleIds))))
+	}
+
+	err = service.ProductRepository.Purge(ctx, product)
+	exception.PanicLogging(err)
+	evictProducts(service.Cache, ctx, id)
+}
+
+// FindById returns a product on sale. The repository also resolves archived products, which order
+// history and the admin paths read, so they are turned away here as not found.
+func (service *productServiceImpl) FindById(ctx context.Context, id string) model.ProductModel {
+	productCache := configuration.SetCache[entity.Product](service.Cache, ctx, service.CachePolicy, productCachePrefix, id, service.ProductRepository.FindById)
+	if productCache.DeletedAt.Valid {
+		panic(exception.NotFoundError{
+			Message: "product Not Found",
+		})
+	}
+	return newProductModel(*productCache)
+}
+
+func (service *productServiceImpl) FindAll(ctx context.Context, searchModel model.ProductSearchModel) ([]model.ProductModel, model.PageInfoModel) {
+	return service.Search(ctx, searchModel)
+}
+
+func (service *productServiceImpl) Search(ctx context.Context, searchModel model.ProductSearchModel) ([]model.ProductModel, model.PageInfoModel) {
+	products, pageInfo := service.ProductRepository.Search(ctx, searchModel)
+
+	var responses []model.ProductModel
+	for _, product := range products {
+		responses = append(responses, newProductModel(product))
+	}
+
+	return responses, pageInfo
+}
+
+func (service *productServiceImpl) FindArchived(ctx context.Context, listQuery model.ListQueryModel) ([]model.ProductModel, model.PageInfoModel) {
+	products, pageInfo := service.ProductRepository.FindArchived(ctx, listQuery)
+
+	var responses []model.ProductModel
+	for _, product := range products {
+		responses = append(responses, newProductModel(product))
+	}
+	return responses, pageInfo
+}
+
+func (service *productServiceImpl) findProduct(ctx context.Context, id string) entity.Product {
 	product, err := service.ProductRepository.FindById(ctx, id)
 	if err != nil {
 		panic(exception.NotFoundError{
 			Message: err.Error(),
 		})
 	}
-	service.ProductRepository.Delete(ctx, product)
+	return product
 }
 
-func (service *productServiceImpl) FindById(ctx context.Context, id string) model.ProductModel {
-	productCache := configuration.SetCache[entity.Product](service.Cache, ctx, "product", id, service.ProductRepository.FindById)
-	return model.ProductModel{
-		Id:          productCache.ProductId.String(),
-		Name:        productCache.Name,
-		Description: productCache.Description,
-		Price:       productCache.Price,
-		Stock:       productCache.Stock,
-		ImageUrl:    productCache.ImageUrl,
-	}
-}
-
-func (service *productServiceImpl) FindAll(ctx context.Context) ([]model.ProductModel, int64) {
-   	products, totalCount := service.ProductRepository.FindAl(ctx)
-
-   	var responses []model.ProductModel
-   	for _, product := range products {
-   		responses = append(responses, model.ProductModel{
-   			Id:          product.ProductId.String(),
-   			Name:        product.Name,
-   			Description: product.Description,
-   			Price:       product.Price,
-   			Stock:       product.Stock,
-   			ImageUrl:    product.ImageUrl,
-   		})
-   	}
-
-   	return responses, totalCount
-   }
-
-func (service *productServiceImpl) Search(ctx context.Context, searchModel model.ProductSearchModel) ([]model.ProductModel, int64) {
-  	products, totalCount := service.ProductRepository.Search(ctx, searchModel)
-
-  	var responses []model.ProductModel
-  	for _, product := range products {
-  		responses = append(responses, model.ProductModel{
-  			Id:          product.ProductId.String(),
-  			Name:        product.Name,
-  			Description: product.Description,
-  			Price:       product.Price,
-  			Stock:       product.Stock,
-  			ImageUrl:    product.ImageUrl,
-  		})
-  	}
-
-  	return responses, totalCount
-  }
+// evictBundles drops the cached bundles containing the product, whose stock follows its stock.
+func (service *productServiceImpl) evictBundles(ctx context.Context, id string) {
+	bundleIds, err := service.ProductRepository.FindBundleIds(ctx, id)
+	if err != nil {
+		common.NewLogger().Error("Failed to find bundles of product ", id, ": ", err.Error())
+		return
+	}
+	evictProducts(service.Cache, ctx, bundleIds...)
+}
+
+func productType(productType string) string {
+	if productType == "" {
+		return "simple"
+	}
+	return productType
+}
+
+// productTaxClass defaults to standard VAT and checks that the tax class exists.
+func (service *productServiceImpl) productTaxClass(ctx context.Context, code string) string {
+	if code == "" {
+		return defaultTaxClass
+	}
+	if _, err := service.TaxRepository.FindByCode(ctx, code); err != nil {
+		panic(common.NewValidationError("TaxClass", "this field is an existing tax class"))
+	}
+	return code
+}
+
+// productSku maps an empty SKU to NULL so products without one don't collide on the unique index.
+func productSku(sku string) *string {
+	if sku == "" {
+		return nil
+	}
+	return &sku
+}
+
+func productSkuValue(sku *string) string {
+	if sku == nil {
+		return ""
+	}
+	return *sku
+}
+
+func newProductModel(product entity.Product) model.ProductModel {
+	response := model.ProductModel{
+		Id:            product.ProductId.String(),
+		Sku:           productSkuValue(product.Sku),
+		Type:          product.Type,
+		Name:          product.Name,
+		Description:   product.Description,
+		Category:      product.Category,
+		TaxClass:      product.TaxClass,
+		Price:         product.Price,
+		Weight:        product.Weight,
+		OriginalPrice: product.CompareAtPrice,
+		Stock:         product.Stock,
+		ImageUrl:      product.ImageUrl,
+		RatingAverage: product.RatingAverage,
+		RatingCount:   product.RatingCount,
+	}
+	if product.SaleEndsAt != nil {
+		response.SaleEndsAt = product.SaleEndsAt.Format(time.RFC3339)
+	}
+	if product.DeletedAt.Valid {
+		response.ArchivedAt = product.DeletedAt.Time.Format(time.RFC3339)
+	}
+	for _, value := range product.Attributes {
+		response.Attributes = append(response.Attributes, model.ProductAttributeModel{
+			Code:  value.Attribute.Code,
+			Name:  value.Attribute.Name,
+			Type:  value.Attribute.Type,
+			Unit:  value.Attribute.Unit,
+			Value: productAttributeModelValue(value),
+		})
+	}
+	for _, component := range product.Components {
+		response.Components = append(response.Components, model.ProductBundleComponentModel{
+			ProductId: component.ComponentProductId,
+			Name:      component.Component.Name,
+			Quantity:  component.Quantity,
+		})
+	}
+	return response
+}
+
+// productBundleComponents checks the components of a bundle, reporting every problem in one
+// ValidationError. Bundles hold simple products only. Like productAttributeValues it never returns
+// nil, so a product that stops being a bundle loses its components.
+func (service *productServiceImpl) productBundleComponents(ctx context.Context, productId string, productType string, components []model.ProductBundleComponentModel) []entity.ProductBundleComponent {
+	values := []entity.ProductBundleComponent{}
+	if productType != "bundle" {
+		if len(components) > 0 {
+			panic(common.NewValidationError("Components", "this field is only allowed for bundles"))
+		}
+		return values
+	}
+	if len(components) == 0 {
+		panic(common.NewValidationError("Components", "this field is required for bundles"))
+	}
+	if productId != "" {
+		bundleIds, err := service.ProductRepository.FindBundleIds(ctx, productId)
+		exception.PanicLogging(err)
+		if len(bundleIds) > 0 {
+			panic(common.NewValidationError("Type", "a component of other bundles cannot become a bundle"))
+		}
+	}
+
+	var messages []map[string]interface{}
+	seen := map[string]bool{}
+	for i, component := range components {
+		field := fmt.Sprintf("components[%d].product_id", i)
+		message := ""
+		if component.ProductId == productId {
+			message = "a bundle cannot contain itself"
+		} else if seen[component.ProductId] {
+			message = "this product is already a component"
+		} else if product, err := service.ProductRepository.FindByProductId(ctx, component.ProductId); err != nil {
+			message = "product not found"
+		} else if product.Type == "bundle" {
+			message = "a bundle cannot contain another bundle"
+		}
+		seen[component.ProductId] = true
+
+		if message != "" {
+			messages = append(messages, map[string]interface{}{
+				"field":   field,
+				"message": message,
+			})
+			continue
+		}
+		values = append(values, entity.ProductBundleComponent{
+			ComponentProductId: component.ProductId,
+			Quantity:           component.Quantity,
+		})
+	}
+
+	if len(messages) > 0 {
+		panic(common.NewValidationErrors(messages))
+	}
+	return values
+}
+
+// productAttributeValues checks the attribute values of a product with checkProductAttributes,
+// reporting every problem in one ValidationError. It never returns nil, so saving the product
+// always replaces its attribute values.
+func (service *productServiceImpl) productAttributeValues(ctx context.Context, category string, attributes map[string]interface{}, current []entity.ProductAttributeValue) []entity.ProductAttributeValue {
+	categoryAttributes, err := service.AttributeRepository.FindCategoryAttributes(ctx, category)
+	exception.PanicLogging(err)
+
+	values, messages := checkProductAttributes(categoryAttributes, category, attributes, current)
+	if len(messages) > 0 {
+		panic(common.NewValidationErrors(messages))
+	}
+	return values
+}
+
+// checkProductAttributes is the attribute rule of creating, updating and importing products: every
+// required attribute of the category has a value, and every value is valid for an attribute
+// attached to the category. When attributes is nil the product keeps its current values instead;
+// those of attributes the category does not have, e.g. after a change of category, are dropped
+// rather than rejected, so the category can change without resending the attributes.
+func checkProductAttributes(categoryAttributes []entity.CategoryAttribute, category string, attributes map[string]interface{}, current []entity.ProductAttributeValue) ([]entity.ProductAttributeValue, []map[string]interface{}) {
+	kept := attributes == nil
+	if kept {
+		attributes = map[string]interface{}{}
+		for _, value := range current {
+			attributes[value.Attribute.Code] = productAttributeModelValue(value)
+		}
+	}
+
+	var messages []map[string]interface{}
+	values := []entity.ProductAttributeValue{}
+	attached := map[string]bool{}
+	for _, categoryAttribute := range categoryAttributes {
+		definition := categoryAttribute.Attribute
+		attached[definition.Code] = true
+
+		raw, ok := attributes[definition.Code]
+		if !ok || raw == nil {
+			if categoryAttribute.Required {
+				messages = append(messages, map[string]interface{}{
+					"field":   "attributes." + definition.Code,
+					"message": "this field is required",
+				})
+			}
+			continue
+		}
+
+		value, message := newProductAttributeValue(definition, raw)
+		if message != "" {
+			messages = append(messages, map[string]interface{}{
+				"field":   "attributes." + definition.Code,
+				"message": message,
+			})
+			continue
+		}
+		values = append(values, value)
+	}
+
+	var unknown []string
+	for code := range attributes {
+		if !attached[code] && !kept {
+			unknown = append(unknown, code)
+		}
+	}
+	sort.Strings(unknown)
+	for _, code := range unknown {
+		messages = append(messages, map[string]interface{}{
+			"field":   "attributes." + code,
+			"message": "this field is not an attribute of category " + category,
+		})
+	}
+	return values, messages
+}
@@ -0,0 +1,305 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"strings"
+	"time"
+)
+
+type promotionDiscount struct {
+	Promotion entity.Promotion
+	Amount    money.Money
+}
+
+// applyPromotions applies the running automatic promotions and the coupons of a cart. A promotion that
+// does not stack only applies alone, when it saves more than all the stackable promotions together;
+// free shipping is priced apart from the items, so it applies next to them either way.
+// userId is 0 for guests, whose per-customer limits are checked at checkout.
+func applyPromotions(ctx context.Context, promotionRepository repository.PromotionRepository, cart entity.Cart, userId uint, now time.Time) (cartPricing, error) {
+	pricing := cartPricing{Discounts: []promotionDiscount{}, Coupons: []model.CartCouponModel{}}
+	for _, item := range cart.CartItems {
+		pricing.Subtotal = pricing.Subtotal.Add(item.Price.Mul(item.Quantity))
+	}
+
+	automatic, err := promotionRepository.FindAutomatic(ctx, now)
+	if err != nil {
+		return cartPricing{}, err
+	}
+	coupons, err := promotionRepository.FindCartCoupons(ctx, cart.Id)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	redemptions := map[uint]int64{}
+	if userId != 0 {
+		var promotionIds []uint
+		for _, promotion := range append(automatic, coupons...) {
+			if promotion.UsageLimitPerUser != nil {
+				promotionIds = append(promotionIds, promotion.Id)
+			}
+		}
+		redemptions, err = promotionRepository.CountRedemptions(ctx, userId, promotionIds)
+		if err != nil {
+			return cartPricing{}, err
+		}
+	}
+
+	var candidates []promotionDiscount
+	for _, promotion := range automatic {
+		if promotionUnavailable(promotion, userId, redemptions[promotion.Id], now) != "" {
+			continue
+		}
+		if amount, reason := promotionAmount(promotion, cart.CartItems); reason == "" {
+			candidates = append(candidates, promotionDiscount{Promotion: promotion, Amount: amount})
+		}
+	}
+	couponReasons := map[uint]string{}
+	for _, promotion := range coupons {
+		reason := promotionUnavailable(promotion, userId, redemptions[promotion.Id], now)
+		if reason == "" {
+			var amount money.Money
+			amount, reason = promotionAmount(promotion, cart.CartItems)
+			if reason == "" {
+				candidates = append(candidates, promotionDiscount{Promotion: promotion, Amount: amount})
+			}
+		}
+		couponReasons[promotion.Id] = reason
+	}
+
+	applied := map[uint]bool{}
+	for _, discount := range combinePromotions(candidates, pricing.Subtotal) {
+		pricing.Discounts = append(pricing.Discounts, discount)
+		pricing.DiscountTotal = pricing.DiscountTotal.Add(discount.Amount)
+		pricing.FreeShipping = pricing.FreeShipping || discount.Promotion.Type == "free_shipping"
+		applied[discount.Promotion.Id] = true
+	}
+
+	for _, promotion := range coupons {
+		reason := couponReasons[promotion.Id]
+		if reason == "" && !applied[promotion.Id] {
+			reason = "does not combine with a better promotion"
+		}
+		pricing.Coupons = append(pricing.Coupons, model.CartCouponModel{
+			Code:    *promotion.Code,
+			Name:    promotion.Name,
+			Applied: applied[promotion.Id],
+			Reason:  reason,
+		})
+	}
+	return pricing, nil
+}
+
+// promotionUnavailable tells why a promotion cannot be used now, or returns "" when it can. userId
+// is 0 for guests; used is how many orders of the customer already redeemed it.
+func promotionUnavailable(promotion entity.Promotion, userId uint, used int64, now time.Time) string {
+	switch {
+	case !promotion.Active:
+		return "coupon is no longer active"
+	case promotion.UserId != nil && *promotion.UserId != userId:
+		return "coupon was issued to another customer"
+	case promotion.StartsAt != nil && promotion.StartsAt.After(now):
+		return "coupon is not valid yet"
+	case promotion.EndsAt != nil && !promotion.EndsAt.After(now):
+		return "coupon has expired"
+	case promotion.UsageLimit != nil && promotion.UsageCount >= *promotion.UsageLimit:
+		return "coupon has been fully redeemed"
+	case promotion.UsageLimitPerUser != nil && used >= int64(*promotion.UsageLimitPerUser):
+		return "coupon has already been used the maximum number of times"
+	}
+	return ""
+}
+
+// promotionAmount returns the discount of a promotion on the cart items it covers, or why it does not apply.
+func promotionAmount(promotion entity.Promotion, items []entity.CartItem) (money.Money, string) {
+	var eligible []entity.CartItem
+	var eligibleSubtotal money.Money
+	for _, item := range items {
+		if promotionCovers(promotion, item) {
+			eligible = append(eligible, item)
+			eligibleSubtotal = eligibleSubtotal.Add(item.Price.Mul(item.Quantity))
+		}
+	}
+	if len(eligible) == 0 {
+		return money.Money{}, "no product in the cart qualifies"
+	}
+	if eligibleSubtotal.LessThan(promotion.MinSpend) {
+		return money.Money{}, fmt.Sprintf("spend %s more to use this coupon", promotion.MinSpend.Sub(eligibleSubtotal))
+	}
+
+	switch promotion.Type {
+	case "percentage":
+		return eligibleSubtotal.Percent(promotion.Value), ""
+	case "fixed":
+		return money.Min(promotion.Amount, eligibleSubtotal), ""
+	case "buy_x_get_y":
+		// The cheapest units are the free ones: Y of every X+Y units bought
+		var unitPrices []money.Money
+		for _, item := range eligible {
+			for i := int32(0); i < item.Quantity; i++ {
+				unitPrices = append(unitPrices, item.Price)
+			}
+		}
+		groupSize := int(promotion.BuyQuantity + promotion.GetQuantity)
+		freeUnits := len(unitPrices) / groupSize * int(promotion.GetQuantity)
+		if freeUnits == 0 {
+			return money.Money{}, fmt.Sprintf("buy %d to get %d", promotion.BuyQuantity, promotion.GetQuantity)
+		}
+		sort.Slice(unitPrices, func(i, j int) bool {
+			return unitPrices[i].LessThan(unitPrices[j])
+		})
+		var freeTotal money.Money
+		for _, price := range unitPrices[:freeUnits] {
+			freeTotal = freeTotal.Add(price)
+		}
+		return freeTotal.Percent(promotion.Value), ""
+	}
+	return money.Money{}, ""
+}
+
+func promotionCovers(promotion entity.Promotion, item entity.CartItem) bool {
+	switch promotion.Scope {
+	case "product":
+		return promotion.ScopeProductId != nil && *promotion.ScopeProductId == item.ProductId
+	case "category":
+		return promotion.ScopeCategory != nil && *promotion.ScopeCategory == item.Product.Category
+	}
+	return true
+}
+
+// combinePromotions picks the promotions to apply: all the stackable ones, or the best promotion that
+// does not stack when it saves more. Discounts are capped so they never exceed the subtotal.
+// Free shipping waives the shipping cost, which is not known until checkout, instead of discounting
+// the items, so it cannot be weighed against them: the first free shipping promotion applies on
+// top of the item discounts, stackable or not.
+func combinePromotions(candidates []promotionDiscount, subtotal money.Money) []promotionDiscount {
+	var stackable []promotionDiscount
+	var stackableTotal money.Money
+	var best *promotionDiscount
+	var freeShipping *promotionDiscount
+	for i, candidate := range candidates {
+		if candidate.Promotion.Type == "free_shipping" {
+			if freeShipping == nil {
+				freeShipping = &candidates[i]
+			}
+			continue
+		}
+		if candidate.Promotion.Stackable {
+			stackable = append(stackable, candidate)
+			stackableTotal = stackableTotal.Add(candidate.Amount)
+			continue
+		}
+		if best == nil || candidate.Amount.GreaterThan(best.Amount) {
+			best = &candidates[i]
+		}
+	}
+
+	chosen := stackable
+	if best != nil && (len(stackable) == 0 || best.Amount.GreaterThan(stackableTotal)) {
+		chosen = []promotionDiscount{*best}
+	}
+
+	remaining := subtotal
+	for i := range chosen {
+		if chosen[i].Amount.GreaterThan(remaining) {
+			chosen[i].Amount = remaining
+		}
+		remaining = remaining.Sub(chosen[i].Amount)
+	}
+	if freeShipping != nil {
+		chosen = append(chosen, *freeShipping)
+	}
+	return chosen
+}
+
+// redeemPromotions counts the applied promotions against their usage limits inside the order
+// transaction; a promotion used up since the cart was priced fails the order. The promotion row
+// stays locked until the order commits, so concurrent orders cannot both take its last use.
+func redeemPromotions(tx *gorm.DB, discounts []promotionDiscount, userId uint, orderId uint) error {
+	for _, discount := range discounts {
+		var promotion entity.Promotion
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", discount.Promotion.Id).First(&promotion).Error; err != nil {
+			return err
+		}
+
+		var used int64
+		if promotion.UsageLimitPerUser != nil {
+			err := tx.Model(&entity.PromotionRedemption{}).
+				Where("promotion_id = ? AND user_id = ?", promotion.Id, userId).
+				Count(&used).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := promotionExhausted(promotion, used); err != nil {
+			return err
+		}
+
+		err := tx.Model(&entity.Promotion{}).
+			Where("id = ?", promotion.Id).
+			Update("usage_count", gorm.Expr("usage_count + 1")).Error
+		if err != nil {
+			return err
+		}
+		redemption := entity.PromotionRedemption{PromotionId: promotion.Id, UserId: userId, OrderId: orderId}
+		if err := tx.Create(&redemption).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// promotionExhausted tells whether the usage limits of a promotion refuse one more redemption by a
+// customer who already redeemed it used times.
+func promotionExhausted(promotion entity.Promotion, used int64) error {
+	if promotion.UsageLimit != nil && promotion.UsageCount >= *promotion.UsageLimit {
+		return fmt.Errorf("promotion %s is no longer available", promotion.Name)
+	}
+	if promotion.UsageLimitPerUser != nil && used >= int64(*promotion.UsageLimitPerUser) {
+		return fmt.Errorf("promotion %s has already been used the maximum number of times", promotion.Name)
+	}
+	return nil
+}
+
+func newDiscountModel(discount promotionDiscount) model.DiscountModel {
+	promotionId := discount.Promotion.Id
+	discountModel := model.DiscountModel{
+		PromotionId: &promotionId,
+		Name:        discount.Promotion.Name,
+		Type:        discount.Promotion.Type,
+		Amount:      discount.Amount,
+	}
+	if discount.Promotion.Code != nil {
+		discountModel.Code = *discount.Promotion.Code
+	}
+	return discountModel
+}
+
+func newOrderDiscountModels(discounts []entity.OrderDiscount) []model.DiscountModel {
+	discountModels := []model.DiscountModel{}
+	for _, discount := range discounts {
+		discountModel := model.DiscountModel{
+			PromotionId: discount.PromotionId,
+			Name:        discount.Name,
+			Type:        discount.Type,
+			Amount:      discount.Amount,
+		}
+		if discount.Code != nil {
+			discountModel.Code = *discount.Code
+		}
+		discountModels = append(discountModels, discountModel)
+	}
+	return discountModels
+}
+
+// normalizeCouponCode makes coupon codes case-insensitive.
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,160 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPromotionAmount(t *testing.T) {
+	phones := "phones"
+	caseId := "case"
+	items := []entity.CartItem{
+		{ProductId: "phone", Quantity: 1, Price: money.New(20000, 0), Product: entity.Product{Category: "phones"}},
+		{ProductId: "case", Quantity: 3, Price: money.New(500, 0), Product: entity.Product{Category: "accessories"}},
+		{ProductId: "cable", Quantity: 2, Price: money.New(300, 0), Product: entity.Product{Category: "accessories"}},
+	}
+
+	cases := map[string]struct {
+		promotion entity.Promotion
+		amount    money.Money
+		reason    string
+	}{
+		"percentage of the order": {
+			promotion: entity.Promotion{Type: "percentage", Value: 10, Scope: "order"},
+			amount:    money.New(2210, 0),
+		},
+		"percentage of a category": {
+			promotion: entity.Promotion{Type: "percentage", Value: 10, Scope: "category", ScopeCategory: &phones},
+			amount:    money.New(2000, 0),
+		},
+		"fixed capped at the eligible items": {
+			promotion: entity.Promotion{Type: "fixed", Amount: money.New(2000, 0), Scope: "product", ScopeProductId: &caseId},
+			amount:    money.New(1500, 0),
+		},
+		"below the minimum spend": {
+			promotion: entity.Promotion{Type: "fixed", Amount: money.New(100, 0), Scope: "product", ScopeProductId: &caseId, MinSpend: money.New(2000, 0)},
+			reason:    "spend 500.00 more to use this coupon",
+		},
+		"no item in scope": {
+			promotion: entity.Promotion{Type: "percentage", Value: 10, Scope: "category", ScopeCategory: &caseId},
+			reason:    "no product in the cart qualifies",
+		},
+		"buy 2 get 1 frees the cheapest units": {
+			// 6 units make 2 groups of 3: the two cables at 300 are free
+			promotion: entity.Promotion{Type: "buy_x_get_y", Value: 100, Scope: "order", BuyQuantity: 2, GetQuantity: 1},
+			amount:    money.New(600, 0),
+		},
+		"buy 1 get 1 at half price": {
+			// 6 units make 3 groups of 2: both cables and a case are half price
+			promotion: entity.Promotion{Type: "buy_x_get_y", Value: 50, Scope: "order", BuyQuantity: 1, GetQuantity: 1},
+			amount:    money.New(550, 0),
+		},
+		"buy x get y without enough units": {
+			promotion: entity.Promotion{Type: "buy_x_get_y", Value: 100, Scope: "category", ScopeCategory: &phones, BuyQuantity: 1, GetQuantity: 1},
+			reason:    "buy 1 to get 1",
+		},
+		"free shipping": {
+			promotion: entity.Promotion{Type: "free_shipping", Scope: "order"},
+		},
+	}
+	for name, c := range cases {
+		amount, reason := promotionAmount(c.promotion, items)
+		assert.Equal(t, c.amount, amount, name)
+		assert.Equal(t, c.reason, reason, name)
+	}
+}
+
+func TestCombinePromotions(t *testing.T) {
+	discount := func(id uint, promotionType string, stackable bool, units int64) promotionDiscount {
+		return promotionDiscount{Promotion: entity.Promotion{Id: id, Type: promotionType, Stackable: stackable}, Amount: money.New(units, 0)}
+	}
+	ids := func(discounts []promotionDiscount) []uint {
+		chosen := []uint{}
+		for _, discount := range discounts {
+			chosen = append(chosen, discount.Promotion.Id)
+		}
+		return chosen
+	}
+	subtotal := money.New(1000, 0)
+
+	cases := map[string]struct {
+		candidates []promotionDiscount
+		chosen     []uint
+	}{
+		"stackable promotions add up": {
+			candidates: []promotionDiscount{discount(1, "percentage", true, 100), discount(2, "fixed", true, 200)},
+			chosen:     []uint{1, 2},
+		},
+		"the best promotion that does not stack": {
+			candidates: []promotionDiscount{discount(1, "percentage", false, 100), discount(2, "fixed", false, 300)},
+			chosen:     []uint{2},
+		},
+		"stackable promotions saving more win": {
+			candidates: []promotionDiscount{discount(1, "percentage", true, 200), discount(2, "fixed", true, 200), discount(3, "fixed", false, 300)},
+			chosen:     []uint{1, 2},
+		},
+		"a promotion that does not stack saving more wins": {
+			candidates: []promotionDiscount{discount(1, "percentage", true, 200), discount(2, "fixed", false, 300)},
+			chosen:     []uint{2},
+		},
+		"free shipping that does not stack applies next to the item discounts": {
+			candidates: []promotionDiscount{discount(1, "percentage", false, 200), discount(2, "free_shipping", false, 0), discount(3, "free_shipping", true, 0)},
+			chosen:     []uint{1, 2},
+		},
+		"free shipping alone": {
+			candidates: []promotionDiscount{discount(1, "free_shipping", false, 0)},
+			chosen:     []uint{1},
+		},
+		"nothing to apply": {
+			chosen: []uint{},
+		},
+	}
+	for name, c := range cases {
+		assert.Equal(t, c.chosen, ids(combinePromotions(c.candidates, subtotal)), name)
+	}
+
+	capped := combinePromotions([]promotionDiscount{discount(1, "fixed", true, 800), discount(2, "fixed", true, 800)}, subtotal)
+	assert.Equal(t, money.New(800, 0), capped[0].Amount)
+	assert.Equal(t, money.New(200, 0), capped[1].Amount)
+}
+
+func TestPromotionExhausted(t *testing.T) {
+	limit := int32(2)
+	once := int32(1)
+
+	cases := map[string]struct {
+		promotion entity.Promotion
+		used      int64
+		exhausted bool
+	}{
+		"no limits":                  {promotion: entity.Promotion{UsageCount: 100}, used: 10},
+		"under the usage limit":      {promotion: entity.Promotion{UsageLimit: &limit, UsageCount: 1}},
+		"usage limit reached":        {promotion: entity.Promotion{UsageLimit: &limit, UsageCount: 2}, exhausted: true},
+		"under the per user limit":   {promotion: entity.Promotion{UsageLimitPerUser: &limit}, used: 1},
+		"per user limit reached":     {promotion: entity.Promotion{UsageLimitPerUser: &once}, used: 1, exhausted: true},
+		"others' uses do not count":  {promotion: entity.Promotion{UsageLimit: &limit, UsageLimitPerUser: &once, UsageCount: 1}},
+		"both limits, usage reached": {promotion: entity.Promotion{UsageLimit: &limit, UsageLimitPerUser: &once, UsageCount: 2}, exhausted: true},
+	}
+	for name, c := range cases {
+		err := promotionExhausted(c.promotion, c.used)
+		assert.Equal(t, c.exhausted, err != nil, name)
+	}
+}
+
+func TestPromotionUnavailable(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	customer := uint(7)
+	coupon := entity.Promotion{Active: true, UserId: &customer, StartsAt: &earlier}
+
+	assert.Equal(t, "", promotionUnavailable(coupon, 7, 0, now))
+	assert.Equal(t, "coupon was issued to another customer", promotionUnavailable(coupon, 8, 0, now))
+	assert.Equal(t, "coupon was issued to another customer", promotionUnavailable(coupon, 0, 0, now)) // guest
+	assert.Equal(t, "", promotionUnavailable(entity.Promotion{Active: true}, 0, 0, now))
+
+	coupon.Active = false
+	assert.Equal(t, "coupon is no longer active", promotionUnavailable(coupon, 7, 0, now))
+}
@@ -0,0 +1,185 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"time"
+)
+
+func NewPromotionServiceImpl(promotionRepository *repository.PromotionRepository, productRepository *repository.ProductRepository) service.PromotionService {
+	return &promotionServiceImpl{
+		PromotionRepository: *promotionRepository,
+		ProductRepository:   *productRepository,
+	}
+}
+
+type promotionServiceImpl struct {
+	repository.PromotionRepository
+	repository.ProductRepository
+}
+
+func (promotionService *promotionServiceImpl) Create(ctx context.Context, request model.PromotionCreateOrUpdateModel) model.PromotionModel {
+	promotion := promotionService.newPromotion(ctx, 0, request)
+	promotion, err := promotionService.PromotionRepository.Insert(ctx, promotion)
+	exception.PanicLogging(err)
+	return newPromotionModel(promotion)
+}
+
+func (promotionService *promotionServiceImpl) Update(ctx context.Context, id uint, request model.PromotionCreateOrUpdateModel) model.PromotionModel {
+	current := promotionService.findById(ctx, id)
+
+	promotion := promotionService.newPromotion(ctx, id, request)
+	promotion.Id = current.Id
+	promotion, err := promotionService.PromotionRepository.Update(ctx, promotion)
+	exception.PanicLogging(err)
+	return newPromotionModel(promotion)
+}
+
+// Delete removes a promotion; orders keep the snapshot of the discounts it gave.
+func (promotionService *promotionServiceImpl) Delete(ctx context.Context, id uint) {
+	promotionService.findById(ctx, id)
+	err := promotionService.PromotionRepository.Delete(ctx, id)
+	exception.PanicLogging(err)
+}
+
+func (promotionService *promotionServiceImpl) FindById(ctx context.Context, id uint) model.PromotionModel {
+	return newPromotionModel(promotionService.findById(ctx, id))
+}
+
+func (promotionService *promotionServiceImpl) FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]model.PromotionModel, model.PageInfoModel) {
+	promotions, pageInfo, err := promotionService.PromotionRepository.FindAll(ctx, listQuery)
+	exception.PanicLogging(err)
+
+	responses := []model.PromotionModel{}
+	for _, promotion := range promotions {
+		responses = append(responses, newPromotionModel(promotion))
+	}
+	return responses, pageInfo
+}
+
+func (promotionService *promotionServiceImpl) findById(ctx context.Context, id uint) entity.Promotion {
+	promotion, err := promotionService.PromotionRepository.FindById(ctx, id)
+	if err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+	return promotion
+}
+
+// newPromotion validates a request and builds the promotion it describes. id is the promotion being
+// updated, or 0 for a new one.
+func (promotionService *promotionServiceImpl) newPromotion(ctx context.Context, id uint, request model.PromotionCreateOrUpdateModel) entity.Promotion {
+	common.Validate(request)
+
+	switch request.Type {
+	case "percentage", "buy_x_get_y":
+		if request.Value == 0 {
+			panic(common.NewValidationError("Value", "this field is required for percentage and buy_x_get_y promotions"))
+		}
+		if request.Value > 100 {
+			panic(common.NewValidationError("Value", "this field is at most 100 for percentage and buy_x_get_y promotions"))
+		}
+	case "fixed":
+		if !request.Amount.IsPositive() {
+			panic(common.NewValidationError("Amount", "this field is required for fixed promotions"))
+		}
+	}
+	if request.StartsAt != nil && request.EndsAt != nil && !request.EndsAt.After(*request.StartsAt) {
+		panic(common.NewValidationError("EndsAt", "this field is after starts_at"))
+	}
+
+	promotion := entity.Promotion{
+		Name:              request.Name,
+		Type:              request.Type,
+		MinSpend:          request.MinSpend,
+		Scope:             request.Scope,
+		UsageLimit:        request.UsageLimit,
+		UsageLimitPerUser: request.UsageLimitPerUser,
+		Stackable:         request.Stackable,
+		Active:            request.Active == nil || *request.Active,
+		StartsAt:          request.StartsAt,
+		EndsAt:            request.EndsAt,
+	}
+	switch promotion.Type {
+	case "percentage", "buy_x_get_y":
+		promotion.Value = request.Value
+	case "fixed":
+		promotion.Amount = request.Amount
+	}
+	if promotion.Type == "buy_x_get_y" {
+		promotion.BuyQuantity = request.BuyQuantity
+		promotion.GetQuantity = request.GetQuantity
+	}
+
+	if request.Code != "" {
+		code := normalizeCouponCode(request.Code)
+		if existing, err := promotionService.PromotionRepository.FindByCode(ctx, code); err == nil && existing.Id != id {
+			panic(common.NewValidationError("Code", "this field is unique"))
+		}
+		promotion.Code = &code
+	}
+
+	switch promotion.Scope {
+	case "product":
+		product, err := promotionService.ProductRepository.FindByProductId(ctx, request.ProductId)
+		if err != nil {
+			panic(common.NewValidationError("ProductId", "this field is an existing product"))
+		}
+		productId := product.ProductId.String()
+		promotion.ScopeProductId = &productId
+	case "category":
+		category := request.Category
+		promotion.ScopeCategory = &category
+	default:
+		promotion.Scope = "order"
+	}
+	return promotion
+}
+
+func newPromotionModel(promotion entity.Promotion) model.PromotionModel {
+	promotionModel := model.PromotionModel{
+		Id:                promotion.Id,
+		Automatic:         promotion.Code == nil,
+		Name:              promotion.Name,
+		Type:              promotion.Type,
+		Value:             promotion.Value,
+		MinSpend:          promotion.MinSpend,
+		Scope:             promotion.Scope,
+		UserId:            promotion.UserId,
+		BuyQuantity:       promotion.BuyQuantity,
+		GetQuantity:       promotion.GetQuantity,
+		UsageLimit:        promotion.UsageLimit,
+		UsageLimitPerUser: promotion.UsageLimitPerUser,
+		UsageCount:        promotion.UsageCount,
+		Stackable:         promotion.Stackable,
+		Active:            promotion.Active,
+		CreatedAt:         promotion.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         promotion.UpdatedAt.Format(time.RFC3339),
+	}
+	if promotion.Code != nil {
+		promotionModel.Code = *promotion.Code
+	}
+	if promotion.Type == "fixed" {
+		amount := promotion.Amount
+		promotionModel.Amount = &amount
+	}
+	if promotion.ScopeProductId != nil {
+		promotionModel.ProductId = *promotion.ScopeProductId
+	}
+	if promotion.ScopeCategory != nil {
+		promotionModel.Category = *promotion.ScopeCategory
+	}
+	if promotion.StartsAt != nil {
+		promotionModel.StartsAt = promotion.StartsAt.Format(time.RFC3339)
+	}
+	if promotion.EndsAt != nil {
+		promotionModel.EndsAt = promotion.EndsAt.Format(time.RFC3339)
+	}
+	return promotionModel
+}
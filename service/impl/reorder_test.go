@@ -0,0 +1,51 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReorderQuantity(t *testing.T) {
+	cases := map[string]struct {
+		ordered, stock, inCart int32
+		added                  int32
+		reason                 string
+	}{
+		"in stock":                      {ordered: 2, stock: 10, added: 2},
+		"in stock next to the cart":     {ordered: 2, stock: 10, inCart: 8, added: 2},
+		"low stock":                     {ordered: 5, stock: 3, added: 3, reason: "low_stock"},
+		"low stock next to the cart":    {ordered: 5, stock: 10, inCart: 7, added: 3, reason: "low_stock"},
+		"out of stock":                  {ordered: 1, stock: 0, reason: "out_of_stock"},
+		"the cart already holds it all": {ordered: 1, stock: 4, inCart: 4, reason: "out_of_stock"},
+		"the cart holds more than left": {ordered: 1, stock: 2, inCart: 3, reason: "out_of_stock"},
+	}
+	for name, c := range cases {
+		added, reason := reorderQuantity(c.ordered, c.stock, c.inCart)
+		assert.Equal(t, c.added, added, name)
+		assert.Equal(t, c.reason, reason, name)
+	}
+}
+
+func TestReorderCartItem(t *testing.T) {
+	product := entity.Product{Stock: 10, Price: money.New(450, 0)}
+
+	// A new line and a line already in the cart both take today's price
+	item, added, reason := reorderCartItem(entity.CartItem{ProductId: "case"}, product, 2)
+	assert.Equal(t, entity.CartItem{ProductId: "case", Quantity: 2, Price: money.New(450, 0)}, item)
+	assert.Equal(t, int32(2), added)
+	assert.Equal(t, "", reason)
+
+	item, added, reason = reorderCartItem(entity.CartItem{Id: 7, ProductId: "case", Quantity: 7, Price: money.New(500, 0)}, product, 5)
+	assert.Equal(t, entity.CartItem{Id: 7, ProductId: "case", Quantity: 10, Price: money.New(450, 0)}, item)
+	assert.Equal(t, int32(3), added)
+	assert.Equal(t, "low_stock", reason)
+
+	// A line with no room left is left as it is
+	full := entity.CartItem{Id: 7, ProductId: "case", Quantity: 10, Price: money.New(500, 0)}
+	item, added, reason = reorderCartItem(full, product, 1)
+	assert.Equal(t, full, item)
+	assert.Equal(t, int32(0), added)
+	assert.Equal(t, "out_of_stock", reason)
+}
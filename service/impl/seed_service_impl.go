@@ -3,6 +3,7 @@ package impl
 import (
 	"context"
 	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/money"
 	"github.com/tech-hive/ecommerce/repository"
 	"github.com/tech-hive/ecommerce/service"
 	"github.com/google/uuid"
@@ -83,7 +84,7 @@ func (seedService *seedServiceImpl) SeedProducts(ctx context.Context) error {
 			ProductId:   uuid.New(),
 			Name:        "iPhone 15 Pro",
 			Description: "Latest iPhone with advanced camera system and titanium design",
-			Price:       999.99,
+			Price:       money.New(999, 99),
 			Stock:       50,
 			ImageUrl:    "https://example.com/iphone15pro.jpg",
 		},
@@ -91,7 +92,7 @@ func (seedService *seedServiceImpl) SeedProducts(ctx context.Context) error {
 			ProductId:   uuid.New(),
 			Name:        "Samsung Galaxy S24",
 			Description: "Premium Android smartphone with AI features",
-			Price:       899.99,
+			Price:       money.New(899, 99),
 			Stock:       30,
 			ImageUrl:    "https://example.com/galaxy-s24.jpg",
 		},
@@ -99,7 +100,7 @@ func (seedService *seedServiceImpl) SeedProducts(ctx context.Context) error {
 			ProductId:   uuid.New(),
 			Name:        "MacBook Pro 16-inch",
 			Description: "Professional laptop with M3 chip and stunning display",
-			Price:       2499.99,
+			Price:       money.New(2499, 99),
 			Stock:       20,
 			ImageUrl:    "https://example.com/macbook-pro.jpg",
 		},
@@ -107,7 +108,7 @@ func (seedService *seedServiceImpl) SeedProducts(ctx context.Context) error {
 			ProductId:   uuid.New(),
 			Name:        "Dell XPS 13",
 			Description: "Ultra-portable laptop with InfinityEdge display",
-			Price:       1299.99,
+			Price:       money.New(1299, 99),
 			Stock:       25,
 			ImageUrl:    "https://example.com/dell-xps13.jpg",
 		},
@@ -115,7 +116,7 @@ func (seedService *seedServiceImpl) SeedProducts(ctx context.Context) error {
 			ProductId:   uuid.New(),
 			Name:        "Sony WH-1000XM5",
 			Description: "Industry-leading noise canceling wireless headphones",
-			Price:       399.99,
+			Price:       money.New(399, 99),
 			Stock:       100,
 			ImageUrl:    "https://example.com/sony-wh1000xm5.jpg",
 		},
@@ -123,7 +124,7 @@ func (seedService *seedServiceImpl) SeedProducts(ctx context.Context) error {
 			ProductId:   uuid.New(),
 			Name:        "iPad Air",
 			Description: "Versatile tablet with M1 chip and all-screen design",
-			Price:       599.99,
+			Price:       money.New(599, 99),
 			Stock:       40,
 			ImageUrl:    "https://example.com/ipad-air.jpg",
 		},
@@ -131,7 +132,7 @@ func (seedService *seedServiceImpl) SeedProducts(ctx context.Context) error {
 			ProductId:   uuid.New(),
 			Name:        "Nintendo Switch OLED",
 			Description: "Gaming console with vibrant OLED screen",
-			Price:       349.99,
+			Price:       money.New(349, 99),
 			Stock:       60,
 			ImageUrl:    "https://example.com/switch-oled.jpg",
 		},
@@ -139,7 +140,7 @@ func (seedService *seedServiceImpl) SeedProducts(ctx context.Context) error {
 			ProductId:   uuid.New(),
 			Name:        "Apple Watch Series 9",
 			Description: "Advanced smartwatch with health monitoring features",
-			Price:       399.99,
+			Price:       money.New(399, 99),
 			Stock:       80,
 			ImageUrl:    "https://example.com/apple-watch9.jpg",
 		},
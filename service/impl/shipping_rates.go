@@ -0,0 +1,101 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"sort"
+)
+
+// cartWeight is the weight of a cart in kg. The cart must have CartItems.Product preloaded.
+func cartWeight(items []entity.CartItem) float64 {
+	var weight float64
+	for _, item := range items {
+		weight += item.Product.Weight * float64(item.Quantity)
+	}
+	return weight
+}
+
+// shippingCost prices a method for the weight and value of an order. It reports false when none of the
+// brackets of a weight or order value method covers the order.
+func shippingCost(method entity.ShippingMethod, weight float64, orderValue money.Money) (money.Money, bool) {
+	if method.RateType == "flat" {
+		return method.FlatFee, true
+	}
+	for _, rate := range method.Rates {
+		covered := weight >= rate.MinWeight && (rate.MaxWeight == nil || weight < *rate.MaxWeight)
+		if method.RateType == "order_value" {
+			covered = !orderValue.LessThan(rate.MinOrderValue) && (rate.MaxOrderValue == nil || orderValue.LessThan(*rate.MaxOrderValue))
+		}
+		if covered {
+			return rate.Fee, true
+		}
+	}
+	return money.Money{}, false
+}
+
+// quoteShipping lists the methods of a zone that can ship a cart, cheapest first. A free shipping
+// promotion makes every method free.
+func quoteShipping(zone entity.ShippingZone, county string, town string, cart entity.Cart, pricing cartPricing) model.ShippingQuoteModel {
+	quote := model.ShippingQuoteModel{
+		Zone:       zone.Name,
+		County:     county,
+		Town:       town,
+		Weight:     cartWeight(cart.CartItems),
+		OrderValue: pricing.Subtotal.Sub(pricing.DiscountTotal),
+		Options:    []model.ShippingOptionModel{},
+		Currency:   money.BaseCurrency,
+	}
+	for _, method := range zone.Methods {
+		cost, ok := shippingCost(method, quote.Weight, quote.OrderValue)
+		if !ok {
+			continue
+		}
+		quote.Options = append(quote.Options, newShippingOption(method, cost, pricing.FreeShipping))
+	}
+	sort.SliceStable(quote.Options, func(i, j int) bool {
+		return quote.Options[i].Cost.LessThan(quote.Options[j].Cost)
+	})
+	return quote
+}
+
+func newShippingOption(method entity.ShippingMethod, cost money.Money, freeShipping bool) model.ShippingOptionModel {
+	option := model.ShippingOptionModel{
+		MethodId: method.Id,
+		Name:     method.Name,
+		Type:     method.Type,
+		Cost:     cost,
+		MinDays:  method.MinDays,
+		MaxDays:  method.MaxDays,
+	}
+	if freeShipping {
+		option.OriginalCost = &cost
+		option.Cost = money.Money{}
+		option.FreeShipping = true
+	}
+	return option
+}
+
+func newOrderShippingModel(order entity.Order) *model.OrderShippingModel {
+	if order.ShippingMethod == nil {
+		return nil
+	}
+	shipping := &model.OrderShippingModel{
+		MethodId: order.ShippingMethodId,
+		Method:   *order.ShippingMethod,
+		Cost:     order.ShippingCost,
+	}
+	if order.ShippingType != nil {
+		shipping.Type = *order.ShippingType
+	}
+	if order.ShippingAddress != nil {
+		shipping.Address = *order.ShippingAddress
+	}
+	if order.ShippingCounty != nil {
+		shipping.County = *order.ShippingCounty
+	}
+	if order.ShippingTown != nil {
+		shipping.Town = *order.ShippingTown
+	}
+	return shipping
+}
@@ -0,0 +1,83 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testShippingMethods() []entity.ShippingMethod {
+	five := 5.0
+	two := money.New(2000, 0)
+	return []entity.ShippingMethod{
+		{Id: 1, Name: "Express", Type: "express", RateType: "flat", FlatFee: money.New(600, 0), MinDays: 1, MaxDays: 1},
+		{Id: 2, Name: "Standard", Type: "standard", RateType: "weight", MinDays: 2, MaxDays: 4, Rates: []entity.ShippingRate{
+			{MinWeight: 0, MaxWeight: &five, Fee: money.New(300, 0)},
+			{MinWeight: 5, Fee: money.New(450, 0)},
+		}},
+		{Id: 3, Name: "Pickup", Type: "pickup_station", RateType: "order_value", MinDays: 3, MaxDays: 5, Rates: []entity.ShippingRate{
+			{MaxOrderValue: &two, Fee: money.New(150, 0)},
+		}},
+	}
+}
+
+func TestShippingCost(t *testing.T) {
+	methods := testShippingMethods()
+
+	cases := map[string]struct {
+		method     entity.ShippingMethod
+		weight     float64
+		orderValue money.Money
+		cost       money.Money
+		ships      bool
+	}{
+		"flat whatever the order":         {method: methods[0], weight: 50, orderValue: money.New(90000, 0), cost: money.New(600, 0), ships: true},
+		"weight bracket":                  {method: methods[1], weight: 4.9, cost: money.New(300, 0), ships: true},
+		"weight bracket excludes its max": {method: methods[1], weight: 5, cost: money.New(450, 0), ships: true},
+		"order value bracket":             {method: methods[2], weight: 50, orderValue: money.New(1999, 99), cost: money.New(150, 0), ships: true},
+		"no bracket covers the order":     {method: methods[2], orderValue: money.New(2000, 0)},
+	}
+	for name, c := range cases {
+		cost, ships := shippingCost(c.method, c.weight, c.orderValue)
+		assert.Equal(t, c.cost, cost, name)
+		assert.Equal(t, c.ships, ships, name)
+	}
+}
+
+func TestQuoteShipping(t *testing.T) {
+	zone := entity.ShippingZone{Name: "Nairobi", Methods: testShippingMethods()}
+	cart := entity.Cart{CartItems: []entity.CartItem{
+		{Quantity: 2, Product: entity.Product{Weight: 1.5}},
+		{Quantity: 1, Product: entity.Product{Weight: 0.5}},
+	}}
+	pricing := cartPricing{Subtotal: money.New(2500, 0), DiscountTotal: money.New(600, 0)}
+
+	quote := quoteShipping(zone, "Nairobi", "Westlands", cart, pricing)
+
+	// The order value after discounts is in the pickup bracket; the cheapest method comes first
+	assert.Equal(t, model.ShippingQuoteModel{
+		Zone:       "Nairobi",
+		County:     "Nairobi",
+		Town:       "Westlands",
+		Weight:     3.5,
+		OrderValue: money.New(1900, 0),
+		Currency:   money.BaseCurrency,
+		Options: []model.ShippingOptionModel{
+			{MethodId: 3, Name: "Pickup", Type: "pickup_station", Cost: money.New(150, 0), MinDays: 3, MaxDays: 5},
+			{MethodId: 2, Name: "Standard", Type: "standard", Cost: money.New(300, 0), MinDays: 2, MaxDays: 4},
+			{MethodId: 1, Name: "Express", Type: "express", Cost: money.New(600, 0), MinDays: 1, MaxDays: 1},
+		},
+	}, quote)
+
+	pricing.FreeShipping = true
+	pricing.DiscountTotal = money.Money{}
+	quote = quoteShipping(zone, "Nairobi", "", cart, pricing)
+
+	// Free shipping makes every method free, in the order of the zone
+	assert.Equal(t, []model.ShippingOptionModel{
+		{MethodId: 1, Name: "Express", Type: "express", OriginalCost: amountOf(600), FreeShipping: true, MinDays: 1, MaxDays: 1},
+		{MethodId: 2, Name: "Standard", Type: "standard", OriginalCost: amountOf(300), FreeShipping: true, MinDays: 2, MaxDays: 4},
+	}, quote.Options)
+}
@@ -0,0 +1,267 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"sort"
+	"strings"
+	"time"
+)
+
+func NewShippingServiceImpl(shippingRepository *repository.ShippingRepository) service.ShippingService {
+	return &shippingServiceImpl{ShippingRepository: *shippingRepository}
+}
+
+type shippingServiceImpl struct {
+	repository.ShippingRepository
+}
+
+func (shippingService *shippingServiceImpl) CreateZone(ctx context.Context, request model.ShippingZoneCreateOrUpdateModel) model.ShippingZoneModel {
+	zone, err := shippingService.ShippingRepository.InsertZone(ctx, shippingService.newShippingZone(ctx, 0, request))
+	exception.PanicLogging(err)
+	return newShippingZoneModel(zone)
+}
+
+// UpdateZone renames a zone and replaces the counties and towns it delivers to.
+func (shippingService *shippingServiceImpl) UpdateZone(ctx context.Context, id uint, request model.ShippingZoneCreateOrUpdateModel) model.ShippingZoneModel {
+	shippingService.findZoneById(ctx, id)
+
+	zone := shippingService.newShippingZone(ctx, id, request)
+	zone.Id = id
+	zone, err := shippingService.ShippingRepository.UpdateZone(ctx, zone)
+	exception.PanicLogging(err)
+	return newShippingZoneModel(zone)
+}
+
+// DeleteZone removes a zone and its methods; orders keep the shipping they were charged.
+func (shippingService *shippingServiceImpl) DeleteZone(ctx context.Context, id uint) {
+	shippingService.findZoneById(ctx, id)
+	err := shippingService.ShippingRepository.DeleteZone(ctx, id)
+	exception.PanicLogging(err)
+}
+
+func (shippingService *shippingServiceImpl) FindZoneById(ctx context.Context, id uint) model.ShippingZoneModel {
+	return newShippingZoneModel(shippingService.findZoneById(ctx, id))
+}
+
+func (shippingService *shippingServiceImpl) FindZones(ctx context.Context) []model.ShippingZoneModel {
+	zones, err := shippingService.ShippingRepository.FindZones(ctx)
+	exception.PanicLogging(err)
+
+	responses := []model.ShippingZoneModel{}
+	for _, zone := range zones {
+		responses = append(responses, newShippingZoneModel(zone))
+	}
+	return responses
+}
+
+func (shippingService *shippingServiceImpl) CreateMethod(ctx context.Context, zoneId uint, request model.ShippingMethodCreateOrUpdateModel) model.ShippingMethodModel {
+	shippingService.findZoneById(ctx, zoneId)
+
+	method := newShippingMethod(request)
+	method.ZoneId = zoneId
+	method, err := shippingService.ShippingRepository.InsertMethod(ctx, method)
+	exception.PanicLogging(err)
+	return newShippingMethodModel(method)
+}
+
+// UpdateMethod changes a method and replaces its rate brackets; placed orders keep the cost they were charged.
+func (shippingService *shippingServiceImpl) UpdateMethod(ctx context.Context, id uint, request model.ShippingMethodCreateOrUpdateModel) model.ShippingMethodModel {
+	current := shippingService.findMethodById(ctx, id)
+
+	method := newShippingMethod(request)
+	method.Id = current.Id
+	method.ZoneId = current.ZoneId
+	method, err := shippingService.ShippingRepository.UpdateMethod(ctx, method)
+	exception.PanicLogging(err)
+	return newShippingMethodModel(method)
+}
+
+func (shippingService *shippingServiceImpl) DeleteMethod(ctx context.Context, id uint) {
+	shippingService.findMethodById(ctx, id)
+	err := shippingService.ShippingRepository.DeleteMethod(ctx, id)
+	exception.PanicLogging(err)
+}
+
+func (shippingService *shippingServiceImpl) findZoneById(ctx context.Context, id uint) entity.ShippingZone {
+	zone, err := shippingService.ShippingRepository.FindZoneById(ctx, id)
+	if err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+	return zone
+}
+
+func (shippingService *shippingServiceImpl) findMethodById(ctx context.Context, id uint) entity.ShippingMethod {
+	method, err := shippingService.ShippingRepository.FindMethodById(ctx, id)
+	if err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+	return method
+}
+
+// newShippingZone validates a request and builds the zone it describes. id is the zone being updated,
+// or 0 for a new one. A county or town can only belong to one zone.
+func (shippingService *shippingServiceImpl) newShippingZone(ctx context.Context, id uint, request model.ShippingZoneCreateOrUpdateModel) entity.ShippingZone {
+	common.Validate(request)
+
+	name := strings.TrimSpace(request.Name)
+	if existing, err := shippingService.ShippingRepository.FindZoneByName(ctx, name); err == nil && existing.Id != id {
+		panic(common.NewValidationError("Name", "this field is unique"))
+	}
+
+	zone := entity.ShippingZone{Name: name}
+	seen := map[string]bool{}
+	for _, location := range request.Locations {
+		county := strings.TrimSpace(location.County)
+		town := strings.TrimSpace(location.Town)
+		key := strings.ToLower(county + "/" + town)
+		if seen[key] {
+			panic(common.NewValidationError("Locations", "this field has unique counties and towns"))
+		}
+		seen[key] = true
+		if existing, err := shippingService.ShippingRepository.FindZoneLocation(ctx, county, town); err == nil && existing.ZoneId != id {
+			panic(common.NewValidationError("Locations", "this field has counties and towns of no other zone"))
+		}
+		zone.Locations = append(zone.Locations, entity.ShippingZoneLocation{County: county, Town: town})
+	}
+	return zone
+}
+
+// newShippingMethod validates a request and builds the method it describes. The brackets of weight and
+// order value methods must not overlap.
+func newShippingMethod(request model.ShippingMethodCreateOrUpdateModel) entity.ShippingMethod {
+	common.Validate(request)
+
+	method := entity.ShippingMethod{
+		Name:     strings.TrimSpace(request.Name),
+		Type:     request.Type,
+		RateType: request.RateType,
+		MinDays:  request.MinDays,
+		MaxDays:  request.MaxDays,
+		Active:   request.Active == nil || *request.Active,
+	}
+	if method.RateType == "flat" {
+		method.FlatFee = request.FlatFee
+		return method
+	}
+
+	if method.RateType == "order_value" {
+		method.Rates = newOrderValueRates(request.Rates)
+	} else {
+		method.Rates = newWeightRates(request.Rates)
+	}
+	return method
+}
+
+// newWeightRates checks that weight brackets do not overlap and keeps their weight bounds.
+func newWeightRates(requested []model.ShippingRateModel) []entity.ShippingRate {
+	rates := append([]model.ShippingRateModel{}, requested...)
+	sort.SliceStable(rates, func(i, j int) bool {
+		return rates[i].MinWeight < rates[j].MinWeight
+	})
+	var shippingRates []entity.ShippingRate
+	for i, rate := range rates {
+		if rate.MaxWeight != nil && *rate.MaxWeight <= rate.MinWeight {
+			panic(common.NewValidationError("Rates", "this field has max_weight after min_weight"))
+		}
+		if i > 0 && (rates[i-1].MaxWeight == nil || *rates[i-1].MaxWeight > rate.MinWeight) {
+			panic(common.NewValidationError("Rates", "this field has brackets that do not overlap"))
+		}
+		shippingRates = append(shippingRates, entity.ShippingRate{
+			MinWeight: rate.MinWeight,
+			MaxWeight: rate.MaxWeight,
+			Fee:       rate.Fee,
+		})
+	}
+	return shippingRates
+}
+
+// newOrderValueRates checks that order value brackets do not overlap and keeps their order value bounds.
+func newOrderValueRates(requested []model.ShippingRateModel) []entity.ShippingRate {
+	rates := append([]model.ShippingRateModel{}, requested...)
+	for i := range rates {
+		if rates[i].MinOrderValue == nil {
+			rates[i].MinOrderValue = &money.Money{}
+		}
+	}
+	sort.SliceStable(rates, func(i, j int) bool {
+		return rates[i].MinOrderValue.LessThan(*rates[j].MinOrderValue)
+	})
+	var shippingRates []entity.ShippingRate
+	for i, rate := range rates {
+		if rate.MaxOrderValue != nil && !rate.MaxOrderValue.GreaterThan(*rate.MinOrderValue) {
+			panic(common.NewValidationError("Rates", "this field has max_order_value after min_order_value"))
+		}
+		if i > 0 && (rates[i-1].MaxOrderValue == nil || rates[i-1].MaxOrderValue.GreaterThan(*rate.MinOrderValue)) {
+			panic(common.NewValidationError("Rates", "this field has brackets that do not overlap"))
+		}
+		shippingRates = append(shippingRates, entity.ShippingRate{
+			MinOrderValue: *rate.MinOrderValue,
+			MaxOrderValue: rate.MaxOrderValue,
+			Fee:           rate.Fee,
+		})
+	}
+	return shippingRates
+}
+
+func newShippingZoneModel(zone entity.ShippingZone) model.ShippingZoneModel {
+	zoneModel := model.ShippingZoneModel{
+		Id:        zone.Id,
+		Name:      zone.Name,
+		Locations: []model.ShippingLocationModel{},
+		Methods:   []model.ShippingMethodModel{},
+		CreatedAt: zone.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: zone.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, location := range zone.Locations {
+		zoneModel.Locations = append(zoneModel.Locations, model.ShippingLocationModel{
+			County: location.County,
+			Town:   location.Town,
+		})
+	}
+	for _, method := range zone.Methods {
+		zoneModel.Methods = append(zoneModel.Methods, newShippingMethodModel(method))
+	}
+	return zoneModel
+}
+
+func newShippingMethodModel(method entity.ShippingMethod) model.ShippingMethodModel {
+	methodModel := model.ShippingMethodModel{
+		Id:        method.Id,
+		ZoneId:    method.ZoneId,
+		Name:      method.Name,
+		Type:      method.Type,
+		RateType:  method.RateType,
+		FlatFee:   method.FlatFee,
+		Rates:     []model.ShippingRateModel{},
+		MinDays:   method.MinDays,
+		MaxDays:   method.MaxDays,
+		Active:    method.Active,
+		CreatedAt: method.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: method.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, rate := range method.Rates {
+		rateModel := model.ShippingRateModel{
+			MinWeight:     rate.MinWeight,
+			MaxWeight:     rate.MaxWeight,
+			MaxOrderValue: rate.MaxOrderValue,
+			Fee:           rate.Fee,
+		}
+		if method.RateType == "order_value" {
+			minOrderValue := rate.MinOrderValue
+			rateModel.MinOrderValue = &minOrderValue
+		}
+		methodModel.Rates = append(methodModel.Rates, rateModel)
+	}
+	return methodModel
+}
@@ -0,0 +1,127 @@
+package impl
+
+import (
+	"errors"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+)
+
+const defaultTaxClass = "standard"
+
+// lineTax is the VAT of one cart line, charged on the line after its share of the discounts.
+type lineTax struct {
+	TaxClass       string
+	Rate           float64
+	DiscountAmount money.Money
+	TaxAmount      money.Money
+}
+
+// applyTax charges VAT per line at the rate of the product's tax class and sums it per tax class.
+// With tax-inclusive prices the VAT is the part of the line amount above its net amount; otherwise it
+// is added on top of the line amount. Products of an unknown tax class are taxed at the standard
+// rate; without a standard tax class, which the tax migration seeds, pricing fails rather than
+// charging no VAT.
+func applyTax(pricing *cartPricing, items []entity.CartItem, taxClasses []entity.TaxClass, pricesIncludeTax bool) error {
+	classes := map[string]entity.TaxClass{}
+	for _, taxClass := range taxClasses {
+		classes[taxClass.Code] = taxClass
+	}
+
+	pricing.PricesIncludeTax = pricesIncludeTax
+	pricing.Lines = map[uint]lineTax{}
+	pricing.Taxes = []model.TaxBreakdownModel{}
+	breakdowns := map[string]int{} // index in pricing.Taxes by tax class
+	discounts := allocateDiscounts(items, pricing.Discounts)
+
+	for _, item := range items {
+		code := item.Product.TaxClass
+		if _, found := classes[code]; !found {
+			code = defaultTaxClass
+		}
+		taxClass, found := classes[code]
+		if !found {
+			return errors.New("tax class " + defaultTaxClass + " is missing")
+		}
+
+		amount := item.Price.Mul(item.Quantity).Sub(discounts[item.Id])
+		line := lineTax{TaxClass: code, DiscountAmount: discounts[item.Id]}
+		if !taxClass.Exempt {
+			line.Rate = taxClass.Rate
+		}
+		netAmount := amount
+		if pricesIncludeTax {
+			line.TaxAmount = amount.PercentIncluded(line.Rate)
+			netAmount = amount.Sub(line.TaxAmount)
+		} else {
+			line.TaxAmount = amount.Percent(line.Rate)
+		}
+		pricing.Lines[item.Id] = line
+		pricing.TaxTotal = pricing.TaxTotal.Add(line.TaxAmount)
+
+		index, found := breakdowns[code]
+		if !found {
+			index = len(pricing.Taxes)
+			breakdowns[code] = index
+			pricing.Taxes = append(pricing.Taxes, model.TaxBreakdownModel{
+				TaxClass: code,
+				Name:     taxClass.Name,
+				Rate:     line.Rate,
+				Exempt:   taxClass.Exempt,
+			})
+		}
+		breakdown := &pricing.Taxes[index]
+		breakdown.NetAmount = breakdown.NetAmount.Add(netAmount)
+		breakdown.TaxAmount = breakdown.TaxAmount.Add(line.TaxAmount)
+	}
+	return nil
+}
+
+// allocateDiscounts spreads every discount over the cart items its promotion covers, in proportion to
+// their amounts, so each line is taxed on what the customer pays for it.
+func allocateDiscounts(items []entity.CartItem, discounts []promotionDiscount) map[uint]money.Money {
+	allocated := map[uint]money.Money{}
+	for _, discount := range discounts {
+		if discount.Amount.IsZero() {
+			continue
+		}
+
+		var covered []entity.CartItem
+		var amounts []money.Money
+		for _, item := range items {
+			if promotionCovers(discount.Promotion, item) {
+				covered = append(covered, item)
+				amounts = append(amounts, item.Price.Mul(item.Quantity))
+			}
+		}
+
+		// The shares add up to the discount exactly
+		for i, share := range discount.Amount.Allocate(amounts) {
+			allocated[covered[i].Id] = allocated[covered[i].Id].Add(share)
+		}
+	}
+	return allocated
+}
+
+func newOrderTaxModels(taxes []entity.OrderTax) []model.TaxBreakdownModel {
+	taxModels := []model.TaxBreakdownModel{}
+	for _, tax := range taxes {
+		taxModels = append(taxModels, model.TaxBreakdownModel{
+			TaxClass:  tax.TaxClass,
+			Name:      tax.Name,
+			Rate:      tax.Rate,
+			Exempt:    tax.Exempt,
+			NetAmount: tax.NetAmount,
+			TaxAmount: tax.TaxAmount,
+		})
+	}
+	return taxModels
+}
+
+// orderItemTaxClass is empty for items of orders placed before tax was charged.
+func orderItemTaxClass(item entity.OrderItem) string {
+	if item.TaxClass == nil {
+		return ""
+	}
+	return *item.TaxClass
+}
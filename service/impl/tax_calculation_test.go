@@ -0,0 +1,95 @@
+package impl
+
+import (
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testTaxClasses = []entity.TaxClass{
+	{Code: "standard", Name: "Standard VAT", Rate: 16},
+	{Code: "zero_rated", Name: "Zero-rated"},
+	{Code: "exempt", Name: "Exempt", Exempt: true},
+}
+
+func testTaxItems() []entity.CartItem {
+	return []entity.CartItem{
+		{Id: 1, ProductId: "phone", Quantity: 1, Price: money.New(1000, 0), Product: entity.Product{TaxClass: "standard"}},
+		{Id: 2, ProductId: "milk", Quantity: 2, Price: money.New(200, 0), Product: entity.Product{TaxClass: "zero_rated"}},
+		{Id: 3, ProductId: "lamp", Quantity: 1, Price: money.New(500, 0), Product: entity.Product{TaxClass: "retired"}},
+		{Id: 4, ProductId: "book", Quantity: 1, Price: money.New(300, 0), Product: entity.Product{TaxClass: "exempt"}},
+	}
+}
+
+func TestApplyTax_Exclusive(t *testing.T) {
+	phone := "phone"
+	pricing := cartPricing{Discounts: []promotionDiscount{
+		{Promotion: entity.Promotion{Scope: "product", ScopeProductId: &phone}, Amount: money.New(100, 0)},
+	}}
+
+	assert.NoError(t, applyTax(&pricing, testTaxItems(), testTaxClasses, false))
+
+	assert.Equal(t, lineTax{TaxClass: "standard", Rate: 16, DiscountAmount: money.New(100, 0), TaxAmount: money.New(144, 0)}, pricing.Lines[1])
+	assert.Equal(t, lineTax{TaxClass: "zero_rated"}, pricing.Lines[2])
+	assert.Equal(t, lineTax{TaxClass: "standard", Rate: 16, TaxAmount: money.New(80, 0)}, pricing.Lines[3]) // unknown class
+	assert.Equal(t, lineTax{TaxClass: "exempt"}, pricing.Lines[4])
+	assert.Equal(t, money.New(224, 0), pricing.TaxTotal)
+	assert.Equal(t, []model.TaxBreakdownModel{
+		{TaxClass: "standard", Name: "Standard VAT", Rate: 16, NetAmount: money.New(1400, 0), TaxAmount: money.New(224, 0)},
+		{TaxClass: "zero_rated", Name: "Zero-rated", NetAmount: money.New(400, 0)},
+		{TaxClass: "exempt", Name: "Exempt", Exempt: true, NetAmount: money.New(300, 0)},
+	}, pricing.Taxes)
+}
+
+func TestApplyTax_Inclusive(t *testing.T) {
+	phone := "phone"
+	pricing := cartPricing{Discounts: []promotionDiscount{
+		{Promotion: entity.Promotion{Scope: "product", ScopeProductId: &phone}, Amount: money.New(100, 0)},
+	}}
+
+	assert.NoError(t, applyTax(&pricing, testTaxItems(), testTaxClasses, true))
+
+	// 16% VAT included in 900.00 and 500.00
+	assert.Equal(t, money.New(124, 14), pricing.Lines[1].TaxAmount)
+	assert.Equal(t, money.New(68, 97), pricing.Lines[3].TaxAmount)
+	assert.Equal(t, money.New(193, 11), pricing.TaxTotal)
+	assert.Equal(t, money.New(1206, 89), pricing.Taxes[0].NetAmount)
+	assert.True(t, pricing.PricesIncludeTax)
+}
+
+func TestApplyTax_MissingStandardClass(t *testing.T) {
+	var pricing cartPricing
+	err := applyTax(&pricing, testTaxItems(), testTaxClasses[1:], false)
+	assert.Error(t, err)
+}
+
+func TestAllocateDiscounts(t *testing.T) {
+	accessories := "accessories"
+	items := []entity.CartItem{
+		{Id: 1, Quantity: 1, Price: money.New(600, 0), Product: entity.Product{Category: "phones"}},
+		{Id: 2, Quantity: 3, Price: money.New(100, 0), Product: entity.Product{Category: "accessories"}},
+		{Id: 3, Quantity: 1, Price: money.New(100, 0), Product: entity.Product{Category: "accessories"}},
+	}
+	discounts := []promotionDiscount{
+		{Promotion: entity.Promotion{Scope: "order"}, Amount: money.New(100, 0)},
+		{Promotion: entity.Promotion{Scope: "category", ScopeCategory: &accessories}, Amount: money.New(40, 0)},
+		{Promotion: entity.Promotion{Type: "free_shipping", Scope: "order"}},
+	}
+
+	assert.Equal(t, map[uint]money.Money{
+		1: money.New(60, 0),
+		2: money.New(30, 0).Add(money.New(30, 0)),
+		3: money.New(10, 0).Add(money.New(10, 0)),
+	}, allocateDiscounts(items, discounts))
+
+	// Rounding leaves the last line the cents that make the shares add up
+	even := []entity.CartItem{
+		{Id: 1, Quantity: 1, Price: money.New(100, 0)},
+		{Id: 2, Quantity: 1, Price: money.New(100, 0)},
+		{Id: 3, Quantity: 1, Price: money.New(100, 0)},
+	}
+	assert.Equal(t, map[uint]money.Money{1: money.New(33, 33), 2: money.New(33, 33), 3: money.New(33, 34)},
+		allocateDiscounts(even, discounts[:1]))
+}
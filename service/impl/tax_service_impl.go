@@ -0,0 +1,82 @@
+package impl
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/exception"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"time"
+)
+
+func NewTaxServiceImpl(taxRepository *repository.TaxRepository) service.TaxService {
+	return &taxServiceImpl{TaxRepository: *taxRepository}
+}
+
+type taxServiceImpl struct {
+	repository.TaxRepository
+}
+
+func (taxService *taxServiceImpl) Create(ctx context.Context, request model.TaxClassCreateOrUpdateModel) model.TaxClassModel {
+	common.Validate(request)
+	if request.Code == "" {
+		panic(common.NewValidationError("Code", "this field is required"))
+	}
+	if _, err := taxService.TaxRepository.FindByCode(ctx, request.Code); err == nil {
+		panic(common.NewValidationError("Code", "this field is unique"))
+	}
+
+	taxClass, err := taxService.TaxRepository.Insert(ctx, newTaxClass(request.Code, request))
+	exception.PanicLogging(err)
+	return newTaxClassModel(taxClass)
+}
+
+// Update changes the rate of a tax class for carts and new orders; placed orders keep their tax amounts.
+func (taxService *taxServiceImpl) Update(ctx context.Context, code string, request model.TaxClassCreateOrUpdateModel) model.TaxClassModel {
+	common.Validate(request)
+	if _, err := taxService.TaxRepository.FindByCode(ctx, code); err != nil {
+		panic(exception.NotFoundError{
+			Message: err.Error(),
+		})
+	}
+
+	taxClass, err := taxService.TaxRepository.Update(ctx, newTaxClass(code, request))
+	exception.PanicLogging(err)
+	return newTaxClassModel(taxClass)
+}
+
+func (taxService *taxServiceImpl) FindAll(ctx context.Context) []model.TaxClassModel {
+	taxClasses, err := taxService.TaxRepository.FindAll(ctx)
+	exception.PanicLogging(err)
+
+	responses := []model.TaxClassModel{}
+	for _, taxClass := range taxClasses {
+		responses = append(responses, newTaxClassModel(taxClass))
+	}
+	return responses
+}
+
+func newTaxClass(code string, request model.TaxClassCreateOrUpdateModel) entity.TaxClass {
+	taxClass := entity.TaxClass{
+		Code:   code,
+		Name:   request.Name,
+		Rate:   request.Rate,
+		Exempt: request.Exempt,
+	}
+	if taxClass.Exempt {
+		taxClass.Rate = 0
+	}
+	return taxClass
+}
+
+func newTaxClassModel(taxClass entity.TaxClass) model.TaxClassModel {
+	return model.TaxClassModel{
+		Code:      taxClass.Code,
+		Name:      taxClass.Name,
+		Rate:      taxClass.Rate,
+		Exempt:    taxClass.Exempt,
+		UpdatedAt: taxClass.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/tech-hive/ecommerce/entity"
 	"github.com/tech-hive/ecommerce/exception"
 	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/money"
 	"github.com/tech-hive/ecommerce/repository"
 	"github.com/tech-hive/ecommerce/service"
 	"github.com/google/uuid"
@@ -23,10 +24,10 @@ func (transactionService *transactionServiceImpl) Create(ctx context.Context, tr
 	common.Validate(transactionModel)
 	uuidGenerate := uuid.New()
 	var transactionDetails []entity.TransactionDetail
-	var totalPrice int64 = 0
+	var totalPrice money.Money
 
 	for _, detail := range transactionModel.TransactionDetails {
-		totalPrice = totalPrice + detail.SubTotalPrice
+		totalPrice = totalPrice.Add(detail.SubTotalPrice)
 		transactionDetails = append(transactionDetails, entity.TransactionDetail{
 			TransactionId: uuidGenerate,
 			ProductId:     detail.ProductId,
@@ -89,8 +90,8 @@ func (transactionService *transactionServiceImpl) FindById(ctx context.Context,
 	}
 }
 
-func (transactionService *transactionServiceImpl) FindAll(ctx context.Context) (responses []model.TransactionModel) {
-	transactions := transactionService.TransactionRepository.FindAll(ctx)
+func (transactionService *transactionServiceImpl) FindAll(ctx context.Context, listQuery model.ListQueryModel) (responses []model.TransactionModel, pageInfo model.PageInfoModel) {
+	transactions, pageInfo := transactionService.TransactionRepository.FindAll(ctx, listQuery)
 	for _, transaction := range transactions {
 		var transactionDetails []model.TransactionDetailModel
 		for _, detail := range transaction.TransactionDetails {
@@ -117,5 +118,5 @@ func (transactionService *transactionServiceImpl) FindAll(ctx context.Context) (
 		})
 	}
 
-	return responses
+	return responses, pageInfo
 }
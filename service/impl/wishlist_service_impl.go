@@ -0,0 +1,76 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/tech-hive/ecommerce/common"
+	"github.com/tech-hive/ecommerce/entity"
+	"github.com/tech-hive/ecommerce/model"
+	"github.com/tech-hive/ecommerce/repository"
+	"github.com/tech-hive/ecommerce/service"
+	"gorm.io/gorm"
+	"time"
+)
+
+func NewWishlistServiceImpl(wishlistRepository *repository.WishlistRepository, productRepository *repository.ProductRepository) service.WishlistService {
+	return &wishlistServiceImpl{
+		WishlistRepository: *wishlistRepository,
+		ProductRepository:  *productRepository,
+	}
+}
+
+type wishlistServiceImpl struct {
+	repository.WishlistRepository
+	repository.ProductRepository
+}
+
+func (wishlistService *wishlistServiceImpl) Add(ctx context.Context, userId uint, request model.WishlistItemCreateModel) (model.WishlistItemModel, error) {
+	common.Validate(request)
+
+	if _, err := wishlistService.ProductRepository.FindByProductId(ctx, request.ProductId); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.WishlistItemModel{}, errors.New("product not found")
+		}
+		return model.WishlistItemModel{}, err
+	}
+
+	item := entity.WishlistItem{
+		UserId:            userId,
+		ProductId:         request.ProductId,
+		NotifyPriceDrop:   request.NotifyPriceDrop == nil || *request.NotifyPriceDrop,
+		NotifyBackInStock: request.NotifyBackInStock == nil || *request.NotifyBackInStock,
+	}
+	item, err := wishlistService.WishlistRepository.Save(ctx, item)
+	if err != nil {
+		return model.WishlistItemModel{}, err
+	}
+	return newWishlistItemModel(item), nil
+}
+
+func (wishlistService *wishlistServiceImpl) Remove(ctx context.Context, userId uint, productId string) error {
+	return wishlistService.WishlistRepository.Delete(ctx, userId, productId)
+}
+
+func (wishlistService *wishlistServiceImpl) FindAll(ctx context.Context, userId uint, listQuery model.ListQueryModel) ([]model.WishlistItemModel, model.PageInfoModel, error) {
+	items, pageInfo, err := wishlistService.WishlistRepository.FindAll(ctx, userId, listQuery)
+	if err != nil {
+		return nil, model.PageInfoModel{}, err
+	}
+
+	responses := []model.WishlistItemModel{}
+	for _, item := range items {
+		responses = append(responses, newWishlistItemModel(item))
+	}
+	return responses, pageInfo, nil
+}
+
+func newWishlistItemModel(item entity.WishlistItem) model.WishlistItemModel {
+	return model.WishlistItemModel{
+		Id:                item.Id,
+		ProductId:         item.ProductId,
+		Product:           newProductModel(item.Product),
+		NotifyPriceDrop:   item.NotifyPriceDrop,
+		NotifyBackInStock: item.NotifyBackInStock,
+		CreatedAt:         item.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type OrderAdminService interface {
+	FindAll(ctx context.Context, search model.OrderSearchModel) ([]model.AdminOrderModel, model.PageInfoModel)
+	FindById(ctx context.Context, orderId uint) model.AdminOrderModel
+	AddNote(ctx context.Context, orderId uint, authorId uint, request model.OrderNoteCreateModel) model.OrderNoteModel
+	UpdateStatuses(ctx context.Context, request model.OrderBulkStatusModel) model.OrderBulkStatusResultModel
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type OrderDocumentService interface {
+	GetDocument(ctx context.Context, orderId uint, userId uint, documentType string) (model.OrderDocumentModel, error)
+	GetAnyDocument(ctx context.Context, orderId uint, documentType string) (model.OrderDocumentModel, error)
+}
@@ -0,0 +1,7 @@
+package service
+
+import "context"
+
+type OrderExpiryService interface {
+	ExpireUnpaid(ctx context.Context) error
+}
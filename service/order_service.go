@@ -8,7 +8,9 @@ import (
 type OrderService interface {
 	CreateOrder(ctx context.Context, userId uint, request model.CreateOrderModel) (model.OrderModel, error)
 	GetOrderById(ctx context.Context, orderId uint, userId uint) (model.OrderModel, error)
-	GetOrdersByUserId(ctx context.Context, userId uint) ([]model.OrderModel, error)
+	GetOrderByNumber(ctx context.Context, number string) (model.OrderModel, error)
+	GetOrdersByUserId(ctx context.Context, userId uint, listQuery model.ListQueryModel) ([]model.OrderModel, model.PageInfoModel, error)
 	UpdateOrderStatus(ctx context.Context, orderId uint, request model.UpdateOrderStatusModel) (model.OrderModel, error)
 	CancelOrder(ctx context.Context, orderId uint, userId uint) error
+	Reorder(ctx context.Context, orderId uint, userId uint) (model.ReorderResultModel, error)
 }
\ No newline at end of file
@@ -0,0 +1,7 @@
+package service
+
+import "context"
+
+type ProductAlertService interface {
+	DispatchPending(ctx context.Context) error
+}
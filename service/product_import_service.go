@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+	"io"
+)
+
+type ProductImportService interface {
+	Import(ctx context.Context, format string, data []byte) model.ProductImportJobModel
+	FindJobById(ctx context.Context, id string) model.ProductImportJobModel
+	Export(ctx context.Context, format string, writer io.Writer) error
+}
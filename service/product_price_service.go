@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type ProductPriceService interface {
+	CreateSchedule(ctx context.Context, productId string, request model.ProductPriceScheduleCreateModel) model.ProductPriceScheduleModel
+	FindSchedules(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]model.ProductPriceScheduleModel, model.PageInfoModel)
+	CancelSchedule(ctx context.Context, id uint) model.ProductPriceScheduleModel
+	FindHistory(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]model.ProductPriceHistoryModel, model.PageInfoModel)
+	ApplyDueSchedules(ctx context.Context) error
+}
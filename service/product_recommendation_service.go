@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type ProductRecommendationService interface {
+	FindByProductId(ctx context.Context, productId string) model.ProductRecommendationModel
+	Refresh(ctx context.Context) error
+}
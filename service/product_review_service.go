@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type ProductReviewService interface {
+	Create(ctx context.Context, userId uint, productId string, request model.ProductReviewCreateModel) (model.ProductReviewModel, error)
+	FindByProductId(ctx context.Context, productId string, listQuery model.ListQueryModel) ([]model.ProductReviewModel, model.PageInfoModel, error)
+	FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]model.ProductReviewModel, model.PageInfoModel, error)
+	Moderate(ctx context.Context, reviewId uint, request model.ProductReviewModerationModel) (model.ProductReviewModel, error)
+}
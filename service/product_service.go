@@ -9,7 +9,10 @@ type ProductService interface {
   	Create(ctx context.Context, model model.ProductCreateOrUpdateModel) model.ProductCreateOrUpdateModel
   	Update(ctx context.Context, productModel model.ProductCreateOrUpdateModel, id string) model.ProductCreateOrUpdateModel
   	Delete(ctx context.Context, id string)
+  	Restore(ctx context.Context, id string) model.ProductModel
+  	Purge(ctx context.Context, id string)
   	FindById(ctx context.Context, id string) model.ProductModel
-  	FindAll(ctx context.Context) ([]model.ProductModel, int64)
-  	Search(ctx context.Context, searchModel model.ProductSearchModel) ([]model.ProductModel, int64)
+  	FindAll(ctx context.Context, searchModel model.ProductSearchModel) ([]model.ProductModel, model.PageInfoModel)
+  	Search(ctx context.Context, searchModel model.ProductSearchModel) ([]model.ProductModel, model.PageInfoModel)
+  	FindArchived(ctx context.Context, listQuery model.ListQueryModel) ([]model.ProductModel, model.PageInfoModel)
   }
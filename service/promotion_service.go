@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type PromotionService interface {
+	Create(ctx context.Context, request model.PromotionCreateOrUpdateModel) model.PromotionModel
+	Update(ctx context.Context, id uint, request model.PromotionCreateOrUpdateModel) model.PromotionModel
+	Delete(ctx context.Context, id uint)
+	FindById(ctx context.Context, id uint) model.PromotionModel
+	FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]model.PromotionModel, model.PageInfoModel)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type ShippingService interface {
+	CreateZone(ctx context.Context, request model.ShippingZoneCreateOrUpdateModel) model.ShippingZoneModel
+	UpdateZone(ctx context.Context, id uint, request model.ShippingZoneCreateOrUpdateModel) model.ShippingZoneModel
+	DeleteZone(ctx context.Context, id uint)
+	FindZoneById(ctx context.Context, id uint) model.ShippingZoneModel
+	FindZones(ctx context.Context) []model.ShippingZoneModel
+	CreateMethod(ctx context.Context, zoneId uint, request model.ShippingMethodCreateOrUpdateModel) model.ShippingMethodModel
+	UpdateMethod(ctx context.Context, id uint, request model.ShippingMethodCreateOrUpdateModel) model.ShippingMethodModel
+	DeleteMethod(ctx context.Context, id uint)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type TaxService interface {
+	Create(ctx context.Context, request model.TaxClassCreateOrUpdateModel) model.TaxClassModel
+	Update(ctx context.Context, code string, request model.TaxClassCreateOrUpdateModel) model.TaxClassModel
+	FindAll(ctx context.Context) []model.TaxClassModel
+}
@@ -9,5 +9,5 @@ type TransactionService interface {
 	Create(ctx context.Context, model model.TransactionCreateUpdateModel) model.TransactionCreateUpdateModel
 	Delete(ctx context.Context, id string)
 	FindById(ctx context.Context, id string) model.TransactionModel
-	FindAll(ctx context.Context) []model.TransactionModel
+	FindAll(ctx context.Context, listQuery model.ListQueryModel) ([]model.TransactionModel, model.PageInfoModel)
 }
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"github.com/tech-hive/ecommerce/model"
+)
+
+type WishlistService interface {
+	Add(ctx context.Context, userId uint, request model.WishlistItemCreateModel) (model.WishlistItemModel, error)
+	Remove(ctx context.Context, userId uint, productId string) error
+	FindAll(ctx context.Context, userId uint, listQuery model.ListQueryModel) ([]model.WishlistItemModel, model.PageInfoModel, error)
+}